@@ -0,0 +1,492 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ack keeps the acknowledgements of alerts and aggregation groups.
+// Acknowledgements are shared by the peers of a cluster.
+package ack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/snapshot"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// ErrNotFound is returned if an acknowledgement was not found.
+var ErrNotFound = errors.New("acknowledgement not found")
+
+// Ack acknowledges an alert, identified by its fingerprint, or all the alerts
+// of an aggregation group, identified by its group key.
+type Ack struct {
+	ID          string    `json:"id"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	GroupKey    string    `json:"groupKey,omitempty"`
+	By          string    `json:"by"`
+	Comment     string    `json:"comment,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Active returns whether the acknowledgement is in effect at the given time.
+func (a *Ack) Active(now time.Time) bool {
+	return a.ExpiresAt.After(now)
+}
+
+// Acknowledgement returns the acknowledgement as recorded by the marker.
+func (a *Ack) Acknowledgement() *types.Acknowledgement {
+	return &types.Acknowledgement{
+		ID:        a.ID,
+		By:        a.By,
+		Comment:   a.Comment,
+		CreatedAt: a.CreatedAt,
+		ExpiresAt: a.ExpiresAt,
+	}
+}
+
+// Validate checks the acknowledgement.
+func (a *Ack) Validate() error {
+	if (a.Fingerprint == "") == (a.GroupKey == "") {
+		return errors.New("exactly one of fingerprint and group key must be set")
+	}
+	if a.Fingerprint != "" {
+		if _, err := model.ParseFingerprint(a.Fingerprint); err != nil {
+			return errors.Errorf("invalid fingerprint %q", a.Fingerprint)
+		}
+	}
+	if a.By == "" {
+		return errors.New("missing author")
+	}
+	return nil
+}
+
+// Options configures the acknowledgements.
+type Options struct {
+	// SnapshotFile is the file the acknowledgements are persisted to by
+	// Maintenance. If empty, they are only kept in memory.
+	SnapshotFile string
+	// Retention is how long acknowledgements are kept after they expired.
+	// It is also the expiry of acknowledgements created without one.
+	Retention time.Duration
+	// Marker, if set, records the acknowledgements of the alerts as soon as
+	// they change.
+	Marker types.Marker
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	active                  prometheus.GaugeFunc
+	propagatedMessagesTotal prometheus.Counter
+}
+
+// Acks holds the acknowledgements by ID.
+type Acks struct {
+	opts      Options
+	logger    log.Logger
+	now       func() time.Time
+	metrics   *metrics
+	broadcast func([]byte)
+
+	mtx sync.RWMutex
+	st  state
+}
+
+type state map[string]*Ack
+
+// merge merges the acknowledgement into the state and returns whether it
+// changed the state. The most recently updated acknowledgement wins.
+func (s state) merge(a *Ack, now time.Time, retention time.Duration) bool {
+	if !a.ExpiresAt.Add(retention).After(now) {
+		return false
+	}
+	prev, ok := s[a.ID]
+	if ok && !prev.UpdatedAt.Before(a.UpdatedAt) {
+		return false
+	}
+	s[a.ID] = a
+	return true
+}
+
+func (s state) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, a := range s {
+		if err := enc.Encode(a); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeState(r io.Reader) (state, error) {
+	st := state{}
+	dec := json.NewDecoder(r)
+	for {
+		var a Ack
+		if err := dec.Decode(&a); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		st[a.ID] = &a
+	}
+	return st, nil
+}
+
+// New returns new acknowledgements, loading them from the snapshot file if
+// it exists.
+func New(o Options) (*Acks, error) {
+	if o.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	a := &Acks{
+		opts:      o,
+		logger:    o.Logger,
+		now:       func() time.Time { return time.Now().UTC() },
+		broadcast: func([]byte) {},
+		st:        state{},
+	}
+	a.metrics = &metrics{
+		active: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_acks_active",
+			Help: "Number of active acknowledgements.",
+		}, func() float64 {
+			return float64(len(a.List(true)))
+		}),
+		propagatedMessagesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_acks_gossip_messages_propagated_total",
+			Help: "Number of received gossip messages that have been further gossiped.",
+		}),
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(a.metrics.active, a.metrics.propagatedMessagesTotal)
+	}
+
+	if o.SnapshotFile == "" {
+		return a, nil
+	}
+	f, err := os.Open(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return a, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	st, err := decodeState(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load acknowledgements snapshot")
+	}
+	now := a.now()
+	for _, e := range st {
+		a.st.merge(e, now, o.Retention)
+	}
+	return a, nil
+}
+
+// Create records a new acknowledgement and returns its ID. If the expiry of
+// the acknowledgement isn't set, it expires after the retention.
+func (a *Acks) Create(ack Ack) (string, error) {
+	if err := ack.Validate(); err != nil {
+		return "", err
+	}
+	now := a.now()
+	if ack.ExpiresAt.IsZero() {
+		ack.ExpiresAt = now.Add(a.opts.Retention)
+	}
+	if !ack.ExpiresAt.After(now) {
+		return "", errors.New("expiry must be in the future")
+	}
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return "", errors.Wrap(err, "generate uuid")
+	}
+	ack.ID = uid.String()
+	ack.CreatedAt = now
+	ack.UpdatedAt = now
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if err := a.setAck(&ack); err != nil {
+		return "", err
+	}
+	return ack.ID, nil
+}
+
+// Expire ends the acknowledgement with the given ID.
+func (a *Acks) Expire(id string) error {
+	now := a.now()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	prev, ok := a.st[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if !prev.Active(now) {
+		return errors.New("acknowledgement already expired")
+	}
+	ack := *prev
+	ack.ExpiresAt = now
+	ack.UpdatedAt = now
+	return a.setAck(&ack)
+}
+
+// setAck stores the acknowledgement and gossips it. The mutex must be held.
+func (a *Acks) setAck(ack *Ack) error {
+	b, err := state{ack.ID: ack}.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	a.st[ack.ID] = ack
+	a.mark(ack.Fingerprint, a.now())
+	a.broadcast(b)
+	return nil
+}
+
+// mark records the active acknowledgement of the alert, if any, in the
+// marker. The mutex must be held.
+func (a *Acks) mark(fingerprint string, now time.Time) {
+	if a.opts.Marker == nil || fingerprint == "" {
+		return
+	}
+	fp, err := model.ParseFingerprint(fingerprint)
+	if err != nil {
+		return
+	}
+	if ack, ok := a.lookup("", fp, now); ok {
+		a.opts.Marker.SetAcknowledged(fp, ack.Acknowledgement())
+		return
+	}
+	a.opts.Marker.SetAcknowledged(fp, nil)
+}
+
+// Get returns the acknowledgement with the given ID.
+func (a *Acks) Get(id string) (Ack, error) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	ack, ok := a.st[id]
+	if !ok {
+		return Ack{}, ErrNotFound
+	}
+	return *ack, nil
+}
+
+// List returns the acknowledgements, from the most recent to the oldest. If
+// active is true, only the active acknowledgements are returned.
+func (a *Acks) List(active bool) []Ack {
+	now := a.now()
+
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	res := []Ack{}
+	for _, ack := range a.st {
+		if active && !ack.Active(now) {
+			continue
+		}
+		res = append(res, *ack)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].ID < res[j].ID
+		}
+		return res[i].CreatedAt.After(res[j].CreatedAt)
+	})
+	return res
+}
+
+// Lookup returns the active acknowledgement of the alert, or else of the
+// group, if any. An empty group key only looks up the acknowledgements of
+// the alert.
+func (a *Acks) Lookup(groupKey string, fp model.Fingerprint) (*Ack, bool) {
+	now := a.now()
+
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	return a.lookup(groupKey, fp, now)
+}
+
+// lookup implements Lookup. The mutex must be held.
+func (a *Acks) lookup(groupKey string, fp model.Fingerprint, now time.Time) (*Ack, bool) {
+	alert := fp.String()
+
+	var res *Ack
+	for _, ack := range a.st {
+		if !ack.Active(now) {
+			continue
+		}
+		switch {
+		case ack.Fingerprint == alert:
+			// Acknowledgements of the alert take precedence.
+			if res == nil || res.GroupKey != "" || ack.CreatedAt.After(res.CreatedAt) {
+				res = ack
+			}
+		case groupKey != "" && ack.GroupKey == groupKey:
+			if res == nil || (res.GroupKey != "" && ack.CreatedAt.After(res.CreatedAt)) {
+				res = ack
+			}
+		}
+	}
+	if res == nil {
+		return nil, false
+	}
+	c := *res
+	return &c, true
+}
+
+// GC removes the acknowledgements expired for longer than the retention, and
+// clears the expired acknowledgements of the alerts from the marker.
+func (a *Acks) GC() int {
+	now := a.now()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	var n int
+	for id, ack := range a.st {
+		if !ack.Active(now) {
+			a.mark(ack.Fingerprint, now)
+		}
+		if !ack.ExpiresAt.Add(a.opts.Retention).After(now) {
+			delete(a.st, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Snapshot writes the acknowledgements to w.
+func (a *Acks) Snapshot(w io.Writer) (int64, error) {
+	b, err := a.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, bytes.NewReader(b))
+}
+
+// Maintenance garbage collects the acknowledgements and persists them to the
+// snapshot file every interval, and once more when stopc is closed.
+func (a *Acks) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	snapshot.Maintain(interval, stopc, func() {
+		a.GC()
+		if a.opts.SnapshotFile == "" {
+			return
+		}
+		if err := snapshot.Write(a.opts.SnapshotFile, func(w io.Writer) error {
+			_, err := a.Snapshot(w)
+			return err
+		}); err != nil {
+			level.Error(a.logger).Log("msg", "Failed to persist the acknowledgements", "err", err)
+		}
+	})
+}
+
+// MarshalBinary serializes the acknowledgements.
+func (a *Acks) MarshalBinary() ([]byte, error) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	return a.st.MarshalBinary()
+}
+
+// Merge merges acknowledgements received from the cluster with the local
+// ones.
+func (a *Acks) Merge(b []byte) error {
+	st, err := decodeState(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	now := a.now()
+
+	for _, e := range st {
+		merged := a.st.merge(e, now, a.opts.Retention)
+		if merged {
+			a.mark(e.Fingerprint, now)
+		}
+		if merged && !cluster.OversizedMessage(b) {
+			// If this is the first we've seen the message and it's
+			// not oversized, gossip it to other nodes. We don't
+			// propagate oversized messages because they're sent to
+			// all nodes already.
+			a.broadcast(b)
+			a.metrics.propagatedMessagesTotal.Inc()
+			level.Debug(a.logger).Log("msg", "gossiping new acknowledgement", "id", e.ID)
+		}
+	}
+	return nil
+}
+
+// SetBroadcast sets a broadcast callback that will be invoked with serialized
+// state on updates.
+func (a *Acks) SetBroadcast(f func([]byte)) {
+	a.mtx.Lock()
+	a.broadcast = f
+	a.mtx.Unlock()
+}
+
+// Acker marks alerts as acknowledged.
+type Acker struct {
+	acks   *Acks
+	marker types.Marker
+}
+
+// NewAcker returns a new Acker.
+func NewAcker(a *Acks, m types.Marker) *Acker {
+	return &Acker{acks: a, marker: m}
+}
+
+// Acknowledged returns the active acknowledgement of the alert in the group,
+// if any. An empty group key only considers the acknowledgements of the alert.
+// The marker is shared by the groups of the alert, so it only records the
+// acknowledgements of the alert itself.
+func (a *Acker) Acknowledged(groupKey string, fp model.Fingerprint) (*types.Acknowledgement, bool) {
+	ack, ok := a.acks.Lookup(groupKey, fp)
+	if !ok {
+		a.marker.SetAcknowledged(fp, nil)
+		return nil, false
+	}
+	res := ack.Acknowledgement()
+	if ack.GroupKey == "" {
+		a.marker.SetAcknowledged(fp, res)
+	} else {
+		a.marker.SetAcknowledged(fp, nil)
+	}
+	return res, true
+}
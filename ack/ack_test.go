@@ -0,0 +1,263 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ack
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestAcks(t *testing.T, snapshot string) *Acks {
+	a, err := New(Options{
+		SnapshotFile: snapshot,
+		Retention:    time.Hour,
+		Metrics:      prometheus.NewRegistry(),
+	})
+	require.NoError(t, err)
+	return a
+}
+
+func TestAckValidate(t *testing.T) {
+	for _, tc := range []struct {
+		ack Ack
+		err string
+	}{
+		{
+			ack: Ack{Fingerprint: "0000000000000001", By: "me"},
+		},
+		{
+			ack: Ack{GroupKey: "{}:{}", By: "me"},
+		},
+		{
+			ack: Ack{By: "me"},
+			err: "exactly one of fingerprint and group key must be set",
+		},
+		{
+			ack: Ack{Fingerprint: "0000000000000001", GroupKey: "{}:{}", By: "me"},
+			err: "exactly one of fingerprint and group key must be set",
+		},
+		{
+			ack: Ack{Fingerprint: "foo", By: "me"},
+			err: `invalid fingerprint "foo"`,
+		},
+		{
+			ack: Ack{GroupKey: "{}:{}"},
+			err: "missing author",
+		},
+	} {
+		err := tc.ack.Validate()
+		if tc.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestAcksCreateAndExpire(t *testing.T) {
+	a := newTestAcks(t, "")
+	var broadcasts int
+	a.SetBroadcast(func([]byte) { broadcasts++ })
+
+	now := time.Now().UTC()
+	a.now = func() time.Time { return now }
+
+	_, err := a.Create(Ack{GroupKey: "{}:{}", By: "me", ExpiresAt: now.Add(-time.Minute)})
+	require.EqualError(t, err, "expiry must be in the future")
+
+	id, err := a.Create(Ack{GroupKey: "{}:{}", By: "me", Comment: "on it"})
+	require.NoError(t, err)
+	require.Equal(t, 1, broadcasts)
+
+	ack, err := a.Get(id)
+	require.NoError(t, err)
+	require.Equal(t, now, ack.CreatedAt)
+	require.Equal(t, now.Add(time.Hour), ack.ExpiresAt)
+	require.True(t, ack.Active(now))
+	require.Len(t, a.List(true), 1)
+
+	require.NoError(t, a.Expire(id))
+	require.Equal(t, 2, broadcasts)
+	require.EqualError(t, a.Expire(id), "acknowledgement already expired")
+	require.Equal(t, ErrNotFound, a.Expire("unknown"))
+	require.Len(t, a.List(true), 0)
+	require.Len(t, a.List(false), 1)
+
+	// Expired acknowledgements are kept for the retention.
+	require.Equal(t, 0, a.GC())
+	a.now = func() time.Time { return now.Add(2 * time.Hour) }
+	require.Equal(t, 1, a.GC())
+	_, err = a.Get(id)
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestAcksLookup(t *testing.T) {
+	a := newTestAcks(t, "")
+	fp := model.Fingerprint(1)
+
+	_, ok := a.Lookup("{}:{}", fp)
+	require.False(t, ok)
+
+	groupID, err := a.Create(Ack{GroupKey: "{}:{}", By: "group"})
+	require.NoError(t, err)
+	ack, ok := a.Lookup("{}:{}", fp)
+	require.True(t, ok)
+	require.Equal(t, groupID, ack.ID)
+
+	// The group acknowledgement doesn't apply to other groups.
+	_, ok = a.Lookup("{}:{foo=\"bar\"}", fp)
+	require.False(t, ok)
+	_, ok = a.Lookup("", fp)
+	require.False(t, ok)
+
+	// Acknowledgements of the alert take precedence over the group's.
+	alertID, err := a.Create(Ack{Fingerprint: fp.String(), By: "alert"})
+	require.NoError(t, err)
+	ack, ok = a.Lookup("{}:{}", fp)
+	require.True(t, ok)
+	require.Equal(t, alertID, ack.ID)
+	ack, ok = a.Lookup("", fp)
+	require.True(t, ok)
+	require.Equal(t, alertID, ack.ID)
+
+	require.NoError(t, a.Expire(alertID))
+	ack, ok = a.Lookup("{}:{}", fp)
+	require.True(t, ok)
+	require.Equal(t, groupID, ack.ID)
+}
+
+func TestAcksMerge(t *testing.T) {
+	a := newTestAcks(t, "")
+	var broadcasts int
+	a.SetBroadcast(func([]byte) { broadcasts++ })
+
+	now := time.Now().UTC()
+	merge := func(ack *Ack) {
+		b, err := state{ack.ID: ack}.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, a.Merge(b))
+	}
+
+	merge(&Ack{ID: "1", GroupKey: "{}:{}", By: "me", CreatedAt: now, UpdatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	require.Equal(t, 1, broadcasts)
+	_, ok := a.Lookup("{}:{}", model.Fingerprint(1))
+	require.True(t, ok)
+
+	// Older updates are dropped.
+	merge(&Ack{ID: "1", GroupKey: "{}:{}", By: "me", CreatedAt: now, UpdatedAt: now.Add(-time.Minute), ExpiresAt: now})
+	require.Equal(t, 1, broadcasts)
+	_, ok = a.Lookup("{}:{}", model.Fingerprint(1))
+	require.True(t, ok)
+
+	// More recent updates win.
+	merge(&Ack{ID: "1", GroupKey: "{}:{}", By: "me", CreatedAt: now, UpdatedAt: now.Add(time.Minute), ExpiresAt: now})
+	require.Equal(t, 2, broadcasts)
+	_, ok = a.Lookup("{}:{}", model.Fingerprint(1))
+	require.False(t, ok)
+}
+
+func TestAcksSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "acks")
+	a := newTestAcks(t, snapshot)
+	id, err := a.Create(Ack{Fingerprint: model.Fingerprint(1).String(), By: "me"})
+	require.NoError(t, err)
+
+	stopc := make(chan struct{})
+	close(stopc)
+	a.Maintenance(time.Hour, stopc)
+
+	loaded := newTestAcks(t, snapshot)
+	ack, err := loaded.Get(id)
+	require.NoError(t, err)
+	require.Equal(t, "me", ack.By)
+}
+
+func TestAcker(t *testing.T) {
+	a := newTestAcks(t, "")
+	marker := types.NewMarker(prometheus.NewRegistry())
+	acker := NewAcker(a, marker)
+	fp := model.Fingerprint(1)
+
+	_, ok := acker.Acknowledged("{}:{}", fp)
+	require.False(t, ok)
+	_, ok = marker.Acknowledged(fp)
+	require.False(t, ok)
+
+	// The acknowledgements of a group only apply to the group, the marker
+	// doesn't record them.
+	id, err := a.Create(Ack{GroupKey: "{}:{}", By: "me"})
+	require.NoError(t, err)
+	res, ok := acker.Acknowledged("{}:{}", fp)
+	require.True(t, ok)
+	require.Equal(t, id, res.ID)
+	_, ok = marker.Acknowledged(fp)
+	require.False(t, ok)
+	_, ok = acker.Acknowledged("{}:{other}", fp)
+	require.False(t, ok)
+
+	id, err = a.Create(Ack{Fingerprint: fp.String(), By: "you"})
+	require.NoError(t, err)
+	res, ok = acker.Acknowledged("{}:{other}", fp)
+	require.True(t, ok)
+	require.Equal(t, id, res.ID)
+	res, ok = marker.Acknowledged(fp)
+	require.True(t, ok)
+	require.Equal(t, "you", res.By)
+
+	require.NoError(t, a.Expire(id))
+	_, ok = acker.Acknowledged("{}:{other}", fp)
+	require.False(t, ok)
+	_, ok = marker.Acknowledged(fp)
+	require.False(t, ok)
+}
+
+func TestAcksMarker(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	a, err := New(Options{Retention: time.Hour, Marker: marker})
+	require.NoError(t, err)
+	fp := model.Fingerprint(1)
+
+	// The marker is updated as soon as the acknowledgements change.
+	id, err := a.Create(Ack{Fingerprint: fp.String(), By: "me"})
+	require.NoError(t, err)
+	res, ok := marker.Acknowledged(fp)
+	require.True(t, ok)
+	require.Equal(t, id, res.ID)
+
+	require.NoError(t, a.Expire(id))
+	_, ok = marker.Acknowledged(fp)
+	require.False(t, ok)
+
+	// The acknowledgements of the peers are recorded too.
+	now := time.Now().UTC()
+	b, err := state{"peer": &Ack{ID: "peer", Fingerprint: fp.String(), By: "peer", CreatedAt: now, UpdatedAt: now, ExpiresAt: now.Add(time.Minute)}}.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, a.Merge(b))
+	res, ok = marker.Acknowledged(fp)
+	require.True(t, ok)
+	require.Equal(t, "peer", res.By)
+
+	// The expired acknowledgements are cleared by the garbage collection.
+	a.now = func() time.Time { return now.Add(2 * time.Minute) }
+	a.GC()
+	_, ok = marker.Acknowledged(fp)
+	require.False(t, ok)
+}
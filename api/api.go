@@ -20,11 +20,18 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/prometheus/alertmanager/ack"
 	apiv1 "github.com/prometheus/alertmanager/api/v1"
 	apiv2 "github.com/prometheus/alertmanager/api/v2"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/enrich"
+	"github.com/prometheus/alertmanager/history"
+	"github.com/prometheus/alertmanager/nflog"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/plugin"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/types"
@@ -74,6 +81,40 @@ type Options struct {
 	// according to the current active configuration. Alerts returned are
 	// filtered by the arguments provided to the function.
 	GroupFunc func(func(*dispatch.Route) bool, func(*types.Alert, time.Time) bool) (dispatch.AlertGroups, map[model.Fingerprint][]string)
+	// Plugins manages the notifier plugins. If nil, plugin receivers can't
+	// be tested through the API.
+	Plugins *plugin.Manager
+	// Outbox stores the notifications that failed to be delivered. If nil,
+	// the outbox endpoints return an error.
+	Outbox *outbox.Outbox
+	// History records the delivery of notifications. If nil, the history
+	// endpoint returns an error.
+	History *history.History
+	// Acks holds the acknowledgements of alerts. If nil, the
+	// acknowledgement endpoints return an error.
+	Acks *ack.Acks
+	// Enricher enriches the received alerts from lookup tables. If nil, the
+	// alerts aren't enriched and the lookup table endpoints return an error.
+	Enricher *enrich.Enricher
+	// LimitsFunc returns the aggregation group limits and the alerts which
+	// exceeded them. If nil, the limits endpoint returns an error.
+	LimitsFunc func() *dispatch.LimitStatus
+	// ExplainFunc explains how the dispatcher routes an alert. If nil, the
+	// explain endpoint returns an error.
+	ExplainFunc func(*types.Alert) *dispatch.Explanation
+	// InhibitFunc returns the fingerprints of the alerts inhibiting the
+	// given label set. If nil, the inhibitions aren't explained.
+	InhibitFunc func(model.LabelSet) []model.Fingerprint
+	// NotificationLog holds the last notification of each aggregation group
+	// by integration. If nil, the notifications aren't explained.
+	NotificationLog *nflog.Log
+	// GroupStatusFunc returns the status of the aggregation groups. If nil,
+	// the aggregation group endpoints return an error.
+	GroupStatusFunc func() []*dispatch.GroupStatus
+	// FlushGroupFunc flushes the aggregation group with the given key
+	// immediately, notifying its alerts again if the second argument is
+	// true. If nil, the aggregation group endpoints return an error.
+	FlushGroupFunc func(string, bool) error
 }
 
 func (o Options) validate() error {
@@ -110,14 +151,25 @@ func New(opts Options) (*API, error) {
 		}
 	}
 
-	v1 := apiv1.New(
-		opts.Alerts,
-		opts.Silences,
-		opts.StatusFunc,
-		opts.Peer,
-		log.With(l, "version", "v1"),
-		opts.Registry,
-	)
+	v1 := apiv1.New(apiv1.Options{
+		Alerts:          opts.Alerts,
+		Silences:        opts.Silences,
+		StatusFunc:      opts.StatusFunc,
+		Peer:            opts.Peer,
+		Logger:          log.With(l, "version", "v1"),
+		Registry:        opts.Registry,
+		Plugins:         opts.Plugins,
+		Outbox:          opts.Outbox,
+		History:         opts.History,
+		Acks:            opts.Acks,
+		Enricher:        opts.Enricher,
+		LimitsFunc:      opts.LimitsFunc,
+		ExplainFunc:     opts.ExplainFunc,
+		InhibitFunc:     opts.InhibitFunc,
+		NotificationLog: opts.NotificationLog,
+		GroupStatusFunc: opts.GroupStatusFunc,
+		FlushGroupFunc:  opts.FlushGroupFunc,
+	})
 
 	v2, err := apiv2.NewAPI(
 		opts.Alerts,
@@ -127,6 +179,7 @@ func New(opts Options) (*API, error) {
 		opts.Peer,
 		log.With(l, "version", "v2"),
 		opts.Registry,
+		opts.Enricher,
 	)
 
 	if err != nil {
@@ -198,10 +251,10 @@ func (api *API) Register(r *route.Router, routePrefix string, reloadCh chan<- ch
 	return mux
 }
 
-// Update config and resolve timeout of each API. APIv2 also needs
-// setAlertStatus to be updated.
-func (api *API) Update(cfg *config.Config, setAlertStatus func(model.LabelSet)) {
-	api.v1.Update(cfg)
+// Update config and resolve timeout of each API. APIv1 also needs the
+// integrations of the receivers and APIv2 setAlertStatus to be updated.
+func (api *API) Update(cfg *config.Config, receivers map[string][]notify.Integration, setAlertStatus func(model.LabelSet)) {
+	api.v1.Update(cfg, receivers)
 	api.v2.Update(cfg, setAlertStatus)
 }
 
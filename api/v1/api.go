@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -29,15 +30,27 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/route"
 	"github.com/prometheus/common/version"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/prometheus/alertmanager/ack"
 	"github.com/prometheus/alertmanager/api/metrics"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/enrich"
+	"github.com/prometheus/alertmanager/history"
+	"github.com/prometheus/alertmanager/nflog"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/plugin"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/tracing"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -67,16 +80,28 @@ func setCORS(w http.ResponseWriter) {
 
 // API provides registration of handlers for API routes.
 type API struct {
-	alerts   provider.Alerts
-	silences *silence.Silences
-	config   *config.Config
-	route    *dispatch.Route
-	uptime   time.Time
-	peer     cluster.ClusterPeer
-	logger   log.Logger
-	m        *metrics.Alerts
+	alerts       provider.Alerts
+	silences     *silence.Silences
+	config       *config.Config
+	integrations map[string][]notify.Integration
+	route        *dispatch.Route
+	uptime       time.Time
+	peer         cluster.ClusterPeer
+	logger       log.Logger
+	m            *metrics.Alerts
 
 	getAlertStatus getAlertStatusFn
+	plugins        *plugin.Manager
+	outbox         *outbox.Outbox
+	history        *history.History
+	acks           *ack.Acks
+	enricher       *enrich.Enricher
+	limits         func() *dispatch.LimitStatus
+	explain        func(*types.Alert) *dispatch.Explanation
+	inhibiting     func(model.LabelSet) []model.Fingerprint
+	nflog          *nflog.Log
+	groupStatuses  func() []*dispatch.GroupStatus
+	flushGroup     func(string, bool) error
 
 	mtx sync.RWMutex
 
@@ -90,27 +115,56 @@ type API struct {
 
 type getAlertStatusFn func(model.Fingerprint) types.AlertStatus
 
+// Options for the creation of an API object. The optional fields are
+// documented on the api.Options they are passed from, and the zero value of
+// each of them is a safe default.
+type Options struct {
+	Alerts     provider.Alerts
+	Silences   *silence.Silences
+	StatusFunc func(model.Fingerprint) types.AlertStatus
+	Peer       cluster.ClusterPeer
+	Logger     log.Logger
+	Registry   prometheus.Registerer
+
+	Plugins         *plugin.Manager
+	Outbox          *outbox.Outbox
+	History         *history.History
+	Acks            *ack.Acks
+	Enricher        *enrich.Enricher
+	LimitsFunc      func() *dispatch.LimitStatus
+	ExplainFunc     func(*types.Alert) *dispatch.Explanation
+	InhibitFunc     func(model.LabelSet) []model.Fingerprint
+	NotificationLog *nflog.Log
+	GroupStatusFunc func() []*dispatch.GroupStatus
+	FlushGroupFunc  func(string, bool) error
+}
+
 // New returns a new API.
-func New(
-	alerts provider.Alerts,
-	silences *silence.Silences,
-	sf getAlertStatusFn,
-	peer cluster.ClusterPeer,
-	l log.Logger,
-	r prometheus.Registerer,
-) *API {
+func New(opts Options) *API {
+	l := opts.Logger
 	if l == nil {
 		l = log.NewNopLogger()
 	}
 
 	return &API{
-		alerts:         alerts,
-		silences:       silences,
-		getAlertStatus: sf,
+		alerts:         opts.Alerts,
+		silences:       opts.Silences,
+		getAlertStatus: opts.StatusFunc,
 		uptime:         time.Now(),
-		peer:           peer,
+		peer:           opts.Peer,
 		logger:         l,
-		m:              metrics.NewAlerts("v1", r),
+		m:              metrics.NewAlerts("v1", opts.Registry),
+		plugins:        opts.Plugins,
+		outbox:         opts.Outbox,
+		history:        opts.History,
+		acks:           opts.Acks,
+		enricher:       opts.Enricher,
+		limits:         opts.LimitsFunc,
+		explain:        opts.ExplainFunc,
+		inhibiting:     opts.InhibitFunc,
+		nflog:          opts.NotificationLog,
+		groupStatuses:  opts.GroupStatusFunc,
+		flushGroup:     opts.FlushGroupFunc,
 	}
 }
 
@@ -132,7 +186,29 @@ func (api *API) Register(r *route.Router, reloadCh chan<- chan error, updateConf
 
 	r.Get("/status", wrap(api.status))
 	r.Get("/receivers", wrap(api.receivers))
+	r.Get("/receivers/status", wrap(api.receiversStatus))
 	r.Post("/testReceiver", wrap(api.testReceiver))
+	r.Get("/plugins", wrap(api.listPlugins))
+
+	r.Get("/outbox", wrap(api.listOutbox))
+	r.Post("/outbox/:id/replay", wrap(api.replayOutboxEntry))
+	r.Del("/outbox/:id", wrap(api.discardOutboxEntry))
+
+	r.Get("/history", wrap(api.listHistory))
+
+	r.Get("/limits", wrap(api.limitStatus))
+	r.Get("/explain", wrap(api.explainAlert))
+
+	r.Get("/groups", wrap(api.listGroups))
+	r.Post("/groups/flush", wrap(api.flushAggrGroup))
+
+	r.Get("/acks", wrap(api.listAcks))
+	r.Post("/acks", wrap(api.createAck))
+	r.Del("/ack/:id", wrap(api.expireAck))
+
+	r.Get("/lookupTables", wrap(api.listLookupTables))
+	r.Get("/lookupTables/:name", wrap(api.getLookupTable))
+	r.Put("/lookupTables/:name", wrap(api.setLookupTable))
 
 	r.Get("/alerts", wrap(api.listAlerts))
 	r.Post("/alerts", wrap(api.addAlerts))
@@ -147,12 +223,14 @@ func (api *API) Register(r *route.Router, reloadCh chan<- chan error, updateConf
 	r.Del("/routes", wrap(api.deleteRoute))
 }
 
-// Update sets the configuration string to a new value.
-func (api *API) Update(cfg *config.Config) {
+// Update sets the configuration string to a new value, along with the
+// integrations built for the receivers in use.
+func (api *API) Update(cfg *config.Config, receivers map[string][]notify.Integration) {
 	api.mtx.Lock()
 	defer api.mtx.Unlock()
 
 	api.config = cfg
+	api.integrations = receivers
 	api.route = dispatch.NewRoute(cfg.Route, nil)
 }
 
@@ -184,6 +262,382 @@ func (api *API) receivers(w http.ResponseWriter, req *http.Request) {
 	api.respond(w, receivers)
 }
 
+type integrationStatus struct {
+	Name           string                       `json:"name"`
+	Index          int                          `json:"index"`
+	CircuitBreaker *notify.CircuitBreakerStatus `json:"circuitBreaker,omitempty"`
+}
+
+type receiverStatus struct {
+	Name string `json:"name"`
+	// Active is false for receivers that no route uses, which have no
+	// integrations.
+	Active       bool                `json:"active"`
+	Integrations []integrationStatus `json:"integrations"`
+}
+
+// receiversStatus returns the integrations of the receivers along with the
+// state of their circuit breakers.
+func (api *API) receiversStatus(w http.ResponseWriter, req *http.Request) {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	receivers := make([]receiverStatus, 0, len(api.config.Receivers))
+	for _, r := range api.config.Receivers {
+		integrations, active := api.integrations[r.Name]
+		rs := receiverStatus{
+			Name:         r.Name,
+			Active:       active,
+			Integrations: make([]integrationStatus, 0, len(integrations)),
+		}
+		for i := range integrations {
+			is := integrationStatus{
+				Name:  integrations[i].Name(),
+				Index: integrations[i].Index(),
+			}
+			if b := integrations[i].CircuitBreaker(); b != nil {
+				status := b.Status()
+				is.CircuitBreaker = &status
+			}
+			rs.Integrations = append(rs.Integrations, is)
+		}
+		receivers = append(receivers, rs)
+	}
+
+	api.respond(w, receivers)
+}
+
+// listPlugins returns the running notifier plugins with their configuration
+// schema.
+func (api *API) listPlugins(w http.ResponseWriter, req *http.Request) {
+	plugins := []plugin.Info{}
+	if api.plugins != nil {
+		plugins = api.plugins.Plugins()
+	}
+	api.respond(w, plugins)
+}
+
+var errOutboxDisabled = errors.New("outbox is disabled")
+
+// listOutbox returns the notifications that failed to be delivered. They can
+// be filtered by status with the status parameter.
+func (api *API) listOutbox(w http.ResponseWriter, req *http.Request) {
+	if api.outbox == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errOutboxDisabled}, nil)
+		return
+	}
+
+	var statuses []outbox.Status
+	for _, s := range req.URL.Query()["status"] {
+		switch st := outbox.Status(s); st {
+		case outbox.StatusPending, outbox.StatusDead:
+			statuses = append(statuses, st)
+		default:
+			api.respondError(w, apiError{
+				typ: errorBadData,
+				err: fmt.Errorf("unknown outbox status %q", s),
+			}, nil)
+			return
+		}
+	}
+	api.respond(w, api.outbox.List(statuses...))
+}
+
+// replayOutboxEntry schedules the immediate delivery of an outbox entry.
+func (api *API) replayOutboxEntry(w http.ResponseWriter, req *http.Request) {
+	if api.outbox == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errOutboxDisabled}, nil)
+		return
+	}
+	if err := api.outbox.Replay(route.Param(req.Context(), "id")); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	api.respond(w, nil)
+}
+
+// discardOutboxEntry removes an outbox entry.
+func (api *API) discardOutboxEntry(w http.ResponseWriter, req *http.Request) {
+	if api.outbox == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errOutboxDisabled}, nil)
+		return
+	}
+	if err := api.outbox.Discard(route.Param(req.Context(), "id")); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	api.respond(w, nil)
+}
+
+// listHistory returns the delivery history of notifications, from the most
+// recent to the oldest. It can be filtered by receiver, alert fingerprint and
+// status, and limited in size.
+func (api *API) listHistory(w http.ResponseWriter, req *http.Request) {
+	if api.history == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errors.New("notification history is disabled")}, nil)
+		return
+	}
+
+	q := history.Query{
+		Receiver:    req.FormValue("receiver"),
+		Fingerprint: req.FormValue("fingerprint"),
+		Status:      history.Status(req.FormValue("status")),
+	}
+	if q.Fingerprint != "" {
+		if _, err := model.ParseFingerprint(q.Fingerprint); err != nil {
+			api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("invalid fingerprint %q", q.Fingerprint)}, nil)
+			return
+		}
+	}
+	switch q.Status {
+	case "", history.StatusSuccess, history.StatusFailure:
+	default:
+		api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("unknown status %q", q.Status)}, nil)
+		return
+	}
+	if limit := req.FormValue("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("invalid limit %q", limit)}, nil)
+			return
+		}
+		q.Limit = n
+	}
+
+	api.respond(w, api.history.Query(q))
+}
+
+// limitStatus returns the aggregation group limits and the alerts of every
+// route which exceeded them.
+func (api *API) limitStatus(w http.ResponseWriter, req *http.Request) {
+	if api.limits == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errors.New("aggregation group limits are unavailable")}, nil)
+		return
+	}
+	api.respond(w, api.limits())
+}
+
+// explanation explains why an alert was notified or not.
+type explanation struct {
+	Labels      model.LabelSet             `json:"labels"`
+	Route       *dispatch.RouteExplanation `json:"route"`
+	Groups      []*groupExplanation        `json:"groups"`
+	Silences    []*types.Silence           `json:"silences"`
+	InhibitedBy []string                   `json:"inhibitedBy"`
+}
+
+type groupExplanation struct {
+	*dispatch.GroupExplanation
+	ActiveMuteTimeIntervals []string                  `json:"activeMuteTimeIntervals"`
+	Notifications           []integrationNotification `json:"notifications"`
+}
+
+// integrationNotification is the last notification of an aggregation group by
+// an integration.
+type integrationNotification struct {
+	Integration string `json:"integration"`
+	Index       int    `json:"index"`
+	// LastNotified is nil if the integration didn't notify the group.
+	LastNotified *time.Time `json:"lastNotified,omitempty"`
+}
+
+// explainAlert explains how an alert, given by its fingerprint or by its
+// labels, is routed and why it is muted: the route tree, the aggregation
+// groups, the active mute time intervals, silences and inhibiting alerts, and
+// the last notifications of the groups.
+func (api *API) explainAlert(w http.ResponseWriter, req *http.Request) {
+	if api.explain == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errors.New("routing explanation is unavailable")}, nil)
+		return
+	}
+
+	var alert *types.Alert
+	switch fp, lbls := req.FormValue("fingerprint"), req.FormValue("labels"); {
+	case fp != "" && lbls != "":
+		api.respondError(w, apiError{typ: errorBadData, err: errors.New("fingerprint and labels are mutually exclusive")}, nil)
+		return
+	case fp != "":
+		f, err := model.ParseFingerprint(fp)
+		if err != nil {
+			api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("invalid fingerprint %q", fp)}, nil)
+			return
+		}
+		alert, err = api.alerts.Get(f)
+		if err != nil {
+			api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("alert %s not found", fp)}, nil)
+			return
+		}
+	case lbls != "":
+		lset, err := parseLabelSet(lbls)
+		if err != nil {
+			api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+			return
+		}
+		alert = &types.Alert{Alert: model.Alert{Labels: lset}}
+	default:
+		api.respondError(w, apiError{typ: errorBadData, err: errors.New("either fingerprint or labels must be set")}, nil)
+		return
+	}
+
+	e := api.explain(alert)
+	res := &explanation{
+		Labels:      alert.Labels,
+		Route:       e.Route,
+		Groups:      make([]*groupExplanation, 0, len(e.Groups)),
+		Silences:    []*types.Silence{},
+		InhibitedBy: []string{},
+	}
+
+	api.mtx.RLock()
+	muteTimes := make(map[string][]timeinterval.TimeInterval, len(api.config.MuteTimeIntervals))
+	for _, mt := range api.config.MuteTimeIntervals {
+		muteTimes[mt.Name] = mt.TimeIntervals
+	}
+	now := time.Now()
+	for _, g := range e.Groups {
+		ge := &groupExplanation{
+			GroupExplanation:        g,
+			ActiveMuteTimeIntervals: []string{},
+			Notifications:           api.lastNotifications(g.Receiver, g.GroupKey),
+		}
+		for _, name := range g.MuteTimeIntervals {
+			for _, ti := range muteTimes[name] {
+				if ti.ContainsTime(now.UTC()) {
+					ge.ActiveMuteTimeIntervals = append(ge.ActiveMuteTimeIntervals, name)
+					break
+				}
+			}
+		}
+		res.Groups = append(res.Groups, ge)
+	}
+	api.mtx.RUnlock()
+
+	if api.silences != nil {
+		sils, _, err := api.silences.Query(silence.QState(types.SilenceStateActive), silence.QMatches(alert.Labels))
+		if err != nil {
+			api.respondError(w, apiError{typ: errorInternal, err: err}, nil)
+			return
+		}
+		for _, ps := range sils {
+			s, err := silenceFromProto(ps)
+			if err != nil {
+				api.respondError(w, apiError{typ: errorInternal, err: err}, nil)
+				return
+			}
+			res.Silences = append(res.Silences, s)
+		}
+	}
+	if api.inhibiting != nil {
+		for _, fp := range api.inhibiting(alert.Labels) {
+			res.InhibitedBy = append(res.InhibitedBy, fp.String())
+		}
+	}
+
+	api.respond(w, res)
+}
+
+// lastNotifications returns the last notification of the aggregation group
+// by each integration of the receiver. The caller must hold the lock of the
+// API.
+func (api *API) lastNotifications(receiver, groupKey string) []integrationNotification {
+	integrations := api.integrations[receiver]
+	res := make([]integrationNotification, 0, len(integrations))
+	for _, i := range integrations {
+		n := integrationNotification{Integration: i.Name(), Index: i.Index()}
+		if api.nflog != nil {
+			entries, err := api.nflog.Query(nflog.QGroupKey(groupKey), nflog.QReceiver(&nflogpb.Receiver{
+				GroupName:   receiver,
+				Integration: i.Name(),
+				Idx:         uint32(i.Index()),
+			}))
+			if err == nil && len(entries) > 0 {
+				ts := entries[0].Timestamp
+				n.LastNotified = &ts
+			}
+		}
+		res = append(res, n)
+	}
+	return res
+}
+
+// groupStatus is the status of an aggregation group along with its last
+// notification by each integration of its receiver.
+type groupStatus struct {
+	*dispatch.GroupStatus
+	Notifications []integrationNotification `json:"notifications"`
+}
+
+// listGroups returns the status of the aggregation groups. They can be
+// filtered by receiver with the receiver parameter.
+func (api *API) listGroups(w http.ResponseWriter, req *http.Request) {
+	if api.groupStatuses == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errors.New("aggregation groups are unavailable")}, nil)
+		return
+	}
+
+	receiver := req.FormValue("receiver")
+	statuses := api.groupStatuses()
+
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	groups := make([]groupStatus, 0, len(statuses))
+	for _, st := range statuses {
+		if receiver != "" && st.RouteOpts.Receiver != receiver {
+			continue
+		}
+		groups = append(groups, groupStatus{
+			GroupStatus:   st,
+			Notifications: api.lastNotifications(st.RouteOpts.Receiver, st.GroupKey),
+		})
+	}
+	api.respond(w, groups)
+}
+
+// flushAggrGroup flushes an aggregation group immediately, optionally
+// notifying its alerts again, e.g. after a broken receiver was fixed.
+func (api *API) flushAggrGroup(w http.ResponseWriter, req *http.Request) {
+	if api.flushGroup == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errors.New("aggregation groups are unavailable")}, nil)
+		return
+	}
+
+	var f struct {
+		GroupKey string `json:"groupKey"`
+		Renotify bool   `json:"renotify"`
+	}
+	if err := api.receive(req, &f); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	if f.GroupKey == "" {
+		api.respondError(w, apiError{typ: errorBadData, err: errors.New("missing group key")}, nil)
+		return
+	}
+	if err := api.flushGroup(f.GroupKey, f.Renotify); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	level.Info(api.logger).Log("msg", "Flushed aggregation group", "groupKey", f.GroupKey, "renotify", f.Renotify)
+	api.respond(w, nil)
+}
+
+// parseLabelSet parses a label set in the {name="value", ...} format.
+func parseLabelSet(s string) (model.LabelSet, error) {
+	matchers, err := labels.ParseMatchers(s)
+	if err != nil {
+		return nil, err
+	}
+	lset := make(model.LabelSet, len(matchers))
+	for _, m := range matchers {
+		if m.Type != labels.MatchEqual {
+			return nil, fmt.Errorf("invalid label %s, expected name=\"value\"", m)
+		}
+		lset[model.LabelName(m.Name)] = model.LabelValue(m.Value)
+	}
+	return lset, nil
+}
+
 func (api *API) status(w http.ResponseWriter, req *http.Request) {
 	api.mtx.RLock()
 
@@ -424,8 +878,12 @@ func (api *API) addAlerts(w http.ResponseWriter, r *http.Request) {
 func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*types.Alert) {
 	now := time.Now()
 
+	_, span := tracing.StartServerSpan(r, "api.v1.insertAlerts", attribute.Int("alerts", len(alerts)))
+	defer span.End()
+
 	api.mtx.RLock()
 	resolveTimeout := time.Duration(api.config.Global.ResolveTimeout)
+	relabelConfigs := api.config.RelabelConfigs
 	api.mtx.RUnlock()
 
 	for _, alert := range alerts {
@@ -452,6 +910,23 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 		}
 	}
 
+	if len(relabelConfigs) > 0 {
+		relabeled := alerts[:0]
+		for _, a := range alerts {
+			ls, keep := relabel.Process(a.Labels, relabelConfigs...)
+			if !keep {
+				api.m.Dropped().Inc()
+				continue
+			}
+			a.Labels = ls
+			relabeled = append(relabeled, a)
+		}
+		alerts = relabeled
+	}
+	if api.enricher != nil {
+		api.enricher.Enrich(alerts...)
+	}
+
 	// Make a best effort to insert all alerts that are valid.
 	var (
 		validAlerts    = make([]*types.Alert, 0, len(alerts))
@@ -468,7 +943,9 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 		validAlerts = append(validAlerts, a)
 	}
 
+	span.SetAttributes(attribute.Int("alerts.valid", len(validAlerts)))
 	if err := api.alerts.Put(validAlerts...); err != nil {
+		tracing.RecordError(span, err)
 		api.respondError(w, apiError{
 			typ: errorInternal,
 			err: err,
@@ -477,6 +954,7 @@ func (api *API) insertAlerts(w http.ResponseWriter, r *http.Request, alerts ...*
 	}
 
 	if validationErrs.Len() > 0 {
+		tracing.RecordError(span, validationErrs)
 		api.respondError(w, apiError{
 			typ: errorBadData,
 			err: validationErrs,
@@ -550,6 +1028,115 @@ func (api *API) setSilence(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+var errAcksDisabled = errors.New("acknowledgements are disabled")
+
+// listAcks returns the acknowledgements, from the most recent to the oldest.
+// If the active parameter is true, only the active ones are returned.
+func (api *API) listAcks(w http.ResponseWriter, r *http.Request) {
+	if api.acks == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errAcksDisabled}, nil)
+		return
+	}
+
+	var active bool
+	if v := r.FormValue("active"); v != "" {
+		var err error
+		if active, err = strconv.ParseBool(v); err != nil {
+			api.respondError(w, apiError{typ: errorBadData, err: fmt.Errorf("invalid active parameter %q", v)}, nil)
+			return
+		}
+	}
+	api.respond(w, api.acks.List(active))
+}
+
+// createAck acknowledges an alert or an aggregation group.
+func (api *API) createAck(w http.ResponseWriter, r *http.Request) {
+	if api.acks == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errAcksDisabled}, nil)
+		return
+	}
+
+	var a ack.Ack
+	if err := api.receive(r, &a); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	id, err := api.acks.Create(ack.Ack{
+		Fingerprint: a.Fingerprint,
+		GroupKey:    a.GroupKey,
+		By:          a.By,
+		Comment:     a.Comment,
+		ExpiresAt:   a.ExpiresAt,
+	})
+	if err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+
+	api.respond(w, struct {
+		AckID string `json:"ackId"`
+	}{
+		AckID: id,
+	})
+}
+
+// expireAck ends an acknowledgement.
+func (api *API) expireAck(w http.ResponseWriter, r *http.Request) {
+	if api.acks == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errAcksDisabled}, nil)
+		return
+	}
+
+	if err := api.acks.Expire(route.Param(r.Context(), "id")); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	api.respond(w, nil)
+}
+
+var errLookupTablesDisabled = errors.New("lookup tables are disabled")
+
+// listLookupTables returns the lookup tables enriching the alerts.
+func (api *API) listLookupTables(w http.ResponseWriter, r *http.Request) {
+	if api.enricher == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errLookupTablesDisabled}, nil)
+		return
+	}
+	api.respond(w, api.enricher.Tables())
+}
+
+// getLookupTable returns the rows of a lookup table.
+func (api *API) getLookupTable(w http.ResponseWriter, r *http.Request) {
+	if api.enricher == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errLookupTablesDisabled}, nil)
+		return
+	}
+	rows, err := api.enricher.Rows(route.Param(r.Context(), "name"))
+	if err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	api.respond(w, rows)
+}
+
+// setLookupTable replaces the rows of a lookup table managed through the API.
+func (api *API) setLookupTable(w http.ResponseWriter, r *http.Request) {
+	if api.enricher == nil {
+		api.respondError(w, apiError{typ: errorInternal, err: errLookupTablesDisabled}, nil)
+		return
+	}
+	var rows enrich.Rows
+	if err := api.receive(r, &rows); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	if err := api.enricher.SetRows(route.Param(r.Context(), "name"), rows); err != nil {
+		api.respondError(w, apiError{typ: errorBadData, err: err}, nil)
+		return
+	}
+	api.respond(w, nil)
+}
+
 func (api *API) getSilence(w http.ResponseWriter, r *http.Request) {
 	sid := route.Param(r.Context(), "sid")
 
@@ -21,17 +21,29 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/route"
 	"github.com/stretchr/testify/require"
 
+	"github.com/prometheus/alertmanager/ack"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/enrich"
+	"github.com/prometheus/alertmanager/history"
+	"github.com/prometheus/alertmanager/nflog"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -133,13 +145,13 @@ func TestAddAlerts(t *testing.T) {
 		}
 
 		alertsProvider := newFakeAlerts([]*types.Alert{}, tc.err)
-		api := New(alertsProvider, nil, newGetAlertStatus(alertsProvider), nil, nil, nil)
+		api := New(Options{Alerts: alertsProvider, StatusFunc: newGetAlertStatus(alertsProvider)})
 		defaultGlobalConfig := config.DefaultGlobalConfig()
 		route := config.Route{}
 		api.Update(&config.Config{
 			Global: &defaultGlobalConfig,
 			Route:  &route,
-		})
+		}, nil)
 
 		r, err := http.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(b))
 		w := httptest.NewRecorder()
@@ -266,7 +278,7 @@ func TestListAlerts(t *testing.T) {
 		},
 	} {
 		alertsProvider := newFakeAlerts(alerts, tc.err)
-		api := New(alertsProvider, nil, newGetAlertStatus(alertsProvider), nil, nil, nil)
+		api := New(Options{Alerts: alertsProvider, StatusFunc: newGetAlertStatus(alertsProvider)})
 		api.route = dispatch.NewRoute(&config.Route{Receiver: "def-receiver"}, nil)
 
 		r, err := http.NewRequest("GET", "/api/v1/alerts", nil)
@@ -583,3 +595,509 @@ func newMatcher(labelSet model.LabelSet) labels.Matchers {
 	}
 	return matchers
 }
+
+func TestReceiversStatus(t *testing.T) {
+	rs := &config.WebhookConfig{
+		NotifierConfig: config.NotifierConfig{
+			CircuitBreaker: &config.CircuitBreakerConfig{FailureThreshold: 1},
+		},
+	}
+	integrations := []notify.Integration{
+		notify.NewIntegration(nil, rs, "webhook", 0),
+		notify.NewIntegration(nil, &config.WebhookConfig{}, "webhook", 1),
+	}
+	integrations[0].CircuitBreaker().Record(errors.New("fail"), time.Now())
+
+	api := New(Options{})
+	api.Update(&config.Config{
+		Route:     &config.Route{Receiver: "team-X"},
+		Receivers: []*config.Receiver{{Name: "team-X"}, {Name: "unused"}},
+	}, map[string][]notify.Integration{"team-X": integrations})
+
+	r, err := http.NewRequest("GET", "/api/v1/receivers/status", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	api.receiversStatus(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Data []receiverStatus `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.Len(t, res.Data, 2)
+
+	require.Equal(t, "team-X", res.Data[0].Name)
+	require.True(t, res.Data[0].Active)
+	require.Len(t, res.Data[0].Integrations, 2)
+	require.Equal(t, "open", res.Data[0].Integrations[0].CircuitBreaker.State)
+	require.Equal(t, 1, res.Data[0].Integrations[0].CircuitBreaker.ConsecutiveFailures)
+	require.NotNil(t, res.Data[0].Integrations[0].CircuitBreaker.OpenedAt)
+	require.Equal(t, 1, res.Data[0].Integrations[1].Index)
+	require.Nil(t, res.Data[0].Integrations[1].CircuitBreaker)
+
+	require.Equal(t, receiverStatus{Name: "unused", Integrations: []integrationStatus{}}, res.Data[1])
+}
+
+func TestOutbox(t *testing.T) {
+	ob, err := outbox.New(outbox.Options{
+		MaxAttempts:    1,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+		Retention:      time.Hour,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ob.Add(&outbox.Entry{Receiver: "team-X"}, errors.New("unavailable"), true))
+	pending := ob.List()[0].ID
+	require.NoError(t, ob.Add(&outbox.Entry{Receiver: "team-Y"}, errors.New("bad request"), false))
+	dead := ob.List(outbox.StatusDead)[0].ID
+
+	api := New(Options{Outbox: ob})
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	do := func(method, url string) (int, []outbox.Entry) {
+		r, err := http.NewRequest(method, url, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data []outbox.Entry `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	code, entries := do("GET", "/outbox?status=dead")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 1)
+	require.Equal(t, dead, entries[0].ID)
+
+	code, _ = do("GET", "/outbox?status=unknown")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do("POST", "/outbox/"+dead+"/replay")
+	require.Equal(t, http.StatusOK, code)
+	code, entries = do("GET", "/outbox?status=pending")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 2)
+
+	code, _ = do("DELETE", "/outbox/"+pending)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = do("DELETE", "/outbox/"+pending)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, entries = do("GET", "/outbox")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 1)
+}
+
+func TestHistory(t *testing.T) {
+	hist, err := history.New(history.Options{Retention: time.Hour})
+	require.NoError(t, err)
+	now := time.Now()
+	hist.Add(&history.Entry{Timestamp: now.Add(-2 * time.Minute), Receiver: "team-X", Fingerprints: []string{"0000000000000001"}, Status: history.StatusSuccess})
+	hist.Add(&history.Entry{Timestamp: now.Add(-time.Minute), Receiver: "team-Y", Fingerprints: []string{"0000000000000002"}, Status: history.StatusFailure})
+
+	api := New(Options{History: hist})
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	do := func(url string) (int, []history.Entry) {
+		r, err := http.NewRequest("GET", url, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data []history.Entry `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	code, entries := do("/history")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 2)
+	require.Equal(t, "team-Y", entries[0].Receiver)
+
+	code, entries = do("/history?fingerprint=0000000000000001")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 1)
+	require.Equal(t, "team-X", entries[0].Receiver)
+
+	code, entries = do("/history?status=failure&limit=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, entries, 1)
+	require.Equal(t, "team-Y", entries[0].Receiver)
+
+	for _, url := range []string{"/history?status=unknown", "/history?limit=-1", "/history?fingerprint=xyz"} {
+		code, _ = do(url)
+		require.Equal(t, http.StatusBadRequest, code, url)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	do := func(api *API) (int, *dispatch.LimitStatus) {
+		router := route.New()
+		api.Register(router, nil, nil, nil)
+		r, err := http.NewRequest("GET", "/limits", nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data *dispatch.LimitStatus `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	code, _ := do(New(Options{}))
+	require.Equal(t, http.StatusInternalServerError, code)
+
+	want := &dispatch.LimitStatus{
+		MaxAggregationGroups: 100,
+		Overflow:             "drop",
+		AggregationGroups:    100,
+		Routes: []*dispatch.RouteLimitStatus{
+			{Route: "{}", Receiver: "team-X", AggregationGroups: 100, Dropped: 3},
+		},
+	}
+	code, got := do(New(Options{LimitsFunc: func() *dispatch.LimitStatus { return want }}))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, want, got)
+}
+
+func TestExplain(t *testing.T) {
+	nlog, err := nflog.New(nflog.WithRetention(time.Hour))
+	require.NoError(t, err)
+	gk := `{}:{alertname="A"}`
+	recv := &nflogpb.Receiver{GroupName: "team-X", Integration: "webhook", Idx: 1}
+	require.NoError(t, nlog.Log(recv, gk, []uint64{1}, nil))
+
+	next := time.Now().Add(time.Minute).UTC()
+	var explained *types.Alert
+	api := New(Options{
+		ExplainFunc: func(alert *types.Alert) *dispatch.Explanation {
+			explained = alert
+			return &dispatch.Explanation{
+				Route: &dispatch.RouteExplanation{Route: "{}", Receiver: "team-X", Matched: true, Selected: true},
+				Groups: []*dispatch.GroupExplanation{{
+					Route:             "{}",
+					Receiver:          "team-X",
+					GroupKey:          gk,
+					GroupLabels:       model.LabelSet{"alertname": "A"},
+					MuteTimeIntervals: []string{"always", "never"},
+					NextFlush:         &next,
+				}},
+			}
+		},
+		InhibitFunc:     func(model.LabelSet) []model.Fingerprint { return []model.Fingerprint{2} },
+		NotificationLog: nlog,
+	})
+	api.Update(&config.Config{
+		Route: &config.Route{Receiver: "team-X"},
+		MuteTimeIntervals: []config.MuteTimeInterval{
+			{Name: "always", TimeIntervals: []timeinterval.TimeInterval{{}}},
+			{Name: "never", TimeIntervals: []timeinterval.TimeInterval{{Months: []timeinterval.MonthRange{{InclusiveRange: timeinterval.InclusiveRange{Begin: 13, End: 13}}}}}},
+		},
+	}, map[string][]notify.Integration{"team-X": {
+		notify.NewIntegration(nil, &config.WebhookConfig{}, "webhook", 0),
+		notify.NewIntegration(nil, &config.WebhookConfig{}, "webhook", 1),
+	}})
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	do := func(query string) (int, *explanation) {
+		r, err := http.NewRequest("GET", "/explain?"+query, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data *explanation `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	for _, query := range []string{
+		"",
+		"fingerprint=xyz",
+		`labels={alertname=~"A"}`,
+		`fingerprint=0000000000000001&labels={alertname="A"}`,
+	} {
+		code, _ := do(query)
+		require.Equal(t, http.StatusBadRequest, code, query)
+	}
+
+	code, res := do(`labels={alertname="A"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, model.LabelSet{"alertname": "A"}, explained.Labels)
+	require.Equal(t, model.LabelSet{"alertname": "A"}, res.Labels)
+	require.True(t, res.Route.Selected)
+	require.Equal(t, []string{"0000000000000002"}, res.InhibitedBy)
+	require.Len(t, res.Groups, 1)
+	require.Equal(t, gk, res.Groups[0].GroupKey)
+	require.Equal(t, next, *res.Groups[0].NextFlush)
+	require.Equal(t, []string{"always"}, res.Groups[0].ActiveMuteTimeIntervals)
+	require.Len(t, res.Groups[0].Notifications, 2)
+	require.Nil(t, res.Groups[0].Notifications[0].LastNotified)
+	require.Equal(t, 1, res.Groups[0].Notifications[1].Index)
+	require.NotNil(t, res.Groups[0].Notifications[1].LastNotified)
+}
+
+func TestAggrGroups(t *testing.T) {
+	next := time.Now().Add(time.Minute).UTC()
+	statuses := []*dispatch.GroupStatus{
+		{GroupKey: `{}:{alertname="A"}`, RouteOpts: &dispatch.RouteOpts{Receiver: "team-X"}, Alerts: 2, NextFlush: next},
+		{GroupKey: `{}:{alertname="B"}`, RouteOpts: &dispatch.RouteOpts{Receiver: "team-Y"}, Alerts: 1, NextFlush: next},
+	}
+	type flush struct {
+		groupKey string
+		renotify bool
+	}
+	var flushed []flush
+	api := New(Options{
+		GroupStatusFunc: func() []*dispatch.GroupStatus { return statuses },
+		FlushGroupFunc: func(groupKey string, renotify bool) error {
+			if groupKey != statuses[0].GroupKey {
+				return dispatch.ErrGroupNotFound
+			}
+			flushed = append(flushed, flush{groupKey: groupKey, renotify: renotify})
+			return nil
+		},
+	})
+	api.Update(&config.Config{Route: &config.Route{Receiver: "team-X"}}, map[string][]notify.Integration{
+		"team-X": {notify.NewIntegration(nil, &config.WebhookConfig{}, "webhook", 0)},
+	})
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	for _, tc := range []struct {
+		query string
+		keys  []string
+	}{
+		{query: "", keys: []string{statuses[0].GroupKey, statuses[1].GroupKey}},
+		{query: "?receiver=team-Y", keys: []string{statuses[1].GroupKey}},
+	} {
+		r, err := http.NewRequest("GET", "/groups"+tc.query, nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res struct {
+			Data []struct {
+				GroupKey      string                    `json:"groupKey"`
+				Alerts        int                       `json:"alerts"`
+				NextFlush     time.Time                 `json:"nextFlush"`
+				Notifications []integrationNotification `json:"notifications"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		var keys []string
+		for _, g := range res.Data {
+			keys = append(keys, g.GroupKey)
+			require.Equal(t, next, g.NextFlush)
+		}
+		require.Equal(t, tc.keys, keys, tc.query)
+		if tc.query == "" {
+			require.Equal(t, 2, res.Data[0].Alerts)
+			require.Equal(t, []integrationNotification{{Integration: "webhook"}}, res.Data[0].Notifications)
+			require.Empty(t, res.Data[1].Notifications)
+		}
+	}
+
+	for _, tc := range []struct {
+		body string
+		code int
+	}{
+		{body: `{}`, code: http.StatusBadRequest},
+		{body: `{"groupKey": "{}:{alertname=\"C\"}"}`, code: http.StatusBadRequest},
+		{body: `{"groupKey": "{}:{alertname=\"A\"}", "renotify": true}`, code: http.StatusOK},
+	} {
+		r, err := http.NewRequest("POST", "/groups/flush", bytes.NewBufferString(tc.body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		require.Equal(t, tc.code, w.Code, tc.body)
+	}
+	require.Equal(t, []flush{{groupKey: statuses[0].GroupKey, renotify: true}}, flushed)
+}
+
+func TestAcks(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	acks, err := ack.New(ack.Options{Retention: time.Hour, Marker: marker})
+	require.NoError(t, err)
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "A"}, StartsAt: time.Now()}}
+
+	api := New(Options{Alerts: newFakeAlerts([]*types.Alert{alert}, false), StatusFunc: marker.Status, Acks: acks})
+	api.route = dispatch.NewRoute(&config.Route{Receiver: "def-receiver"}, nil)
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	do := func(method, url, body string) (int, json.RawMessage) {
+		r, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	acknowledgement := func() *types.Acknowledgement {
+		code, data := do("GET", "/alerts", "")
+		require.Equal(t, http.StatusOK, code)
+		var res []Alert
+		require.NoError(t, json.Unmarshal(data, &res))
+		require.Len(t, res, 1)
+		return res[0].Status.Acknowledgement
+	}
+
+	code, data := do("POST", "/acks", `{"fingerprint":"`+alert.Fingerprint().String()+`","by":"me","comment":"on it"}`)
+	require.Equal(t, http.StatusOK, code)
+	var created struct {
+		AckID string `json:"ackId"`
+	}
+	require.NoError(t, json.Unmarshal(data, &created))
+	require.NotEmpty(t, created.AckID)
+
+	// The status of the alert is updated right away.
+	require.NotNil(t, acknowledgement())
+	require.Equal(t, created.AckID, acknowledgement().ID)
+
+	for _, body := range []string{`{"by":"me"}`, `{"groupKey":"{}:{}"}`, `{"fingerprint":"xyz","by":"me"}`, `{`} {
+		code, _ = do("POST", "/acks", body)
+		require.Equal(t, http.StatusBadRequest, code, body)
+	}
+
+	list := func(url string) []ack.Ack {
+		code, data := do("GET", url, "")
+		require.Equal(t, http.StatusOK, code)
+		var res []ack.Ack
+		require.NoError(t, json.Unmarshal(data, &res))
+		return res
+	}
+	res := list("/acks?active=true")
+	require.Len(t, res, 1)
+	require.Equal(t, created.AckID, res[0].ID)
+	require.Equal(t, "me", res[0].By)
+
+	code, _ = do("DELETE", "/ack/"+created.AckID, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Nil(t, acknowledgement())
+	code, _ = do("DELETE", "/ack/"+created.AckID, "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	require.Len(t, list("/acks?active=true"), 0)
+	require.Len(t, list("/acks"), 1)
+
+	code, _ = do("GET", "/acks?active=xyz", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestLookupTables(t *testing.T) {
+	enricher, err := enrich.New(enrich.Options{})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "services.yml")
+	require.NoError(t, os.WriteFile(file, []byte("checkout: {team: payments}\n"), 0o644))
+	require.NoError(t, enricher.ApplyConfig([]*config.LookupTable{
+		{Name: "services", File: file, KeyLabel: "service", Labels: []string{"team"}},
+		{Name: "teams", KeyLabel: "team", Annotations: []string{"slack_channel"}},
+	}))
+
+	api := New(Options{Enricher: enricher})
+	router := route.New()
+	api.Register(router, nil, nil, nil)
+
+	do := func(method, url, body string) (int, json.RawMessage) {
+		r, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		var res struct {
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+		return w.Code, res.Data
+	}
+
+	code, _ := do("PUT", "/lookupTables/teams", `{"payments":{"slack_channel":"#payments"}}`)
+	require.Equal(t, http.StatusOK, code)
+	for _, url := range []string{"/lookupTables/services", "/lookupTables/unknown"} {
+		code, _ = do("PUT", url, `{}`)
+		require.Equal(t, http.StatusBadRequest, code, url)
+	}
+
+	code, data := do("GET", "/lookupTables", "")
+	require.Equal(t, http.StatusOK, code)
+	var tables []enrich.TableStatus
+	require.NoError(t, json.Unmarshal(data, &tables))
+	require.Equal(t, []enrich.TableStatus{
+		{Name: "services", KeyLabel: "service", File: file, Rows: 1},
+		{Name: "teams", KeyLabel: "team", Managed: true, Rows: 1},
+	}, tables)
+
+	code, data = do("GET", "/lookupTables/teams", "")
+	require.Equal(t, http.StatusOK, code)
+	var rows enrich.Rows
+	require.NoError(t, json.Unmarshal(data, &rows))
+	require.Equal(t, enrich.Rows{"payments": {"slack_channel": "#payments"}}, rows)
+
+	code, _ = do("GET", "/lookupTables/unknown", "")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+// recordingAlerts records the alerts put into the provider.
+type recordingAlerts struct {
+	*fakeAlerts
+	put []*types.Alert
+}
+
+func (r *recordingAlerts) Put(alerts ...*types.Alert) error {
+	r.put = append(r.put, alerts...)
+	return nil
+}
+
+func TestAddAlertsRelabeling(t *testing.T) {
+	conf, err := config.Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+relabel_configs:
+- source_labels: [sev]
+  target_label: severity
+- regex: sev
+  action: labeldrop
+- source_labels: [env]
+  regex: test
+  action: drop
+`)
+	require.NoError(t, err)
+
+	alertsProvider := &recordingAlerts{fakeAlerts: newFakeAlerts(nil, false)}
+	api := New(Options{Alerts: alertsProvider})
+	api.Update(conf, nil)
+
+	b, err := json.Marshal([]model.Alert{
+		{Labels: model.LabelSet{"alertname": "HighLatency", "sev": "critical"}},
+		{Labels: model.LabelSet{"alertname": "HighLatency", "env": "test"}},
+	})
+	require.NoError(t, err)
+	r, err := http.NewRequest("POST", "/api/v1/alerts", bytes.NewReader(b))
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	api.addAlerts(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.Len(t, alertsProvider.put, 1)
+	require.Equal(t, model.LabelSet{"alertname": "HighLatency", "severity": "critical"}, alertsProvider.put[0].Labels)
+}
@@ -12,10 +12,16 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/notify/email"
+	"github.com/prometheus/alertmanager/notify/kafka"
+	"github.com/prometheus/alertmanager/notify/mqtt"
 	"github.com/prometheus/alertmanager/notify/msteams"
+	"github.com/prometheus/alertmanager/notify/nats"
 	"github.com/prometheus/alertmanager/notify/opsgenie"
 	"github.com/prometheus/alertmanager/notify/pagerduty"
+	"github.com/prometheus/alertmanager/notify/plugin"
+	"github.com/prometheus/alertmanager/notify/servicenow"
 	"github.com/prometheus/alertmanager/notify/slack"
+	"github.com/prometheus/alertmanager/notify/sms"
 	"github.com/prometheus/alertmanager/notify/telegram"
 	"github.com/prometheus/alertmanager/notify/webhook"
 	"github.com/prometheus/alertmanager/template"
@@ -315,6 +321,98 @@ func (api *API) testReceiver(w http.ResponseWriter, req *http.Request) {
 			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
 		}
 		return
+	} else if receiver.ServiceNowConfigs != nil {
+		serviceNowConfig := receiver.ServiceNowConfigs[0]
+		serviceNowConfig.HTTPConfig = &commoncfg.HTTPClientConfig{}
+		notifier, err := servicenow.New(serviceNowConfig, tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
+	} else if receiver.SMSConfigs != nil {
+		smsConfig := receiver.SMSConfigs[0]
+		smsConfig.HTTPConfig = &commoncfg.HTTPClientConfig{}
+		if smsConfig.APIURL == nil {
+			smsConfig.APIURL = defaultGlobalConfig.TwilioAPIURL
+		}
+		notifier, err := sms.New(smsConfig, tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
+	} else if receiver.KafkaConfigs != nil {
+		notifier, err := kafka.New(receiver.KafkaConfigs[0], tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		defer notifier.Close()
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
+	} else if receiver.NATSConfigs != nil {
+		notifier, err := nats.New(receiver.NATSConfigs[0], tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		defer notifier.Close()
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
+	} else if receiver.MQTTConfigs != nil {
+		notifier, err := mqtt.New(receiver.MQTTConfigs[0], tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		defer notifier.Close()
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
+	} else if receiver.PluginConfigs != nil {
+		var plugins *plugin.Set
+		if api.plugins != nil {
+			plugins = api.plugins.Current()
+		}
+		notifier, err := plugin.New(receiver.PluginConfigs[0], plugins, tmpl, api.logger)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, "failed to prepare message for select config")
+			return
+		}
+		ctx := getCtx(receiver.Name)
+		dummyAlert := getDummyAlert()
+		_, err = notifier.Notify(ctx, &dummyAlert)
+		if err != nil {
+			api.respondError(w, apiError{err: err, typ: errorInternal}, fmt.Sprintf("failed to send test message to channel (%s)", receiver.Name))
+			return
+		}
 	} else if receiver.EmailConfigs != nil {
 		emailConfig := receiver.EmailConfigs[0]
 		emailConfig.From = defaultGlobalConfig.SMTPFrom
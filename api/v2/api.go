@@ -30,6 +30,7 @@ import (
 	prometheus_model "github.com/prometheus/common/model"
 	"github.com/prometheus/common/version"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/prometheus/alertmanager/api/metrics"
 	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
@@ -43,10 +44,13 @@ import (
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/enrich"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/tracing"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -57,6 +61,7 @@ type API struct {
 	alerts         provider.Alerts
 	alertGroups    groupsFn
 	getAlertStatus getAlertStatusFn
+	enricher       *enrich.Enricher
 	uptime         time.Time
 
 	// mtx protects alertmanagerConfig, setAlertStatus and route.
@@ -86,6 +91,7 @@ func NewAPI(
 	peer cluster.ClusterPeer,
 	l log.Logger,
 	r prometheus.Registerer,
+	enricher *enrich.Enricher,
 ) (*API, error) {
 	api := API{
 		alerts:         alerts,
@@ -93,6 +99,7 @@ func NewAPI(
 		alertGroups:    gf,
 		peer:           peer,
 		silences:       silences,
+		enricher:       enricher,
 		logger:         l,
 		m:              metrics.NewAlerts("v2", r),
 		uptime:         time.Now(),
@@ -293,8 +300,12 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 	alerts := OpenAPIAlertsToAlerts(params.Alerts)
 	now := time.Now()
 
+	_, span := tracing.StartServerSpan(params.HTTPRequest, "api.v2.postAlerts", attribute.Int("alerts", len(alerts)))
+	defer span.End()
+
 	api.mtx.RLock()
 	resolveTimeout := time.Duration(api.alertmanagerConfig.Global.ResolveTimeout)
+	relabelConfigs := api.alertmanagerConfig.RelabelConfigs
 	api.mtx.RUnlock()
 
 	for _, alert := range alerts {
@@ -321,6 +332,23 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 		}
 	}
 
+	if len(relabelConfigs) > 0 {
+		relabeled := alerts[:0]
+		for _, a := range alerts {
+			ls, keep := relabel.Process(a.Labels, relabelConfigs...)
+			if !keep {
+				api.m.Dropped().Inc()
+				continue
+			}
+			a.Labels = ls
+			relabeled = append(relabeled, a)
+		}
+		alerts = relabeled
+	}
+	if api.enricher != nil {
+		api.enricher.Enrich(alerts...)
+	}
+
 	// Make a best effort to insert all alerts that are valid.
 	var (
 		validAlerts    = make([]*types.Alert, 0, len(alerts))
@@ -336,12 +364,15 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 		}
 		validAlerts = append(validAlerts, a)
 	}
+	span.SetAttributes(attribute.Int("alerts.valid", len(validAlerts)))
 	if err := api.alerts.Put(validAlerts...); err != nil {
+		tracing.RecordError(span, err)
 		level.Error(logger).Log("msg", "Failed to create alerts", "err", err)
 		return alert_ops.NewPostAlertsInternalServerError().WithPayload(err.Error())
 	}
 
 	if validationErrs.Len() > 0 {
+		tracing.RecordError(span, validationErrs)
 		level.Error(logger).Log("msg", "Failed to validate alerts", "err", validationErrs.Error())
 		return alert_ops.NewPostAlertsBadRequest().WithPayload(validationErrs.Error())
 	}
@@ -163,9 +163,9 @@ var Assets = func() http.FileSystem {
 		"/templates/default.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "default.tmpl",
 			modTime:          time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC),
-			uncompressedSize: 17526,
+			uncompressedSize: 20805,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x1b\xfd\x6f\xdb\xb6\xf2\x77\xfd\x15\x37\x0d\x0f\x6b\x00\x7f\xa5\xdd\x8a\xc5\xb1\xf3\xe0\x3a\x4a\x23\x3c\xc7\x0e\x6c\xa5\x5d\x31\x0c\x01\x2d\x9d\x6d\xb6\x12\xa9\x91\x54\x1c\x2f\xf5\xff\xfe\x40\x4a\xfe\x90\x3f\x12\xa7\xe8\x92\xec\x3d\x2f\xd8\x66\x51\xf7\x7d\xc7\xbb\xa3\x48\xde\xdd\x41\x80\x03\xca\x10\xec\xeb\x6b\x12\xa2\x50\x11\x61\x64\x88\xc2\x86\xe9\xb4\xb1\xf4\x7c\x77\x07\xc8\x02\x98\x4e\xad\xad\x28\x57\xdd\x96\xc6\xba\xbb\x83\x92\x73\xab\x50\x30\x12\x5e\x75\x5b\x30\x9d\x96\x7f\x2c\x1b\x38\xf9\x6f\x81\x3e\xd2\x1b\x14\x75\x0d\xd4\xcd\x1e\xe0\x2b\x24\x22\xfc\x33\x41\x31\x49\xd1\x33\x46\x79\x4e\x32\xe9\x7f\x46\x5f\x69\x0e\xbf\x6b\xec\x9e\x22\x2a\x91\xf0\x15\x14\xbf\x8a\x63\x14\x29\x2a\x1d\x00\xfe\x39\x7f\x69\x0f\xa8\xa0\x6c\xa8\x71\xaa\x1a\xc7\x28\x24\x4b\x67\x66\x14\xbe\x42\x88\x6c\x99\xe3\x1f\xa0\x81\xde\x0b\x9e\xc4\x2d\xd2\xc7\x50\x96\x7a\x5c\x28\x0c\x2e\x09\x15\xb2\xf4\x81\x84\x09\x6a\x86\x9f\x39\x65\x60\x83\xa6\x0a\x29\xcb\xa1\x82\x57\x9a\x56\xa9\xc9\xa3\x88\xb3\x14\xf9\x20\x1b\x5b\xa2\x77\x00\xd3\xe9\xab\xbb\x3b\x18\x53\x35\xca\x03\x97\xba\x18\xf1\x1b\xcc\x73\x6f\x93\x08\x65\x66\xd1\x4d\xdc\xe7\x82\x1f\xcc\x7f\x6d\x71\x53\x80\xd2\x17\x34\x56\x94\x33\xfb\x1e\x1b\x2b\xbc\x55\xa9\x4b\xaf\x43\x2a\x55\x06\x2a\x08\x1b\x22\x94\x60\x3a\x4d\xe5\xaa\x5a\x8b\xc1\x75\x3b\x69\xab\x14\x8d\x21\xb5\xf8\xfa\xa9\x0e\x73\x05\x32\xc1\x52\xe6\x0d\xc6\xb8\x22\x5a\xa6\x1c\xc9\xa5\xe1\x6f\xa3\xdb\xe3\x89\xf0\xb1\x9a\x3a\x13\x19\x0a\xa2\xb8\x48\x23\xd1\xda\x60\xa8\x9c\x0d\x64\x48\xfc\x2f\xa5\x00\x07\x24\x09\x55\x49\x51\x15\x62\x66\x05\x85\x51\x1c\x12\x95\x8f\xc5\xd2\x36\x93\xe7\xe9\x24\x52\xcf\x86\x68\x13\xa9\xfc\x9c\xdb\x91\xde\x80\x84\x61\x9f\xf8\x5f\xd6\xe8\x6d\x14\x5f\x13\x85\xaf\xf0\x10\x60\x48\xd9\x97\x9d\x25\xf0\x33\x09\x68\x60\xef\x86\x10\x0b\xd4\xd1\xb5\x23\xf4\x92\x40\xf7\x5a\xcc\xa4\x9c\x1d\x45\xa6\x3e\x67\x18\xf1\xcf\xd4\xde\x1d\x3e\x11\xe1\xae\x12\xef\xae\xdc\x80\x73\x95\x26\xd8\x2d\x41\x18\x6b\xd5\x82\x44\x4d\xe6\x28\xeb\xf3\xf7\x71\xe1\xb8\x4e\xd1\x0f\x29\x32\xf5\xed\x01\xb9\x8d\xe2\xa2\x08\x7c\x9b\xcf\xd6\xe9\x52\x26\x15\x61\x3e\xca\x0d\x74\xd7\x12\x56\x69\xbb\x55\x79\x2c\x87\xc8\x28\xce\x09\x47\x28\x25\x19\x7e\xdb\xfc\x5e\x23\xb6\xee\xa1\x2c\xbf\x6f\x49\x67\x1b\x13\xba\xb5\x52\x4e\x72\xf5\xea\x00\x2a\x50\x9c\x4e\xad\x74\x10\xd2\x41\x93\x38\xef\xb7\x48\xbe\xe8\x19\x26\xc5\x25\x8d\x36\xf0\xeb\xa2\xe4\xe1\x0d\x06\x2b\x1c\x67\xc3\xbb\xf3\x9c\x61\xac\x71\x2d\xee\x62\x52\x69\xf2\xf8\xe3\xa3\x29\xe7\xf5\x31\xfa\x23\xa2\x1e\xeb\x73\x6b\xef\xbf\x7b\xfc\xb7\xdc\x17\x5e\x89\x70\x8d\xde\x46\xff\x6c\xf1\xfa\x8a\x7f\x14\xbf\xd6\xc5\x72\x6b\x26\x5d\x07\x8f\x89\x50\x93\x47\xc0\x2b\x32\xdc\x15\x9a\x0c\x91\xa9\xeb\xd5\x12\x97\x8f\xaf\x1b\xea\x2b\x2e\x78\x2c\x17\x61\xab\x88\xc2\xeb\x7c\xa0\xed\x63\xe9\x71\xb9\x60\xdd\xaa\xc8\x14\x55\x93\xeb\x80\xca\x38\x24\x93\xeb\x2d\xdd\xd4\xc3\x89\x7b\x9d\x72\xc4\x19\x55\x5c\x1b\xe4\x5a\x71\x1e\x3e\xb2\x24\x2e\xd3\xc6\x88\xd0\x70\x11\x07\x8b\x05\xcb\xa3\xa5\xcc\x53\x1a\xa9\xc8\x88\x65\xd5\x7e\x38\xed\x34\xbd\x4f\x97\x0e\xe8\x21\xb8\xbc\x7a\xd7\x72\x9b\x60\x17\xcb\xe5\x8f\x6f\x9a\xe5\xf2\xa9\x77\x0a\xbf\x9d\x7b\x17\x2d\x38\x2c\x55\xc0\x13\x84\x49\xaa\x83\x8d\x84\xe5\xb2\xd3\xb6\xc1\x1e\x29\x15\x57\xcb\xe5\xf1\x78\x5c\x1a\xbf\x29\x71\x31\x2c\x7b\xdd\xf2\xad\xa6\x75\xa8\x91\xb3\x9f\x45\xb5\x84\x59\x0a\x54\x60\x9f\x58\xb5\x1f\x8a\x45\xab\xa7\x26\x21\x02\x61\x01\x18\x26\x01\x0a\xaa\x1d\x3a\x10\x3c\x02\x4d\x5a\x56\xcb\xe5\x21\x55\xa3\xa4\x5f\xf2\x79\x54\xd6\x3a\x0c\x13\x56\x36\xe4\x88\x9f\xd2\x2b\x1a\xd5\x8a\x33\x73\x48\xcb\xb2\xbc\x11\xc2\x85\xeb\x41\x8b\xfa\xc8\x24\xc2\xab\x0b\xd7\x3b\xb0\xac\x26\x8f\x27\x82\x0e\x47\x0a\x5e\xf9\x07\xf0\xba\x72\xf8\x33\x5c\xa4\x14\x2d\xeb\x12\x45\x44\xa5\xa4\x9c\x01\x95\x30\x42\x81\xfd\x09\x0c\x05\x61\x0a\x83\x02\x0c\x04\x22\xf0\x01\xf8\x23\x22\x86\x58\x00\xc5\x81\xb0\x09\xc4\x28\x24\x67\xc0\xfb\x8a\x50\xa6\xe3\x9f\x80\xcf\xe3\x89\xc5\x07\xa0\x46\x54\x82\xe4\x03\x35\x26\x22\xd5\x90\x48\xc9\x7d\x4a\x14\x06\x10\x70\x3f\x89\x90\xa5\x13\x17\x06\x34\x44\x09\xaf\xd4\x08\xc1\xee\x65\x18\xf6\x81\x61\x12\x20\x09\x2d\xca\x40\xbf\x9b\xbd\x32\x6b\x3d\x9e\x28\x10\x28\x95\xa0\xc6\x0a\x05\xa0\xcc\x0f\x93\x40\xcb\x30\x7b\x1d\xd2\x88\x66\x1c\x34\xba\x51\x5c\x5a\x8a\x43\x22\xb1\x60\xe4\x2c\x40\xc4\x03\x3a\xd0\xff\x47\xa3\x56\x9c\xf4\x43\x2a\x47\x05\x08\xa8\x26\xdd\x4f\x14\x16\x40\xea\x41\x63\xc7\x82\xd6\xa3\xcc\x05\x48\x0c\x43\xcb\xe7\x31\x45\x09\x46\xd7\x85\x74\x06\x46\x8b\x1e\x6b\x83\xaa\xcc\x44\x52\x8f\x8c\x47\x3c\xca\x6b\x42\xa5\x35\x48\x04\xa3\x72\x84\x06\x27\xe0\x20\xb9\xe1\xa8\xa3\x59\x8f\x68\xf0\x01\x0f\x43\x3e\xd6\xaa\xf9\x9c\x05\x34\x5b\xde\x19\x27\x93\xbe\x5e\xe2\xfa\x73\xbf\x32\xae\xa8\x9f\x9a\xdb\x38\x20\x5e\x78\x35\x7b\x25\x47\x24\x0c\xa1\x8f\x99\xc1\x30\x00\xca\x80\x2c\xa9\x23\x34\x7b\xdd\x1f\x2a\x4a\x42\x88\xb9\x30\xfc\x56\xd5\x2c\x59\x96\x77\xee\x40\xaf\x73\xe6\x7d\x6c\x74\x1d\x70\x7b\x70\xd9\xed\x7c\x70\x4f\x9d\x53\xb0\x1b\x3d\x70\x7b\x76\x01\x3e\xba\xde\x79\xe7\xca\x83\x8f\x8d\x6e\xb7\xd1\xf6\x3e\x41\xe7\x0c\x1a\xed\x4f\xf0\x1f\xb7\x7d\x5a\x00\xe7\xb7\xcb\xae\xd3\xeb\x41\xa7\x6b\xb9\x17\x97\x2d\xd7\x39\x2d\x80\xdb\x6e\xb6\xae\x4e\xdd\xf6\x7b\x78\x77\xe5\x41\xbb\xe3\x41\xcb\xbd\x70\x3d\xe7\x14\xbc\x0e\x68\x86\x19\x29\xd7\xe9\x69\x62\x17\x4e\xb7\x79\xde\x68\x7b\x8d\x77\x6e\xcb\xf5\x3e\x15\xac\x33\xd7\x6b\x6b\x9a\x67\x9d\x2e\x34\xe0\xb2\xd1\xf5\xdc\xe6\x55\xab\xd1\x85\xcb\xab\xee\x65\xa7\xe7\x40\xa3\x7d\x0a\xed\x4e\xdb\x6d\x9f\x75\xdd\xf6\x7b\xe7\xc2\x69\x7b\x25\x70\xdb\xd0\xee\x80\xf3\xc1\x69\x7b\xd0\x3b\x6f\xb4\x5a\x9a\x95\xd5\xb8\xf2\xce\x3b\x5d\x2d\x1f\x34\x3b\x97\x9f\xba\xee\xfb\x73\x0f\xce\x3b\xad\x53\xa7\xdb\x83\x77\x0e\xb4\xdc\xc6\xbb\x96\x93\xb2\x6a\x7f\x82\x66\xab\xe1\x5e\x14\xe0\xb4\x71\xd1\x78\xef\x18\xac\x8e\x77\xee\x74\x2d\x0d\x96\x4a\x07\x1f\xcf\x1d\x3d\xa4\xf9\x35\xda\xd0\x68\x7a\x6e\xa7\xad\xd5\x68\x76\xda\x5e\xb7\xd1\xf4\x0a\xe0\x75\xba\xde\x1c\xf5\xa3\xdb\x73\x0a\xd0\xe8\xba\x3d\x6d\x90\xb3\x6e\xe7\xa2\x60\x69\x73\x76\xce\x34\x88\xdb\xd6\x78\x6d\x27\xa5\xa2\x4d\x0d\x39\x8f\x74\xba\xe6\xf9\xaa\xe7\xcc\x09\xc2\xa9\xd3\x68\xb9\xed\xf7\x3d\x8d\xac\x55\x9c\x01\x97\xac\x62\xf1\xc4\xaa\x99\x14\x78\x1b\x85\x4c\xd6\x37\x24\xb6\xc3\xa3\xa3\xa3\x34\x9f\xd9\xbb\x01\x49\x9d\xdc\xea\xf6\x80\x33\x55\x1c\x90\x88\x86\x93\x2a\xfc\x74\x8e\xe1\x0d\x2a\xea\x13\x68\x63\x82\x3f\x15\x60\x3e\x50\x80\x86\xa0\x24\x2c\x80\x24\x4c\x16\x25\x0a\x3a\x38\x86\x3e\xbf\x2d\x4a\xfa\x97\xae\xc5\xd0\xe7\x22\x40\x51\xec\xf3\xdb\x63\x30\x44\x25\xfd\x0b\xab\x70\xf8\x73\x7c\x7b\x0c\x11\x11\x43\xca\xaa\x50\x39\xd6\xb9\x75\x84\x24\x78\x4e\xfe\x11\x2a\x02\xba\xa2\xd6\xed\x1b\x8a\x63\x3d\x8b\x6c\x3d\x7b\x15\x32\x55\xb7\xc7\x34\x50\xa3\x7a\x80\x37\xd4\xc7\xa2\x79\x78\x3e\x63\x41\x79\x26\xae\x76\x66\x11\xff\x4c\xe8\x4d\xdd\x6e\xa6\xa2\x16\xbd\x49\x8c\x4b\x82\xeb\x56\xa4\xac\x9d\x7b\x6c\x2a\x81\x44\x55\xbf\xf2\xce\x8a\xbf\x3e\xb3\xf8\xe6\xdb\xc6\xf3\xb9\xfb\xbe\x5e\xa4\x56\x36\xc2\x9d\x58\x56\xad\xac\x83\x52\xff\xe8\xf3\x60\x02\x54\x61\x24\x7d\x1e\x63\xdd\xb6\xcd\x83\x9a\xe8\xdf\xd9\x8c\x92\xfe\x08\x23\x62\x66\x94\xa3\xab\xfb\xc5\xac\xf7\x7d\x52\x25\x8b\x63\xec\x7f\xa1\xaa\x98\xbe\x88\x38\x57\x23\x83\x94\xd6\x06\x4a\x24\x06\x0b\x20\x1d\x1b\x06\xbb\x48\x82\xcf\x89\x54\x55\x60\x9c\xe1\x31\x8c\x50\x57\xa6\x2a\x1c\x56\x2a\xff\x3a\x86\x90\x32\x2c\xce\x87\x4a\x6f\x31\x3a\x06\x33\x03\x52\x00\xf8\x81\x46\x7a\xb2\x10\xa6\x8e\xa1\x4f\xfc\x2f\x43\xc1\x13\x16\x14\x7d\x1e\x72\x51\x85\x1f\x07\x6f\xf5\xdf\xb2\xf9\x21\x26\x41\x60\xa4\xd2\xd1\xd0\x1f\x1a\xc8\xba\x9d\x41\xda\xda\xde\x8a\xf4\x9f\x3a\x3c\x96\x54\xda\x51\x8f\x8d\xb2\x03\xd4\x94\x78\xc6\x3c\x06\xa0\x25\x78\xe2\x4c\x7a\x83\x42\x13\x09\x8b\x24\xa4\x43\x56\x05\xc5\xe3\xbc\xa1\x6e\xcc\x8b\xba\xad\x78\x6c\x9f\xd4\xca\x2a\x58\x08\x9a\x66\x56\xfb\x6d\xa5\x62\xbf\x00\xa1\xb3\xa5\x55\x15\xfa\x21\xf7\xbf\xe4\x62\x3b\x22\xb7\xc5\x2c\x48\xde\x56\x2a\xf1\x6d\xee\xa5\x1f\x22\x11\x9a\xa1\x1a\xe5\xc6\xb7\x4d\x94\xb9\x71\x80\x24\x8a\xaf\x4c\x89\x9c\xb5\x8c\xa1\x00\x6a\x01\xbd\x79\xea\xb0\xca\xeb\xbb\x6a\x9c\xfb\x95\x98\xc9\xad\x9d\x6c\x26\x73\xe6\x67\x6d\x09\x1b\x7c\x0c\xc3\x0c\xba\x6e\x57\xd2\x67\x19\x13\x7f\xf6\xfc\xa4\x8a\x66\x2f\x05\x09\x68\x22\xab\xf0\xc6\x8c\x6d\x48\x00\x83\x41\x2e\x8b\xa5\x68\x55\x38\x8c\x6f\x41\xf2\x90\x06\xf0\x23\x1e\xe9\xbf\x7c\x62\x18\x0c\x96\x6c\xf1\x12\xb2\xc3\x42\x92\xa7\xcb\x12\x6f\xb7\x4e\xb8\x9c\x75\x0d\xca\x38\x2b\x35\xbf\x54\x2a\xc7\x60\x4a\x54\x06\xef\x23\x53\x28\x36\xf9\xcb\xfc\x5b\x31\x4e\x59\xf7\x9b\xf3\xf6\x97\xd7\xaf\x9b\x9b\x0b\xd0\x6b\x1d\xd7\x36\x64\xf3\x2d\x65\xb0\xec\xbd\x14\x77\xf3\x8c\x9c\xfd\xb3\xd8\x53\x9d\x6f\xa6\x82\xf9\x58\xb2\xf1\x5b\xd2\x01\x1c\xc2\x74\x2a\xe7\x1f\x3c\x60\xc0\x05\x2c\xf6\xfd\xb6\xec\xbb\xc2\x74\xba\xc2\x15\x96\x77\x01\xeb\xb9\x3d\xc0\x35\xb0\xec\xd3\x4a\xce\xf9\xf3\x1c\x3c\x7f\x16\xfb\x30\xdd\xa5\x98\x2d\x82\xe7\x30\x0d\x9e\xfb\x62\xe3\xc5\xe7\xbe\xad\x66\x7f\x59\x41\xf0\xd2\x43\xa1\x02\x95\x59\x2e\xb9\x2f\x1c\x32\x35\x08\x8c\x04\x0e\xea\xf6\x2e\x3b\x06\x4f\x1c\x0f\xb3\xa4\x79\x76\x76\x96\x25\xdf\x00\x7d\x2e\xcc\x37\xb9\xd9\xf2\x20\xb7\x20\x78\xad\x97\x03\xb9\xbc\xdd\xe7\x61\xb0\x39\x71\xfb\x89\x90\x9a\x7a\xcc\x69\x3a\x30\x6f\x28\x28\x33\x44\xb3\xbe\x62\x25\xc1\xff\xa2\x05\x33\xf4\xcc\x47\xd4\x01\x17\x51\x15\x7c\x12\x53\x45\x42\xfa\x17\x6e\x4c\xfa\x6f\x7e\xfe\x15\x03\xb2\xa1\x5e\xaf\x41\x64\xc3\xc6\xca\xd5\xb4\x90\xcf\x07\xe7\xdd\x5b\x7c\x9b\xb9\xf7\xe4\x03\xc5\x31\x50\x06\x0f\x7e\x1d\xaf\x95\xc9\xc6\x18\x5e\x49\xbc\x9b\xd3\xef\x3c\x75\xdf\xbb\xf9\xb1\xa1\x28\xec\xa7\xec\xdf\x33\x65\xa5\x12\x9c\x0d\x9f\xcf\xb4\xbf\x6f\x3f\xb9\xf5\x47\xb6\xf3\x55\x2b\xa7\x42\x7e\x87\xa8\xdb\xd0\x30\x64\x6f\x66\xc7\x93\x56\xb7\xd0\xf6\x71\xf8\xff\x11\x87\x69\x6b\x3a\x0f\xb5\x5a\x5f\x3c\xeb\x77\xc4\x4d\x36\x7a\xe0\x5c\xde\xf6\xc3\x73\xcf\xac\xcc\xf6\x79\xb7\xa9\x16\x2c\x36\xd1\xd3\x4a\xf0\xec\x91\xb1\x24\xd1\x4b\x09\x8f\x07\x2d\xfa\xe0\x61\xcb\x7f\x68\xb0\x2c\x77\x98\xab\xa7\x3f\x9f\xa9\xa1\x9c\xb5\x5b\x6b\x3d\x65\xc2\x02\x14\xba\xfb\xcb\x87\x53\x7a\x7e\x55\x37\x51\x2f\x2f\xc7\x7c\x5b\x35\xdd\xb1\xbd\x5b\x3e\x6b\xb2\xd1\xbd\xfb\xae\xf0\xc5\x54\xe3\x17\x58\xfd\x6a\xa3\x17\x28\xd3\x3f\x7a\x06\xdf\xd7\x11\xef\x27\xd6\xff\xfe\x72\x6b\x7e\x66\x6f\xb1\xe0\x9a\x0d\x3d\xc3\x92\x6b\xf9\x04\xe1\x3e\x1a\xf7\x8b\xae\xfd\xa2\x6b\xbf\xe8\xda\x2f\xba\xf6\x8b\xae\xfd\xa2\x6b\x87\x7a\x5a\x2b\x9b\xfd\xb8\x93\x47\x6c\x85\xce\x51\x16\x23\x4f\x7e\x12\x23\x77\x34\x69\xe9\xa4\xc9\xc2\xd1\x47\x47\x47\xf7\x6d\x70\xe7\x77\x76\xd7\xb7\x24\x5f\xca\x4e\xef\xcb\x69\x5f\x9e\xb2\x75\x79\xbd\xb5\x75\xd9\xb8\x89\xf6\x90\xcb\x97\x7a\x9b\x95\x73\x0d\xf9\x53\x58\xcb\xe9\x2a\x7f\x55\xdd\x7e\x5a\xd5\x73\x1a\xed\x9c\xaa\x90\x29\xe8\x4f\x76\xdb\x87\x5b\xcf\x1d\x6b\xe7\x1d\x56\x33\x43\xad\x1c\xd0\x9b\x93\xf4\xbf\x56\x3e\x4d\xfc\x43\x8e\xd7\xa5\x2a\x2e\xf2\x57\xad\xdc\xe7\xc1\x44\x8f\x8c\x54\x14\x9e\x58\xd6\xe6\xfb\x3b\x71\x22\x47\xfc\x06\xc5\x77\xb8\xff\xbd\x46\xea\xef\xbf\x0f\xf6\x7d\xae\x83\xed\x7e\x1b\xec\xfb\x5d\x06\x5b\xe2\xb9\x83\x25\x17\x77\xb2\x1f\x73\x27\x74\xf9\x36\x36\x93\xdf\xe5\x92\xd6\x32\x9d\xbd\x7b\x1f\xe3\xde\xff\x06\x00\x00\xff\xff\x0a\xd2\x4d\x62\x76\x44\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x5c\x7d\x6f\xdb\x36\xb7\xff\x5f\x9f\xe2\x4c\xc5\xc5\x1a\xc0\x6f\x49\x5f\xb0\x3a\x4e\x2e\xdc\xc4\x69\x84\xeb\xd8\x81\xad\xb4\x2b\x86\x21\xa0\xa5\x23\x9b\xad\x44\x6a\x24\x15\xc7\x4b\xfd\xdd\x2f\x28\xc9\xb6\x64\xcb\x89\xd2\xf5\x49\xd2\x67\x59\xb0\xcd\xa2\x78\xde\x7e\xe7\xf0\xf0\x90\xa2\x74\x73\x03\x2e\x7a\x94\x21\x98\x97\x97\xc4\x47\xa1\x02\xc2\xc8\x18\x85\x09\xf3\x79\x3b\x73\x7d\x73\x03\xc8\x5c\x98\xcf\x8d\xad\x24\x17\x83\xae\xa6\xba\xb9\x81\x5a\xe7\x5a\xa1\x60\xc4\xbf\x18\x74\x61\x3e\xaf\xbf\xa8\xc7\xfd\xe4\xff\x0a\x74\x90\x5e\xa1\x38\xd0\x9d\x06\xe9\x05\x7c\x83\x48\xf8\x7f\x45\x28\x66\x09\x79\x2a\x28\x2f\x49\x46\xa3\x2f\xe8\xa8\x54\x02\xf5\xa0\x76\x4c\xc7\x28\x55\x72\xad\x30\x08\x7d\xa2\x10\x4c\x37\x6e\xad\xb9\xe8\x91\xc8\x57\xb5\x25\x59\x2d\xe5\xed\x4b\x84\xf9\xfc\x0f\xad\xc0\x50\x11\x15\x49\xf8\x06\x8a\x5f\x84\x21\x8a\x25\x6b\xfc\x6b\x79\xd3\xf4\xa8\xa0\x6c\xac\xc5\x36\x35\x4d\x8c\x89\xac\x9d\xc4\xad\xf0\x0d\x7c\x64\x59\xa5\xff\x04\xdd\xe9\x83\xe0\x51\xd8\x25\x23\xf4\x65\x6d\xc8\x85\x42\xf7\x9c\x50\x21\x6b\x1f\x89\x1f\xa1\x16\xf8\x85\x53\x06\x26\x68\xae\x90\x88\x1c\x2b\x78\xa9\x79\xd5\x8e\x78\x10\x70\x96\x10\xef\xa4\x6d\x19\x7e\x3b\x30\x9f\xbf\xbc\xb9\x81\x29\x55\x93\x7c\xe7\xda\x00\x03\x7e\x85\x79\xe9\x3d\x12\xa0\x4c\x9d\x52\x24\x7d\xa9\xf8\xce\xf2\xd7\xe6\x8f\xbc\x23\x5c\x94\x8e\xa0\xa1\xa2\x9c\x99\xb7\xf8\x4b\xe1\xb5\x4a\xc2\xe3\xd2\xa7\x72\xe1\x37\x41\xd8\x18\x63\x5f\x24\x0a\x36\x8d\x55\xe3\x26\x60\x1a\x9e\x6a\x8c\xa8\xb6\x43\x5f\x1d\xc0\xd2\x92\x54\xb1\x44\x78\x9b\x31\xae\x88\xd6\x29\xc7\x32\xd3\xfc\x7d\x7c\x87\x3c\x12\x0e\x36\x13\xaf\x22\x43\x41\x14\x17\x49\x54\x1b\x4b\x2f\xb4\x9d\xaf\x8c\x4f\x7d\x74\xc7\x18\x20\xd3\x11\x99\x69\x71\x61\x34\x4b\xe8\xdf\xcf\x56\xb1\xab\x1d\x97\x74\x05\xed\xcd\xcc\xf5\x4e\x0e\xf6\xa5\x27\x34\xd1\x89\x4f\xc2\x50\x87\xdd\x7c\xbe\xf8\xd9\x04\x2f\x12\x6a\x82\x02\x18\x57\xd4\xa3\x4e\x62\x2c\x10\x81\x20\xa3\x30\x14\x28\x25\xba\x10\x31\x45\x7d\x50\x13\x84\xd8\x23\x20\x15\x0f\x25\x78\x29\x13\xe3\x36\xdf\xdf\xea\xd5\xcb\x80\x88\xaf\x2e\x9f\xb2\x0d\xf7\x1a\x65\xfd\x5b\xd2\x11\xc6\xfd\x3d\x5c\x96\xf3\xbf\xd5\xc7\xc6\x36\x27\x27\x29\xf4\xb6\xc1\x9b\x42\x5b\x32\xc5\xad\x40\xcc\x38\x4b\x46\x41\x40\xe2\x8c\x9f\x20\x97\xd5\x0c\x5e\x4a\xca\x1c\x84\x34\x49\x0b\x25\xdb\xaa\x76\xc2\x45\x40\x14\x98\x7b\x8d\xc6\xdb\x6a\x63\xb7\xda\xd8\x83\xdd\x37\xcd\xc6\x6b\x38\x1b\xda\x5a\xca\x8e\x71\x47\xf4\x6e\x9b\x19\xe6\xf3\x3f\x8e\xad\x0f\x9d\xa1\x9d\x24\xef\x64\x5a\xd1\x19\x1e\xdd\x65\x82\x07\x4f\x5f\x56\xb2\x1d\x06\x28\xb9\x7f\x95\xed\x23\xd2\x96\x5c\xb7\x7e\x88\x6c\xd5\x85\x87\xc8\x0a\xf3\xea\x9a\x72\x7a\x9c\x2d\xa7\x60\x09\x9e\xe0\x41\x96\x69\x0c\xca\x9d\x88\x80\xe2\x59\xa2\x0e\x73\xef\x24\x31\x72\x73\x6b\x0a\xc2\x7c\x6e\xc4\x3f\x9a\x46\x6e\xa6\x2d\x0c\x94\x75\xca\xf5\xf8\x5e\x07\x6f\x3e\x37\x16\xbf\xef\xc3\x3e\x43\xbf\x45\x42\x8c\xbb\x1e\xdd\x8a\xfa\x7e\x8c\xfb\x7d\xf8\xa7\xd4\x77\x44\x94\xf4\x89\xf3\x75\xe5\x33\xaa\x7c\x34\xd7\xeb\x91\x4c\xe5\x52\xdb\x36\xa9\xe6\xf9\x44\x52\xd7\x4e\x41\x11\xab\x7c\x85\x56\x92\x9f\x47\x7c\x7f\x44\x9c\xaf\x1b\xfc\x0a\xd5\xd7\x4c\xe1\x1b\xdc\xd5\xd1\xa7\xec\x6b\x69\x0d\x9c\x54\x03\xea\x9a\xe5\x08\x42\x81\x8b\x11\x50\xa2\x77\x46\xa1\x5b\x11\x8b\x0b\xd4\x92\x2a\x53\x87\x33\x0c\xf8\x17\x6a\x96\xef\x1f\x09\xbf\xac\xc6\xe5\x8d\xf3\x38\x57\x28\xf2\x9d\x73\x41\x18\x6a\xd3\xdc\x48\xcd\x96\x24\x9b\x15\xda\xfd\xc2\x71\x93\xa3\xe3\x53\x64\xea\xfb\x03\x72\x1b\xc7\xd5\x92\xe1\xfb\x7c\xb6\xc9\x97\x32\xa9\x08\x73\x50\x16\xf0\xdd\x28\x49\x6b\xdb\x51\xe5\xa1\x1c\x23\xa3\xb8\x64\x1c\xa0\x94\x64\xfc\x7d\xe3\x7b\x83\xd9\xa6\x87\xd2\x52\x7e\x4b\x39\x53\x38\xad\x1a\x6b\x2b\x87\xdc\xd2\x64\x07\x1a\x50\xd5\x65\x53\xdc\x08\x49\x63\xd3\xb8\x13\x91\x1c\x93\x44\x48\x35\x63\x51\x81\xbc\x45\x2a\x5e\x93\xb8\x35\xab\x6f\x95\x99\x9d\x13\x72\x52\xab\x65\x20\x95\x71\x15\x77\xff\x68\xca\x32\x55\xe8\xe3\x58\x90\xa0\xc8\xe9\x65\xd0\x6e\x8d\x0e\x73\x78\xb7\xea\xa3\xc3\x6c\xad\xba\x86\xed\x63\x2f\x81\xca\x16\xc8\x5b\x7e\xdd\x1d\x0b\x2b\x3c\x16\x37\x8a\x11\xc9\x78\xfe\xc7\x62\x62\x3c\x38\x28\x05\x51\x35\x45\x67\x42\xd4\x7d\x13\x89\xf1\x9c\x14\x6e\x49\x0a\xd9\xad\xa9\x0b\xe1\x6f\xf0\x2b\x1c\xf4\x5b\x52\xc9\x9a\x7f\x14\xbf\xd4\x15\xd8\xd6\xe9\x79\xb3\x7b\x48\x84\x9a\xdd\xa3\xbf\x22\xe3\xb2\xbd\xc9\x18\x99\xba\x5c\xaf\x9b\xf2\x53\xd5\x15\x75\x14\x17\x3c\x94\x4b\x2a\xa9\x88\xc2\xcb\x7c\xa0\x3d\xc7\xd2\xfd\x26\x98\x4d\x54\x91\x29\xaa\x66\x97\x2e\x95\xa1\x4f\x66\x97\x5b\x4a\xf4\xbb\xab\x81\x4d\xce\x01\x67\x54\x71\x0d\xc8\xa5\xe2\xdc\xbf\x67\x9d\x95\xe5\x8d\x01\xa1\x7e\xd1\x12\xf7\xde\x5a\xe6\x39\x4d\x54\x10\xab\x65\xb4\x7e\x39\xee\x1f\xd9\x9f\xcf\x3b\xa0\x9b\xe0\xfc\xe2\x7d\xd7\x3a\x02\xb3\x5a\xaf\x7f\x7a\x75\x54\xaf\x1f\xdb\xc7\xf0\xfb\xa9\x7d\xd6\x85\xdd\x5a\x03\x6c\x41\x98\xa4\x3a\xd8\x88\x5f\xaf\x77\x7a\x26\x98\x13\xa5\xc2\x66\xbd\x3e\x9d\x4e\x6b\xd3\x57\x35\x2e\xc6\x75\x7b\x50\xbf\xd6\xbc\x76\x35\x71\xfa\xb3\xaa\x32\x94\x35\x57\xb9\xe6\xa1\xd1\xfa\xa5\x5a\x35\x86\x6a\xe6\x23\x10\xe6\x42\x2c\xc4\x45\x41\xb5\x43\xe3\xf5\xb1\x66\x2d\x9b\xf5\xfa\x98\xaa\x49\x34\xaa\x39\x3c\xa8\x6b\x1b\xc6\x11\xab\xc7\xec\x88\x93\xf0\xab\xc6\xa6\x55\x17\x70\x48\xc3\x30\xec\x09\xc2\x99\x65\x43\x97\x3a\xc8\x24\xc2\xcb\x33\xcb\xde\x31\x8c\x23\x1e\xce\x04\x1d\x4f\x14\xbc\x74\x76\x60\xaf\xb1\xfb\x1a\xce\x12\x8e\x86\x71\x8e\x22\xa0\x52\x52\xce\x80\x4a\x98\xa0\xc0\xd1\x0c\xc6\x82\x30\xa5\x37\x00\x3c\x81\x08\xdc\x03\x67\x42\xc4\x18\x2b\xa0\x38\x10\x36\x83\x10\x85\xe4\x0c\xf8\x48\x11\xca\x74\xfc\x13\x70\x78\x38\x33\xb8\x07\x6a\x42\x25\x48\xee\xa9\x29\x11\x89\x85\x44\x4a\xee\x50\xa2\xd0\x05\x97\x3b\x91\xde\x3b\x8a\x07\x2e\x78\xd4\x47\x09\x2f\xf5\x56\x8f\x39\x4c\x29\xcc\x9d\x58\x88\x8b\xc4\x37\x28\x8b\xb7\x81\x16\xb7\xe2\xcd\x17\x1e\x29\x10\x28\x95\xa0\x31\x0a\x15\xa0\xcc\xf1\x23\x57\xeb\xb0\xb8\xed\xd3\x80\xa6\x12\x34\x79\x6c\xb8\x34\x14\x87\x48\x62\x25\xd6\xb3\x02\x01\x77\xa9\xa7\xff\x8f\xb1\x59\x61\x34\xf2\xa9\x9c\x54\xc0\xa5\x9a\xf5\x28\x52\x58\x01\xa9\x1b\x63\x1c\x2b\xda\x8e\x3a\x17\x20\xd1\xf7\x0d\x87\x87\x14\x25\x70\x2f\xa7\x5d\xdc\x47\xab\x1e\x6a\x40\x55\x0a\x91\xd4\x2d\xd3\x09\x0f\xf2\x96\x50\x69\x78\x91\x60\x54\x4e\x30\xa6\x71\x39\x48\x5e\x81\x34\x9a\x75\x8b\xee\xee\x71\xdf\xe7\x53\x6d\x9a\xc3\x99\x4b\xd3\xd9\x3f\x76\x32\x19\xe9\x2d\x72\x67\xe9\x57\xc6\x15\x75\x12\xb8\x63\x07\x84\x2b\xaf\xa6\xb7\xe4\x84\xf8\x3e\x8c\x30\x05\x0c\x5d\xa0\x0c\x48\xc6\x1c\xa1\xc5\xeb\x45\x87\xa2\xc4\x87\x90\x8b\x58\xde\xba\x99\x35\xc3\xb0\x4f\x3b\x30\xec\x9f\xd8\x9f\xda\x83\x0e\x58\x43\x38\x1f\xf4\x3f\x5a\xc7\x9d\x63\x30\xdb\x43\xb0\x86\x66\x05\x3e\x59\xf6\x69\xff\xc2\x86\x4f\xed\xc1\xa0\xdd\xb3\x3f\x43\xff\x04\xda\xbd\xcf\xf0\x7f\x56\xef\xb8\x02\x9d\xdf\xcf\x07\x9d\xe1\x10\xfa\x03\xc3\x3a\x3b\xef\x5a\x9d\xe3\x0a\x58\xbd\xa3\xee\xc5\xb1\xd5\xfb\x00\xef\x2f\x6c\xe8\xf5\x6d\xe8\x5a\x67\x96\xdd\x39\x06\xbb\x0f\x5a\x60\xca\xca\xea\x0c\x35\xb3\xb3\xce\xe0\xe8\xb4\xdd\xb3\xdb\xef\xad\xae\x65\x7f\xae\x18\x27\x96\xdd\xd3\x3c\x4f\xfa\x03\x68\xc3\x79\x7b\x60\x5b\x47\x17\xdd\xf6\x00\xce\x2f\x06\xe7\xfd\x61\x07\xda\xbd\x63\xe8\xf5\x7b\x56\xef\x64\x60\xf5\x3e\x74\xce\x3a\x3d\xbb\x06\x56\x0f\x7a\x7d\xe8\x7c\xec\xf4\x6c\x18\x9e\xb6\xbb\x5d\x2d\xca\x68\x5f\xd8\xa7\xfd\x81\xd6\x0f\x8e\xfa\xe7\x9f\x07\xd6\x87\x53\x1b\x4e\xfb\xdd\xe3\xce\x60\x08\xef\x3b\xd0\xb5\xda\xef\xbb\x9d\x44\x54\xef\x33\x1c\x75\xdb\xd6\x59\x05\x8e\xdb\x67\xed\x0f\x9d\x98\xaa\x6f\x9f\x76\x06\x46\xd7\x5a\x68\x07\x9f\x4e\x3b\xba\x49\xcb\x6b\xf7\xa0\x7d\x64\x5b\xfd\x9e\x36\xe3\xa8\xdf\xb3\x07\xed\x23\xbb\x02\x76\x7f\x60\x2f\x49\x3f\x59\xc3\x4e\x05\xda\x03\x6b\xa8\x01\x39\x19\xf4\xcf\x2a\x86\x86\xb3\x7f\xa2\xbb\x58\x3d\x4d\xd7\xeb\x24\x5c\x34\xd4\x90\xf3\x48\x7f\x10\x5f\x5f\x0c\x3b\x4b\x86\x70\xdc\x69\x77\xad\xde\x87\xa1\x26\xd6\x26\x2e\x3a\xd7\x8c\x6a\xf5\xd0\x68\xc5\x29\xf0\x3a\xf0\x99\x3c\x28\x48\x6c\xbb\xef\xde\xbd\x4b\xf2\x99\x59\xae\x93\xd4\xc9\xed\xc0\xf4\x38\x53\x55\x8f\x04\xd4\x9f\x35\xe1\xd7\x53\xf4\xaf\x50\x51\x87\x40\x0f\x23\xfc\xb5\x02\xcb\x86\x0a\xb4\x05\x25\x7e\x05\x24\x61\xb2\x2a\x51\x50\x6f\x1f\x46\xfc\xba\x2a\xe9\xdf\xf1\xb6\xf1\x88\x0b\x17\x45\x75\xc4\xaf\xf7\x21\x66\x2a\xe9\xdf\xd8\x84\xdd\xd7\xe1\xf5\x3e\x04\x44\x8c\x29\x6b\x42\x63\x5f\xe7\xd6\x09\x12\xf7\x31\xe5\x07\xa8\x08\xe8\x19\xf5\xc0\xbc\xa2\x38\xd5\xa3\xc8\xd4\xa3\x57\x21\x53\x07\xe6\x94\xba\x6a\x72\xe0\xe2\x15\x75\xb0\x1a\x5f\x3c\x1e\x58\x50\x5f\xa8\xab\x9d\x59\xc5\xbf\x22\x7a\x75\x60\x1e\x25\xaa\x56\xed\x59\x88\x19\xc5\x75\x29\x52\xd7\xce\xdd\x8f\x67\x02\x89\xea\xe0\xc2\x3e\xa9\xfe\xf6\xc8\xea\xc7\x1b\x66\x8f\xe7\xee\xdb\x6a\x91\x56\x3d\x56\xee\xd0\x30\x5a\x75\x1d\x94\xfa\xc7\x88\xbb\x33\xa0\x0a\x03\xe9\xf0\x10\x0f\x4c\x33\xbe\x50\x33\xfd\x3b\x1d\x51\xd2\x99\x60\x40\xe2\x11\xd5\xd1\xb3\xfb\xd9\xa2\xf6\x7d\x50\x23\xab\x53\x1c\x7d\xa5\xaa\x9a\xdc\x08\x38\x57\x93\x98\x28\x99\x1b\x28\x91\xe8\xae\x3a\xe9\xd8\x88\xa9\xab\xc4\xfd\x12\x49\xd5\x04\xc6\x19\xee\xc3\x04\xf5\xcc\xd4\x84\xdd\x46\xe3\x7f\xf6\xc1\xa7\x0c\xab\xcb\xa6\xda\x5b\x0c\xf6\x21\x1e\x01\x49\x07\xf8\x85\x06\x7a\xb0\x10\xa6\xf6\x41\x6f\xb1\x8e\x05\x8f\x98\x5b\x75\xb8\xcf\x45\x13\x5e\x78\x6f\xf5\x5f\x16\x7e\x08\x89\xeb\xc6\x5a\xe9\x68\x18\x8d\xe3\x9e\x07\x66\xda\xd3\xd4\x78\x2b\x32\x7a\xe8\xf0\xc8\x98\x54\xd2\x8e\x42\xdd\x01\x5a\x4a\x3c\x62\x1e\x03\xd0\x1a\x3c\x70\x26\xbd\x42\xa1\x99\xf8\x55\xe2\xd3\x31\x6b\x82\xe2\x61\x1e\xa8\xab\xf8\xc6\x81\xa9\x78\x68\x1e\xb6\xea\xca\x5d\x29\x9a\x64\x56\xf3\x6d\xa3\x61\x3e\x01\xa5\xd3\xa5\x55\x13\x46\x3e\x77\xbe\xe6\x62\x3b\x20\xd7\xd5\x34\x48\xde\x36\x1a\xe1\x75\xee\xa6\xe3\x23\x11\x5a\xa0\x9a\xe4\xda\xb7\x0d\x94\x25\x38\x40\x22\xc5\xd7\x86\x44\x0e\xad\x18\x28\x80\x96\x4b\xaf\x1e\x3a\xac\xf2\xf6\xae\x83\x73\xbb\x11\x0b\xbd\x01\xd2\xc1\x9c\xfa\x59\x23\x61\x82\x83\xbe\x9f\xf6\x3e\x30\x1b\xc9\xb5\x0c\x89\xb3\xb8\x7e\x50\x43\xd3\x9b\x82\xb8\x34\x92\x4d\x78\x15\x5e\x17\x27\x00\xcf\xcb\x65\xb1\x84\xac\x09\xbb\xe1\x35\x48\xee\x53\x17\x5e\xe0\x3b\xfd\x97\x4f\x0c\x9e\x97\xc1\xe2\x29\x64\x87\x95\x26\x0f\x97\x25\xde\x6e\x1d\x70\x39\x74\x63\x92\x69\x3a\xd5\xbc\x69\x34\xf6\x21\x9e\xa2\xd2\xfe\x0e\x32\x85\xa2\xc8\x5f\xf1\xbf\x0d\x68\x14\xfa\xad\xf3\xf6\xcd\xde\xde\x51\xf1\x04\xb4\xa7\xe3\xda\x84\x74\xbc\x25\x02\xb2\xde\x4b\x68\x8b\x47\xe4\xe2\x9f\xd5\x99\xac\xd5\x43\xf6\x78\xb3\xa4\x70\x2f\x69\x07\x76\x61\x3e\x97\xab\x83\x06\x1e\x17\xb0\xda\x16\xde\x72\x6e\x4b\xef\x7b\x00\x6c\xca\x4d\xf7\x88\x0f\x72\x3b\xc4\x1b\xdd\x12\x49\x79\xe7\x2f\x73\xf0\xf2\x5a\x3c\x87\x69\x99\xc9\x6c\x15\x3c\xbb\x49\xf0\xdc\x16\x1b\x4f\x3e\xf7\x6d\x85\xfd\x69\x05\xc1\x53\x0f\x85\x06\x34\x60\xef\xee\x70\x48\xcd\x20\x30\x11\xe8\x1d\x98\x65\x9e\x18\x3c\x70\x3c\x2c\x92\xe6\xc9\xc9\x49\x9a\x7c\x5d\x74\xb8\x88\xf7\xe4\x16\xcb\x83\xdc\x82\x60\x0f\x83\x94\xcd\x22\x6f\x8f\xb8\xef\x16\x27\x6e\x27\x12\x52\x73\x0f\x39\x4d\x1a\x96\x05\x05\x65\x31\xd3\xb4\xae\x58\x4b\xf0\x6f\xc2\xeb\x94\x5f\xbc\x89\xea\x71\x11\x34\xc1\x21\x21\x55\xc4\xa7\x7f\x63\x61\xd2\x7f\xf5\xfa\x37\x74\x49\xc1\x7c\xbd\xd1\x23\x6d\x8e\x51\x6e\x26\x13\xf9\xb2\x71\x59\xbd\x85\xd7\xa9\x7b\x0f\x3f\x52\x9c\x02\x65\x70\xe7\xee\x78\xab\x4e\x0a\x63\x78\x2d\xf1\x16\xa7\xdf\x65\xea\xbe\xf5\xe1\xc7\x7c\xfe\x3c\x64\x1f\x68\xc8\x4a\x25\x38\x1b\x3f\x1e\xb4\x7f\x6c\x3f\xf9\xfd\xe7\xf2\x51\x7f\xa2\xe4\x0f\x88\xba\x82\x82\x21\xbd\xb3\xe5\x1c\xc1\x73\x1c\xfe\x4b\xe2\x30\x29\x4d\x97\xa1\xd6\x1a\x89\x47\xdd\x47\x2c\xc2\xe8\x8e\x73\x1b\xdb\x8f\x56\x3c\xb2\x31\xdb\xc7\x5d\xd1\x5c\xb0\x7a\x88\x9e\xcc\x04\x8f\x1e\x19\x19\x8d\x9e\x4a\x78\xdc\x89\xe8\x9d\x67\x71\x7e\xd2\x60\xc9\x56\x98\xeb\x2f\x14\x3c\x52\x41\xb9\x28\xb7\x36\x6a\xca\x88\xb9\x28\x74\xf5\x97\x0f\xa7\xe4\x95\x08\x5d\x44\x3d\xbd\x1c\xf3\x7d\xb3\x69\xc9\xf2\x2e\x7b\xd6\xa4\xd0\xbd\xcf\x55\xe1\x93\x99\x8d\x9f\xe0\xec\xd7\x9a\x3c\x41\x9d\x7e\xea\x11\x7c\x5b\x45\xfc\x3c\xb0\xfe\xfb\x97\x5b\x1b\x6f\x59\xfd\x99\x39\x4b\xfc\xe0\x4b\xae\xec\x09\xc2\xe7\x68\x7c\x5e\x74\x3d\x2f\xba\x9e\x17\x5d\xcf\x8b\xae\xe7\x45\xd7\xf3\xa2\xab\xc4\x7c\xda\xaa\xc7\xcf\xe3\x0e\xef\xf1\x28\x74\x49\xb2\x6a\x79\xf0\x93\x18\xb9\xa3\x49\x99\x93\x26\x2b\x47\xbf\x7b\xf7\xee\xb6\x07\xdc\x39\xf3\x0a\x1e\x49\x3e\x95\x27\xbd\x4f\xa7\x7c\x79\xc8\xd2\x65\x6f\x6b\xe9\x52\xf8\x10\xed\x2e\x97\x67\x6a\x9b\xb5\x73\x0d\xf9\x53\x58\xd9\x74\x95\xff\x5a\x8e\xf9\xb0\xa6\xe7\x2c\x2a\x9d\xaa\x90\x29\x18\xcd\xca\x3d\x87\xdb\xcc\x1d\x1b\xe7\x1d\xd6\x33\x43\xab\xee\xd2\xab\xc3\xe4\xbf\x46\x3e\x4d\xfc\x24\xc7\xeb\x12\x13\x57\xf9\xab\x55\xd7\xa7\x58\x75\x8b\x3e\x0e\x7c\x68\x6c\x79\x57\x30\x8c\xe4\x84\x5f\xa1\xf8\x01\x1f\x15\xd8\x60\xf5\x9f\x7f\x1f\xec\xc7\xbc\x0e\x56\xfe\x6d\xb0\x1f\xf7\x32\x58\xe1\xbb\x9c\x5b\x91\x5c\xbd\xe8\xff\x9d\x2f\x1a\x4b\x26\x7f\xc8\x4b\x5a\x59\x3e\xcf\xee\xbd\x8f\x7b\xb3\x28\x06\x52\x21\x09\xe4\x0f\x18\x73\x1b\x9c\xd2\xef\x3c\x94\x81\xf6\x05\xdc\x0b\xdc\xcc\xa7\x97\xfe\x31\xca\x4b\xd1\x65\x71\x2e\x10\x7e\x27\xe0\xb9\xb8\x45\xa1\xdf\xe5\x60\x7c\xba\x1a\x06\x13\x2e\xd4\xe5\x3f\xfd\x74\x45\x01\xe3\x87\xf9\xd6\xc2\xcf\x3f\x36\x32\x9f\xc1\x2a\xf3\x26\x75\x39\xf0\x1d\x9f\x4b\xbc\x64\x5c\x25\x1f\xe4\x58\x4a\x2e\x53\x3b\x34\xa1\x9c\xfb\x73\x2a\x04\xf2\x3b\xbe\xd8\x91\xfd\x98\x5e\xf1\x37\xaa\xd2\xaf\x02\x14\x7f\x12\xe8\xff\x07\x00\x3d\x4a\x67\xc9\x45\x51\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
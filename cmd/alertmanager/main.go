@@ -43,28 +43,44 @@ import (
 	"github.com/prometheus/exporter-toolkit/web"
 	webflag "github.com/prometheus/exporter-toolkit/web/kingpinflag"
 
+	"github.com/prometheus/alertmanager/ack"
 	"github.com/prometheus/alertmanager/api"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/digest"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/enrich"
+	"github.com/prometheus/alertmanager/escalation"
+	"github.com/prometheus/alertmanager/events"
+	"github.com/prometheus/alertmanager/groupstate"
+	"github.com/prometheus/alertmanager/history"
 	"github.com/prometheus/alertmanager/inhibit"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/notify/email"
+	"github.com/prometheus/alertmanager/notify/exec"
+	"github.com/prometheus/alertmanager/notify/kafka"
+	"github.com/prometheus/alertmanager/notify/mqtt"
 	"github.com/prometheus/alertmanager/notify/msteams"
+	"github.com/prometheus/alertmanager/notify/nats"
 	"github.com/prometheus/alertmanager/notify/opsgenie"
 	"github.com/prometheus/alertmanager/notify/pagerduty"
+	"github.com/prometheus/alertmanager/notify/plugin"
 	"github.com/prometheus/alertmanager/notify/pushover"
+	"github.com/prometheus/alertmanager/notify/servicenow"
 	"github.com/prometheus/alertmanager/notify/slack"
+	"github.com/prometheus/alertmanager/notify/sms"
 	"github.com/prometheus/alertmanager/notify/sns"
 	"github.com/prometheus/alertmanager/notify/telegram"
 	"github.com/prometheus/alertmanager/notify/victorops"
 	"github.com/prometheus/alertmanager/notify/webhook"
 	"github.com/prometheus/alertmanager/notify/wechat"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/provider/mem"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/tracing"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/alertmanager/ui"
 
@@ -134,7 +150,7 @@ const defaultClusterAddr = "0.0.0.0:9094"
 
 // buildReceiverIntegrations builds a list of integration notifiers off of a
 // receiver config.
-func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, logger log.Logger) ([]notify.Integration, error) {
+func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, plugins *plugin.Set, logger log.Logger) ([]notify.Integration, error) {
 
 	var (
 		errs         types.MultiError
@@ -182,6 +198,27 @@ func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, log
 	for i, c := range nc.MSTeamsConfigs {
 		add("msteams", i, c, func(l log.Logger) (notify.Notifier, error) { return msteams.New(c, tmpl, l) })
 	}
+	for i, c := range nc.ServiceNowConfigs {
+		add("servicenow", i, c, func(l log.Logger) (notify.Notifier, error) { return servicenow.New(c, tmpl, l) })
+	}
+	for i, c := range nc.SMSConfigs {
+		add("sms", i, c, func(l log.Logger) (notify.Notifier, error) { return sms.New(c, tmpl, l) })
+	}
+	for i, c := range nc.KafkaConfigs {
+		add("kafka", i, c, func(l log.Logger) (notify.Notifier, error) { return kafka.New(c, tmpl, l) })
+	}
+	for i, c := range nc.NATSConfigs {
+		add("nats", i, c, func(l log.Logger) (notify.Notifier, error) { return nats.New(c, tmpl, l) })
+	}
+	for i, c := range nc.MQTTConfigs {
+		add("mqtt", i, c, func(l log.Logger) (notify.Notifier, error) { return mqtt.New(c, tmpl, l) })
+	}
+	for i, c := range nc.ExecConfigs {
+		add("exec", i, c, func(l log.Logger) (notify.Notifier, error) { return exec.New(c, tmpl, l) })
+	}
+	for i, c := range nc.PluginConfigs {
+		add("plugin", i, c, func(l log.Logger) (notify.Notifier, error) { return plugin.New(c, plugins, tmpl, l) })
+	}
 	if errs.Len() > 0 {
 		return nil, &errs
 	}
@@ -212,6 +249,14 @@ func run() int {
 		retention       = kingpin.Flag("data.retention", "How long to keep data for.").Default("120h").Duration()
 		alertGCInterval = kingpin.Flag("alerts.gc-interval", "Interval between alert GC.").Default("30m").Duration()
 
+		outboxEnabled     = kingpin.Flag("outbox.enabled", "Record the notifications the notification pipeline gave up on in an outbox and retry them in the background.").Bool()
+		outboxMaxAttempts = kingpin.Flag("outbox.max-attempts", "Number of background attempts to deliver a failed notification before it becomes a dead letter.").Default("5").Int()
+		outboxBackoff     = kingpin.Flag("outbox.backoff", "Delay before the first background attempt to deliver a failed notification. It doubles after each attempt.").Default("1m").Duration()
+		outboxMaxBackoff  = kingpin.Flag("outbox.max-backoff", "Maximum delay between background attempts to deliver a failed notification.").Default("1h").Duration()
+
+		historyRetention  = kingpin.Flag("history.retention", "How long to keep the notification delivery history for.").Default("24h").Duration()
+		historyMaxEntries = kingpin.Flag("history.max-entries", "Maximum number of notifications kept in the delivery history. 0 means no limit besides the retention.").Default("10000").Int()
+
 		webConfig      = webflag.AddFlags(kingpin.CommandLine, ":9093")
 		externalURL    = kingpin.Flag("web.external-url", "The URL under which Alertmanager is externally reachable (for example, if Alertmanager is served via a reverse proxy). Used for generating relative and absolute links back to Alertmanager itself. If the URL has a path portion, it will be used to prefix all HTTP endpoints served by Alertmanager. If omitted, relevant URL components will be derived automatically.").String()
 		routePrefix    = kingpin.Flag("web.route-prefix", "Prefix for the internal routes of web endpoints. Defaults to path of --web.external-url.").String()
@@ -302,7 +347,14 @@ func run() int {
 		notificationLog.SetBroadcast(c.Broadcast)
 	}
 
-	marker := types.NewMarker(prometheus.DefaultRegisterer)
+	eventBus := events.New(events.Options{
+		Logger:  log.With(logger, "component", "events"),
+		Metrics: prometheus.DefaultRegisterer,
+	})
+	defer eventBus.Stop()
+
+	// The silenced and inhibited events are published by the marker.
+	marker := eventBus.Marker(types.NewMarker(prometheus.DefaultRegisterer))
 
 	silenceOpts := silence.Options{
 		SnapshotFile: filepath.Join(*dataDir, "silences"),
@@ -321,6 +373,88 @@ func run() int {
 		silences.SetBroadcast(c.Broadcast)
 	}
 
+	escalationLog, err := escalation.New(escalation.Options{
+		SnapshotFile: filepath.Join(*dataDir, "escalation"),
+		Retention:    *retention,
+		Logger:       log.With(logger, "component", "escalation"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+	if peer != nil {
+		c := peer.AddState("esc", escalationLog, prometheus.DefaultRegisterer)
+		escalationLog.SetBroadcast(c.Broadcast)
+	}
+
+	groupState, err := groupstate.New(groupstate.Options{
+		SnapshotFile: filepath.Join(*dataDir, "aggregation_groups"),
+		Retention:    *retention,
+		Logger:       log.With(logger, "component", "groupstate"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+
+	acks, err := ack.New(ack.Options{
+		SnapshotFile: filepath.Join(*dataDir, "acks"),
+		Retention:    *retention,
+		Marker:       marker,
+		Logger:       log.With(logger, "component", "acks"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+	if peer != nil {
+		c := peer.AddState("ack", acks, prometheus.DefaultRegisterer)
+		acks.SetBroadcast(c.Broadcast)
+	}
+
+	var ob *outbox.Outbox
+	if *outboxEnabled {
+		ob, err = outbox.New(outbox.Options{
+			SnapshotFile:   filepath.Join(*dataDir, "outbox"),
+			MaxAttempts:    *outboxMaxAttempts,
+			InitialBackoff: *outboxBackoff,
+			MaxBackoff:     *outboxMaxBackoff,
+			Timeout:        notify.MinTimeout,
+			Retention:      *retention,
+			Logger:         log.With(logger, "component", "outbox"),
+			Metrics:        prometheus.DefaultRegisterer,
+		})
+		if err != nil {
+			level.Error(logger).Log("err", err)
+			return 1
+		}
+	}
+
+	hist, err := history.New(history.Options{
+		SnapshotFile: filepath.Join(*dataDir, "history"),
+		Retention:    *historyRetention,
+		MaxEntries:   *historyMaxEntries,
+		Logger:       log.With(logger, "component", "history"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+
+	enricher, err := enrich.New(enrich.Options{
+		SnapshotFile: filepath.Join(*dataDir, "lookup_tables"),
+		Logger:       log.With(logger, "component", "enrich"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+
 	// Start providers before router potentially sends updates.
 	wg.Add(1)
 	go func() {
@@ -328,6 +462,32 @@ func run() int {
 		wg.Done()
 	}()
 
+	wg.Add(1)
+	go func() {
+		hist.Maintenance(15*time.Minute, stopc)
+		wg.Done()
+	}()
+
+	wg.Add(1)
+	go func() {
+		escalationLog.Maintenance(15*time.Minute, stopc)
+		wg.Done()
+	}()
+
+	wg.Add(1)
+	go func() {
+		acks.Maintenance(15*time.Minute, stopc)
+		wg.Done()
+	}()
+
+	// The group state is persisted more often than the other states as it
+	// is outdated after a group interval.
+	wg.Add(1)
+	go func() {
+		groupState.Maintenance(time.Minute, stopc)
+		wg.Done()
+	}()
+
 	defer func() {
 		close(stopc)
 		wg.Wait()
@@ -352,14 +512,27 @@ func run() int {
 		go peer.Settle(ctx, *gossipInterval*10)
 	}
 
-	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, nil, logger)
+	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, eventBus, logger)
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return 1
 	}
 	defer alerts.Close()
 
-	var disp *dispatch.Dispatcher
+	plugins, err := plugin.NewManager(log.With(logger, "component", "plugins"))
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+	defer plugins.Close()
+
+	tracingManager := tracing.NewManager(log.With(logger, "component", "tracing"))
+	defer tracingManager.Stop()
+
+	var (
+		disp      *dispatch.Dispatcher
+		inhibitor *inhibit.Inhibitor
+	)
 	defer disp.Stop()
 
 	groupFn := func(routeFilter func(*dispatch.Route) bool, alertFilter func(*types.Alert, time.Time) bool) (dispatch.AlertGroups, map[model.Fingerprint][]string) {
@@ -384,6 +557,27 @@ func run() int {
 		Logger:      log.With(logger, "component", "api"),
 		Registry:    prometheus.DefaultRegisterer,
 		GroupFunc:   groupFn,
+		Plugins:     plugins,
+		Outbox:      ob,
+		History:     hist,
+		Acks:        acks,
+		Enricher:    enricher,
+		LimitsFunc: func() *dispatch.LimitStatus {
+			return disp.LimitStatus()
+		},
+		ExplainFunc: func(alert *types.Alert) *dispatch.Explanation {
+			return disp.Explain(alert)
+		},
+		InhibitFunc: func(lset model.LabelSet) []model.Fingerprint {
+			return inhibitor.InhibitingAlerts(lset)
+		},
+		NotificationLog: notificationLog,
+		GroupStatusFunc: func() []*dispatch.GroupStatus {
+			return disp.GroupStatuses()
+		},
+		FlushGroupFunc: func(groupKey string, renotify bool) error {
+			return disp.FlushGroup(groupKey, renotify)
+		},
 	})
 
 	if err != nil {
@@ -410,11 +604,52 @@ func run() int {
 	}
 
 	var (
-		inhibitor *inhibit.Inhibitor
-		tmpl      *template.Template
+		tmpl *template.Template
+
+		// The integrations and the pipeline of the current configuration.
+		// The pipeline delivers the notifications of the outbox and the
+		// digests.
+		integrationsMtx sync.RWMutex
+		integrations    map[string][]notify.Integration
+		pipeline        notify.RoutingStage
 	)
 
-	dispMetrics := dispatch.NewDispatcherMetrics(false, prometheus.DefaultRegisterer)
+	if ob != nil {
+		wg.Add(1)
+		go func() {
+			ob.Run(10*time.Second, stopc, notify.OutboxNotifyFunc(func() notify.Stage {
+				integrationsMtx.RLock()
+				defer integrationsMtx.RUnlock()
+				return pipeline
+			}, alerts.Get, log.With(logger, "component", "outbox")))
+			wg.Done()
+		}()
+	}
+
+	digests, err := digest.New(digest.Options{
+		SnapshotFile: filepath.Join(*dataDir, "digests"),
+		Timeout:      timeoutFunc(notify.MinTimeout),
+		Logger:       log.With(logger, "component", "digest"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 1
+	}
+
+	wg.Add(1)
+	go func() {
+		digests.Run(10*time.Second, stopc, notify.DigestNotifyFunc(func() notify.Stage {
+			integrationsMtx.RLock()
+			defer integrationsMtx.RUnlock()
+			return pipeline
+		}, log.With(logger, "component", "digest")))
+		wg.Done()
+	}()
+
+	flapDetector := notify.NewFlapDetector(marker, prometheus.DefaultRegisterer)
+
+	dispMetrics := dispatch.NewDispatcherMetrics(true, prometheus.DefaultRegisterer)
 	pipelineBuilder := notify.NewPipelineBuilder(prometheus.DefaultRegisterer)
 	configLogger := log.With(logger, "component", "configuration")
 
@@ -455,6 +690,40 @@ func run() int {
 		}
 		tmpl.ExternalURL = amURL
 
+		// The plugins, lookup tables, tracer provider and log events exporter
+		// of the configuration are prepared first and only replace the
+		// current ones once the whole configuration is loaded.
+		var discards []func()
+		discard := func(err error) error {
+			for _, d := range discards {
+				d()
+			}
+			return err
+		}
+
+		pluginSet, err := plugins.Prepare(conf.Plugins)
+		if err != nil {
+			return err
+		}
+		discards = append(discards, pluginSet.Discard)
+
+		lookupTables, err := enricher.Prepare(conf.LookupTables)
+		if err != nil {
+			return discard(err)
+		}
+
+		tracingProvider, err := tracingManager.Prepare(conf)
+		if err != nil {
+			return discard(errors.Wrap(err, "failed to apply tracing config"))
+		}
+		discards = append(discards, tracingProvider.Discard)
+
+		eventsExporter, err := eventBus.Prepare(conf.LogEvents)
+		if err != nil {
+			return discard(errors.Wrap(err, "failed to apply log events config"))
+		}
+		discards = append(discards, eventsExporter.Discard)
+
 		// Build the routing tree and record which receivers are used.
 		routes := dispatch.NewRoute(conf.Route, nil)
 		activeReceivers := make(map[string]struct{})
@@ -462,6 +731,29 @@ func run() int {
 			activeReceivers[r.RouteOpts.Receiver] = struct{}{}
 		})
 
+		// The receivers of the escalation policies are used too.
+		escalationPolicies := make(map[string]*config.EscalationPolicy, len(conf.EscalationPolicies))
+		for _, ep := range conf.EscalationPolicies {
+			escalationPolicies[ep.Name] = ep
+			for _, s := range ep.Steps {
+				activeReceivers[s.Receiver] = struct{}{}
+			}
+		}
+
+		// The fallback receivers of the used receivers are used too.
+		fallbacks := make(map[string]string)
+		for _, rcv := range conf.Receivers {
+			if rcv.FallbackReceiver != "" {
+				fallbacks[rcv.Name] = rcv.FallbackReceiver
+			}
+		}
+		for name := range activeReceivers {
+			// The configuration guarantees that the fallbacks have no cycle.
+			for fb, ok := fallbacks[name]; ok; fb, ok = fallbacks[fb] {
+				activeReceivers[fb] = struct{}{}
+			}
+		}
+
 		// Build the map of receiver to integrations.
 		receivers := make(map[string][]notify.Integration, len(activeReceivers))
 		var integrationsNum int
@@ -471,15 +763,20 @@ func run() int {
 				level.Info(configLogger).Log("msg", "skipping creation of receiver not referenced by any route", "receiver", rcv.Name)
 				continue
 			}
-			integrations, err := buildReceiverIntegrations(rcv, tmpl, logger)
+			integrations, err := buildReceiverIntegrations(rcv, tmpl, pluginSet, logger)
 			if err != nil {
-				return err
+				return discard(err)
 			}
 			// rcv.Name is guaranteed to be unique across all receivers.
 			receivers[rcv.Name] = integrations
 			integrationsNum += len(integrations)
 		}
 
+		pluginSet.Commit()
+		lookupTables.Commit()
+		tracingProvider.Commit()
+		eventsExporter.Commit()
+
 		// Build the map of time interval names to mute time definitions.
 		muteTimes := make(map[string][]timeinterval.TimeInterval, len(conf.MuteTimeIntervals))
 		for _, ti := range conf.MuteTimeIntervals {
@@ -491,6 +788,7 @@ func run() int {
 
 		inhibitor = inhibit.NewInhibitor(alerts, conf.InhibitRules, marker, logger)
 		silencer := silence.NewSilencer(silences, marker, logger)
+		acker := ack.NewAcker(acks, marker)
 
 		// An interface value that holds a nil concrete value is non-nil.
 		// Therefore we explicly pass an empty interface, to detect if the
@@ -500,24 +798,68 @@ func run() int {
 			pipelinePeer = peer
 		}
 
-		pipeline := pipelineBuilder.New(
+		// The receivers with a digest schedule are notified of digests.
+		digestSchedules := make(map[string]*config.DigestConfig)
+		for _, rcv := range conf.Receivers {
+			if _, found := activeReceivers[rcv.Name]; found && rcv.Digest != nil {
+				digestSchedules[rcv.Name] = rcv.Digest
+			}
+		}
+		digests.SetSchedules(digestSchedules)
+		flapDetector.Configure(conf.FlapDetection)
+
+		newPipeline := pipelineBuilder.New(
 			receivers,
+			fallbacks,
 			waitFunc,
 			inhibitor,
 			silencer,
 			muteTimes,
 			notificationLog,
+			ob,
+			hist,
+			acker,
+			flapDetector,
+			digests,
+			eventBus,
 			pipelinePeer,
 		)
 		configuredReceivers.Set(float64(len(activeReceivers)))
 		configuredIntegrations.Set(float64(integrationsNum))
 
-		api.Update(conf, func(labels model.LabelSet) {
+		integrationsMtx.Lock()
+		oldIntegrations := integrations
+		integrations = receivers
+		pipeline = newPipeline
+		integrationsMtx.Unlock()
+
+		// Release the connections held by the replaced integrations. The
+		// connections are closed once their notifications in flight are done.
+		for _, ints := range oldIntegrations {
+			for _, i := range ints {
+				if err := i.Close(); err != nil {
+					level.Warn(configLogger).Log("msg", "Failed to close integration", "integration", i.String(), "err", err)
+				}
+			}
+		}
+
+		api.Update(conf, receivers, func(labels model.LabelSet) {
 			inhibitor.Mutes(labels)
 			silencer.Mutes(labels)
 		})
 
-		disp = dispatch.NewDispatcher(alerts, routes, pipeline, marker, timeoutFunc, nil, logger, dispMetrics)
+		disp = dispatch.NewDispatcher(
+			alerts,
+			routes,
+			newPipeline,
+			marker,
+			timeoutFunc,
+			dispatch.NewLimits(conf.Limits),
+			&dispatch.Escalation{Policies: escalationPolicies, Log: escalationLog, Acker: acker},
+			groupState,
+			logger,
+			dispMetrics,
+		)
 		routes.Walk(func(r *dispatch.Route) {
 			if r.RouteOpts.RepeatInterval > *retention {
 				level.Warn(configLogger).Log(
@@ -32,6 +32,7 @@ import (
 
 	"github.com/prometheus/alertmanager/constants"
 	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/pkg/relabel"
 	"github.com/prometheus/alertmanager/timeinterval"
 )
 
@@ -224,6 +225,21 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 	}
 
 	cfg.Global.HTTPConfig.SetDirectory(baseDir)
+	for _, p := range cfg.Plugins {
+		if strings.ContainsRune(p.Command, filepath.Separator) {
+			p.Command = join(p.Command)
+		}
+		p.Socket = join(p.Socket)
+	}
+	for _, lt := range cfg.LookupTables {
+		lt.File = join(lt.File)
+	}
+	if cfg.Tracing != nil && cfg.Tracing.TLSConfig != nil {
+		cfg.Tracing.TLSConfig.SetDirectory(baseDir)
+	}
+	if cfg.LogEvents != nil && cfg.LogEvents.TLSConfig != nil {
+		cfg.LogEvents.TLSConfig.SetDirectory(baseDir)
+	}
 	for _, receiver := range cfg.Receivers {
 		for _, cfg := range receiver.OpsGenieConfigs {
 			cfg.HTTPConfig.SetDirectory(baseDir)
@@ -255,6 +271,35 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 		for _, cfg := range receiver.MSTeamsConfigs {
 			cfg.HTTPConfig.SetDirectory(baseDir)
 		}
+		for _, cfg := range receiver.ServiceNowConfigs {
+			cfg.HTTPConfig.SetDirectory(baseDir)
+		}
+		for _, cfg := range receiver.SMSConfigs {
+			cfg.HTTPConfig.SetDirectory(baseDir)
+		}
+		for _, cfg := range receiver.KafkaConfigs {
+			if cfg.TLSConfig != nil {
+				cfg.TLSConfig.SetDirectory(baseDir)
+			}
+		}
+		for _, cfg := range receiver.NATSConfigs {
+			cfg.CredentialsFile = join(cfg.CredentialsFile)
+			if cfg.TLSConfig != nil {
+				cfg.TLSConfig.SetDirectory(baseDir)
+			}
+		}
+		for _, cfg := range receiver.MQTTConfigs {
+			if cfg.TLSConfig != nil {
+				cfg.TLSConfig.SetDirectory(baseDir)
+			}
+		}
+		for _, cfg := range receiver.ExecConfigs {
+			// Bare command names are looked up in PATH.
+			if strings.ContainsRune(cfg.Command, filepath.Separator) {
+				cfg.Command = join(cfg.Command)
+			}
+			cfg.WorkingDir = join(cfg.WorkingDir)
+		}
 	}
 }
 
@@ -276,6 +321,396 @@ func (mt *MuteTimeInterval) UnmarshalYAML(unmarshal func(interface{}) error) err
 	return nil
 }
 
+// Plugin declares an out-of-process notifier plugin. Receivers use it through
+// plugin_configs.
+type Plugin struct {
+	Name string `yaml:"name" json:"name"`
+	// Command is the plugin executable started and supervised by
+	// Alertmanager. The path of the Unix socket the plugin has to serve on is
+	// passed in the ALERTMANAGER_PLUGIN_SOCKET environment variable.
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	// Socket is the Unix socket of a plugin managed outside of Alertmanager.
+	Socket string `yaml:"socket,omitempty" json:"socket,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Plugin.
+func (p *Plugin) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Plugin
+	if err := unmarshal((*plain)(p)); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		return fmt.Errorf("missing name in plugin")
+	}
+	if (p.Command == "") == (p.Socket == "") {
+		return fmt.Errorf("exactly one of command & socket must be configured for plugin %q", p.Name)
+	}
+	return nil
+}
+
+// EscalationPolicy is a named sequence of receivers notified one after the
+// other while the alerts of an aggregation group keep firing.
+type EscalationPolicy struct {
+	Name  string           `yaml:"name" json:"name"`
+	Steps []EscalationStep `yaml:"steps" json:"steps"`
+}
+
+// EscalationStep notifies a receiver once the alerts of an aggregation group
+// have been firing for the delay.
+type EscalationStep struct {
+	Receiver string         `yaml:"receiver" json:"receiver"`
+	Delay    model.Duration `yaml:"delay,omitempty" json:"delay,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for EscalationPolicy.
+func (ep *EscalationPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain EscalationPolicy
+	if err := unmarshal((*plain)(ep)); err != nil {
+		return err
+	}
+	return ep.Validate()
+}
+
+// Validate checks the escalation policy.
+func (ep *EscalationPolicy) Validate() error {
+	if ep.Name == "" {
+		return fmt.Errorf("missing name in escalation policy")
+	}
+	if len(ep.Steps) == 0 {
+		return fmt.Errorf("missing steps in escalation policy %q", ep.Name)
+	}
+	for i, s := range ep.Steps {
+		if s.Receiver == "" {
+			return fmt.Errorf("missing receiver in step %d of escalation policy %q", i, ep.Name)
+		}
+		if i > 0 && s.Delay < ep.Steps[i-1].Delay {
+			return fmt.Errorf("steps of escalation policy %q must be sorted by delay", ep.Name)
+		}
+	}
+	return nil
+}
+
+// LookupTable enriches the alerts at ingestion with the row of a table
+// selected by the value of one of their labels. The table is read from a CSV
+// or YAML file, or managed through the API if no file is set.
+type LookupTable struct {
+	Name string `yaml:"name" json:"name"`
+	// File is a CSV file, with a header row and a column named after the key
+	// label, or a YAML file mapping the keys to their row.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+	// KeyLabel is the label of the alerts whose value is looked up.
+	KeyLabel string `yaml:"key_label" json:"key_label"`
+	// Labels and Annotations are the columns of the row added to the
+	// alerts as labels and annotations.
+	Labels      []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations []string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// Validate checks the lookup table.
+func (lt *LookupTable) Validate() error {
+	if lt.Name == "" {
+		return fmt.Errorf("missing name in lookup table")
+	}
+	if !model.LabelName(lt.KeyLabel).IsValid() {
+		return fmt.Errorf("invalid key_label %q in lookup table %q", lt.KeyLabel, lt.Name)
+	}
+	if len(lt.Labels) == 0 && len(lt.Annotations) == 0 {
+		return fmt.Errorf("at least one of labels & annotations must be configured for lookup table %q", lt.Name)
+	}
+	for _, l := range lt.Labels {
+		if !model.LabelName(l).IsValid() {
+			return fmt.Errorf("invalid label %q in lookup table %q", l, lt.Name)
+		}
+	}
+	for _, a := range lt.Annotations {
+		if !model.LabelName(a).IsValid() {
+			return fmt.Errorf("invalid annotation %q in lookup table %q", a, lt.Name)
+		}
+	}
+	if lt.File != "" {
+		switch filepath.Ext(lt.File) {
+		case ".csv", ".yaml", ".yml":
+		default:
+			return fmt.Errorf("unsupported file %q in lookup table %q, expected .csv, .yaml or .yml", lt.File, lt.Name)
+		}
+	}
+	return nil
+}
+
+// OTLPClientType is the protocol of an OTLP exporter.
+type OTLPClientType string
+
+// The supported protocols of the OTLP exporters.
+const (
+	OTLPClientGRPC OTLPClientType = "grpc"
+	OTLPClientHTTP OTLPClientType = "http"
+)
+
+// DefaultTracingConfig defines default values for tracing.
+var DefaultTracingConfig = TracingConfig{
+	ClientType:       OTLPClientGRPC,
+	SamplingFraction: 1,
+	Timeout:          model.Duration(10 * time.Second),
+}
+
+// TracingConfig configures the export of the traces of Alertmanager to an
+// OTLP endpoint.
+type TracingConfig struct {
+	ClientType OTLPClientType `yaml:"client_type,omitempty" json:"client_type,omitempty"`
+	// Endpoint is the host:port of the OTLP receiver.
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+	// SamplingFraction is the fraction of the traces started by Alertmanager
+	// which are sampled. The traces started by clients keep their decision.
+	SamplingFraction float64              `yaml:"sampling_fraction" json:"sampling_fraction"`
+	Insecure         bool                 `yaml:"insecure,omitempty" json:"insecure,omitempty"`
+	Headers          map[string]string    `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Compression      string               `yaml:"compression,omitempty" json:"compression,omitempty"`
+	Timeout          model.Duration       `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	TLSConfig        *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+}
+
+// Validate checks the tracing configuration.
+func (c *TracingConfig) Validate() error {
+	if c.Endpoint == "" {
+		return fmt.Errorf("missing endpoint in tracing config")
+	}
+	if strings.Contains(c.Endpoint, "://") {
+		return fmt.Errorf("endpoint %q in tracing config must be host:port, without a scheme", c.Endpoint)
+	}
+	switch c.ClientType {
+	case OTLPClientGRPC, OTLPClientHTTP:
+	default:
+		return fmt.Errorf("unknown client_type %q in tracing config, expected grpc or http", c.ClientType)
+	}
+	if c.SamplingFraction < 0 || c.SamplingFraction > 1 {
+		return fmt.Errorf("sampling_fraction must be between 0 and 1 in tracing config")
+	}
+	if c.Compression != "" && c.Compression != "gzip" {
+		return fmt.Errorf("unsupported compression %q in tracing config, expected gzip", c.Compression)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in tracing config")
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *TracingConfig) UnmarshalJSON(data []byte) error {
+	type plain TracingConfig
+	sp := (plain)(DefaultTracingConfig)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (TracingConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TracingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultTracingConfig
+	type plain TracingConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// DefaultLogEventsConfig defines default values for the log events.
+var DefaultLogEventsConfig = LogEventsConfig{
+	ClientType:    OTLPClientGRPC,
+	Timeout:       model.Duration(10 * time.Second),
+	BatchSize:     512,
+	BufferSize:    4096,
+	FlushInterval: model.Duration(5 * time.Second),
+}
+
+// LogEventsConfig configures the export of the state changes of the alerts
+// as log records to an OTLP endpoint.
+type LogEventsConfig struct {
+	ClientType OTLPClientType `yaml:"client_type,omitempty" json:"client_type,omitempty"`
+	// Endpoint is the host:port of the OTLP receiver.
+	Endpoint    string               `yaml:"endpoint" json:"endpoint"`
+	Insecure    bool                 `yaml:"insecure,omitempty" json:"insecure,omitempty"`
+	Headers     map[string]string    `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Compression string               `yaml:"compression,omitempty" json:"compression,omitempty"`
+	Timeout     model.Duration       `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	TLSConfig   *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	// BatchSize is the maximum number of log records of an export.
+	BatchSize int `yaml:"batch_size,omitempty" json:"batch_size,omitempty"`
+	// BufferSize is the maximum number of log records waiting to be
+	// exported. The log records are dropped once it is full.
+	BufferSize int `yaml:"buffer_size,omitempty" json:"buffer_size,omitempty"`
+	// FlushInterval is the maximum time a log record waits to be exported.
+	FlushInterval model.Duration `yaml:"flush_interval,omitempty" json:"flush_interval,omitempty"`
+}
+
+// Validate checks the log events configuration.
+func (c *LogEventsConfig) Validate() error {
+	if c.Endpoint == "" {
+		return fmt.Errorf("missing endpoint in log events config")
+	}
+	if strings.Contains(c.Endpoint, "://") {
+		return fmt.Errorf("endpoint %q in log events config must be host:port, without a scheme", c.Endpoint)
+	}
+	switch c.ClientType {
+	case OTLPClientGRPC, OTLPClientHTTP:
+	default:
+		return fmt.Errorf("unknown client_type %q in log events config, expected grpc or http", c.ClientType)
+	}
+	if c.Compression != "" && c.Compression != "gzip" {
+		return fmt.Errorf("unsupported compression %q in log events config, expected gzip", c.Compression)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in log events config")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive in log events config")
+	}
+	if c.BufferSize < c.BatchSize {
+		return fmt.Errorf("buffer_size must be at least batch_size in log events config")
+	}
+	if c.FlushInterval <= 0 {
+		return fmt.Errorf("flush_interval must be positive in log events config")
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *LogEventsConfig) UnmarshalJSON(data []byte) error {
+	type plain LogEventsConfig
+	sp := (plain)(DefaultLogEventsConfig)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (LogEventsConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *LogEventsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultLogEventsConfig
+	type plain LogEventsConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// OverflowPolicy is what happens to the alerts exceeding the aggregation
+// group limits.
+type OverflowPolicy string
+
+// The supported overflow policies.
+const (
+	// OverflowDrop drops the alerts.
+	OverflowDrop OverflowPolicy = "drop"
+	// OverflowGroup inserts the alerts into the overflow group of their
+	// route, which notifies the receiver of the route about them.
+	OverflowGroup OverflowPolicy = "overflow_group"
+)
+
+// DefaultLimitsConfig defines default values for the limits.
+var DefaultLimitsConfig = LimitsConfig{
+	Overflow: OverflowDrop,
+}
+
+// LimitsConfig configures the limits of the aggregation groups. A zero limit
+// means unlimited.
+type LimitsConfig struct {
+	// MaxAggregationGroups is the maximum number of aggregation groups of
+	// all the routes.
+	MaxAggregationGroups int `yaml:"max_aggregation_groups,omitempty" json:"max_aggregation_groups,omitempty"`
+	// MaxAlertsPerGroup is the maximum number of alerts of an aggregation
+	// group.
+	MaxAlertsPerGroup int            `yaml:"max_alerts_per_group,omitempty" json:"max_alerts_per_group,omitempty"`
+	Overflow          OverflowPolicy `yaml:"overflow,omitempty" json:"overflow,omitempty"`
+}
+
+// Validate checks the limits configuration.
+func (c *LimitsConfig) Validate() error {
+	if c.MaxAggregationGroups < 0 {
+		return fmt.Errorf("max_aggregation_groups cannot be negative in limits config")
+	}
+	if c.MaxAlertsPerGroup < 0 {
+		return fmt.Errorf("max_alerts_per_group cannot be negative in limits config")
+	}
+	switch c.Overflow {
+	case OverflowDrop, OverflowGroup:
+	default:
+		return fmt.Errorf("unknown overflow policy %q in limits config, expected drop or overflow_group", c.Overflow)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *LimitsConfig) UnmarshalJSON(data []byte) error {
+	type plain LimitsConfig
+	sp := (plain)(DefaultLimitsConfig)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (LimitsConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *LimitsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultLimitsConfig
+	type plain LimitsConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// DefaultFlapDetectionConfig defines default values for flap detection.
+var DefaultFlapDetectionConfig = FlapDetectionConfig{
+	Window:    model.Duration(time.Hour),
+	Threshold: 5,
+}
+
+// FlapDetectionConfig configures the detection of flapping alerts. An alert
+// is flapping while it changed state, from firing to resolved or back, at
+// least Threshold times within the last Window.
+type FlapDetectionConfig struct {
+	Window    model.Duration `yaml:"window,omitempty" json:"window,omitempty"`
+	Threshold int            `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// Validate checks the flap detection configuration.
+func (c *FlapDetectionConfig) Validate() error {
+	if c.Window <= 0 {
+		return fmt.Errorf("window must be positive in flap detection config")
+	}
+	if c.Threshold < 2 {
+		return fmt.Errorf("threshold must be at least 2 in flap detection config")
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *FlapDetectionConfig) UnmarshalJSON(data []byte) error {
+	type plain FlapDetectionConfig
+	sp := (plain)(DefaultFlapDetectionConfig)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (FlapDetectionConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *FlapDetectionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultFlapDetectionConfig
+	type plain FlapDetectionConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
 // Config is the top-level configuration for Alertmanager's config files.
 type Config struct {
 	Global       *GlobalConfig  `yaml:"global,omitempty" json:"global,omitempty"`
@@ -287,6 +722,26 @@ type Config struct {
 	// base dir but we no longer use yaml file
 	Templates         []string           `yaml:"templates" json:"templates"`
 	MuteTimeIntervals []MuteTimeInterval `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
+	Plugins           []*Plugin          `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+	// EscalationPolicies can be referenced by routes to notify further
+	// receivers while alerts keep firing.
+	EscalationPolicies []*EscalationPolicy `yaml:"escalation_policies,omitempty" json:"escalation_policies,omitempty"`
+	// FlapDetection enables the suppression of the notifications of alerts
+	// changing state too often.
+	FlapDetection *FlapDetectionConfig `yaml:"flap_detection,omitempty" json:"flap_detection,omitempty"`
+	// RelabelConfigs rewrite the labels of the alerts at ingestion, before
+	// their enrichment.
+	RelabelConfigs []*relabel.Config `yaml:"relabel_configs,omitempty" json:"relabel_configs,omitempty"`
+	// LookupTables enrich the alerts at ingestion, in order.
+	LookupTables []*LookupTable `yaml:"lookup_tables,omitempty" json:"lookup_tables,omitempty"`
+	// Tracing enables the export of traces. Disabled if unset.
+	Tracing *TracingConfig `yaml:"tracing,omitempty" json:"tracing,omitempty"`
+	// LogEvents enables the export of the state changes of the alerts as
+	// log records. Disabled if unset.
+	LogEvents *LogEventsConfig `yaml:"log_events,omitempty" json:"log_events,omitempty"`
+	// Limits bounds the number of aggregation groups and of their alerts.
+	// Unlimited if unset.
+	Limits *LimitsConfig `yaml:"limits,omitempty" json:"limits,omitempty"`
 
 	// original is the input from which the config was parsed.
 	original string
@@ -410,6 +865,14 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("at most one of opsgenie_api_key & opsgenie_api_key_file must be configured")
 	}
 
+	plugins := map[string]struct{}{}
+	for _, p := range c.Plugins {
+		if _, ok := plugins[p.Name]; ok {
+			return fmt.Errorf("plugin name %q is not unique", p.Name)
+		}
+		plugins[p.Name] = struct{}{}
+	}
+
 	names := map[string]struct{}{}
 
 	for _, rcv := range c.Receivers {
@@ -585,6 +1048,39 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("no msteams webhook URL provided")
 			}
 		}
+		for _, snc := range rcv.ServiceNowConfigs {
+			if snc.HTTPConfig == nil {
+				snc.HTTPConfig = c.Global.HTTPConfig
+			}
+		}
+		for _, sms := range rcv.SMSConfigs {
+			if sms.HTTPConfig == nil {
+				sms.HTTPConfig = c.Global.HTTPConfig
+			}
+			if sms.Provider != SMSProviderTwilio {
+				continue
+			}
+			if sms.APIURL == nil {
+				if c.Global.TwilioAPIURL == nil {
+					return fmt.Errorf("no global Twilio API URL set")
+				}
+				sms.APIURL = c.Global.TwilioAPIURL
+			}
+			if !strings.HasSuffix(sms.APIURL.Path, "/") {
+				sms.APIURL.Path += "/"
+			}
+		}
+		for _, pc := range rcv.PluginConfigs {
+			if _, ok := plugins[pc.Plugin]; !ok {
+				return fmt.Errorf("undefined plugin %q used in receiver %q", pc.Plugin, rcv.Name)
+			}
+		}
+
+		if rcv.Digest != nil {
+			if err := rcv.Digest.Validate(); err != nil {
+				return fmt.Errorf("invalid digest in receiver %q: %w", rcv.Name, err)
+			}
+		}
 
 		names[rcv.Name] = struct{}{}
 	}
@@ -613,6 +1109,67 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	epNames := map[string]struct{}{}
+	for _, ep := range c.EscalationPolicies {
+		if _, ok := epNames[ep.Name]; ok {
+			return fmt.Errorf("escalation policy %q is not unique", ep.Name)
+		}
+		if err := ep.Validate(); err != nil {
+			return err
+		}
+		for _, s := range ep.Steps {
+			if _, ok := names[s.Receiver]; !ok {
+				return fmt.Errorf("undefined receiver %q used in escalation policy %q", s.Receiver, ep.Name)
+			}
+		}
+		epNames[ep.Name] = struct{}{}
+	}
+	if err := checkEscalationPolicy(c.Route, epNames); err != nil {
+		return err
+	}
+
+	if err := checkFallbackReceivers(c.Receivers, names); err != nil {
+		return err
+	}
+
+	if c.FlapDetection != nil {
+		if err := c.FlapDetection.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Tracing != nil {
+		if err := c.Tracing.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.LogEvents != nil {
+		if err := c.LogEvents.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Limits != nil {
+		if err := c.Limits.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, rc := range c.RelabelConfigs {
+		if err := rc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	ltNames := map[string]struct{}{}
+	for _, lt := range c.LookupTables {
+		if _, ok := ltNames[lt.Name]; ok {
+			return fmt.Errorf("lookup table %q is not unique", lt.Name)
+		}
+		if err := lt.Validate(); err != nil {
+			return err
+		}
+		ltNames[lt.Name] = struct{}{}
+	}
+
 	tiNames := make(map[string]struct{})
 	for _, mt := range c.MuteTimeIntervals {
 		if _, ok := tiNames[mt.Name]; ok {
@@ -732,6 +1289,49 @@ func checkReceiver(r *Route, receivers map[string]struct{}) error {
 	return nil
 }
 
+// checkEscalationPolicy returns an error if a node in the routing tree
+// references an escalation policy not in the given map.
+func checkEscalationPolicy(r *Route, policies map[string]struct{}) error {
+	for _, sr := range r.Routes {
+		if err := checkEscalationPolicy(sr, policies); err != nil {
+			return err
+		}
+	}
+	if r.EscalationPolicy == "" {
+		return nil
+	}
+	if _, ok := policies[r.EscalationPolicy]; !ok {
+		return fmt.Errorf("undefined escalation policy %q used in route", r.EscalationPolicy)
+	}
+	return nil
+}
+
+// checkFallbackReceivers returns an error if a receiver falls back to a
+// receiver not in the given map, or if falling back would notify a receiver
+// again.
+func checkFallbackReceivers(rcvs []*Receiver, names map[string]struct{}) error {
+	fallbacks := make(map[string]string, len(rcvs))
+	for _, rcv := range rcvs {
+		if rcv.FallbackReceiver == "" {
+			continue
+		}
+		if _, ok := names[rcv.FallbackReceiver]; !ok {
+			return fmt.Errorf("undefined fallback receiver %q used in receiver %q", rcv.FallbackReceiver, rcv.Name)
+		}
+		fallbacks[rcv.Name] = rcv.FallbackReceiver
+	}
+	for name := range fallbacks {
+		seen := map[string]struct{}{name: {}}
+		for fb, ok := fallbacks[name]; ok; fb, ok = fallbacks[fb] {
+			if _, dup := seen[fb]; dup {
+				return fmt.Errorf("fallback receivers of receiver %q form a cycle", name)
+			}
+			seen[fb] = struct{}{}
+		}
+	}
+	return nil
+}
+
 func checkTimeInterval(r *Route, timeIntervals map[string]struct{}) error {
 	for _, sr := range r.Routes {
 		if err := checkTimeInterval(sr, timeIntervals); err != nil {
@@ -785,6 +1385,7 @@ func DefaultGlobalConfig() GlobalConfig {
 		TelegramAPIUrl:   mustParseURL("https://api.telegram.org"),
 		WeChatAPIURL:     mustParseURL("https://qyapi.weixin.qq.com/cgi-bin/"),
 		VictorOpsAPIURL:  mustParseURL("https://alert.victorops.com/integrations/generic/20131114/alert/"),
+		TwilioAPIURL:     mustParseURL("https://api.twilio.com/2010-04-01/"),
 	}
 }
 
@@ -908,6 +1509,7 @@ type GlobalConfig struct {
 	WeChatAPICorpID    string `yaml:"wechat_api_corp_id,omitempty" json:"wechat_api_corp_id,omitempty"`
 	VictorOpsAPIURL    *URL   `yaml:"victorops_api_url,omitempty" json:"victorops_api_url,omitempty"`
 	VictorOpsAPIKey    Secret `yaml:"victorops_api_key,omitempty" json:"victorops_api_key,omitempty"`
+	TwilioAPIURL       *URL   `yaml:"twilio_api_url,omitempty" json:"twilio_api_url,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface for GlobalConfig.
@@ -930,12 +1532,19 @@ type Route struct {
 	MatchRE           MatchRegexps `yaml:"match_re,omitempty" json:"match_re,omitempty"`
 	Matchers          Matchers     `yaml:"matchers,omitempty" json:"matchers,omitempty"`
 	MuteTimeIntervals []string     `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
+	EscalationPolicy  string       `yaml:"escalation_policy,omitempty" json:"escalation_policy,omitempty"`
 	Continue          bool         `yaml:"continue" json:"continue,omitempty"`
 	Routes            []*Route     `yaml:"routes,omitempty" json:"routes,omitempty"`
 
 	GroupWait      *model.Duration `yaml:"group_wait,omitempty" json:"group_wait,omitempty"`
 	GroupInterval  *model.Duration `yaml:"group_interval,omitempty" json:"group_interval,omitempty"`
 	RepeatInterval *model.Duration `yaml:"repeat_interval,omitempty" json:"repeat_interval,omitempty"`
+
+	// MaxAggregationGroups and MaxAlertsPerGroup limit the aggregation
+	// groups of the route, within the global limits. A zero limit means
+	// unlimited.
+	MaxAggregationGroups *int `yaml:"max_aggregation_groups,omitempty" json:"max_aggregation_groups,omitempty"`
+	MaxAlertsPerGroup    *int `yaml:"max_alerts_per_group,omitempty" json:"max_alerts_per_group,omitempty"`
 }
 
 // Key returns unique identification of route
@@ -995,6 +1604,12 @@ func (r *Route) Validate() error {
 	if r.RepeatInterval != nil && time.Duration(*r.RepeatInterval) == time.Duration(0) {
 		return fmt.Errorf("repeat_interval cannot be zero")
 	}
+	if r.MaxAggregationGroups != nil && *r.MaxAggregationGroups < 0 {
+		return fmt.Errorf("max_aggregation_groups cannot be negative")
+	}
+	if r.MaxAlertsPerGroup != nil && *r.MaxAlertsPerGroup < 0 {
+		return fmt.Errorf("max_alerts_per_group cannot be negative")
+	}
 
 	return nil
 }
@@ -1060,18 +1675,31 @@ func (r *InhibitRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type Receiver struct {
 	// A unique identifier for this receiver.
 	Name string `yaml:"name" json:"name"`
-
-	EmailConfigs     []*EmailConfig     `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
-	PagerdutyConfigs []*PagerdutyConfig `yaml:"pagerduty_configs,omitempty" json:"pagerduty_configs,omitempty"`
-	SlackConfigs     []*SlackConfig     `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
-	WebhookConfigs   []*WebhookConfig   `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
-	OpsGenieConfigs  []*OpsGenieConfig  `yaml:"opsgenie_configs,omitempty" json:"opsgenie_configs,omitempty"`
-	TelegramConfigs  []*TelegramConfig  `yaml:"telegram_configs,omitempty" json:"telegram_configs,omitempty"`
-	WechatConfigs    []*WechatConfig    `yaml:"wechat_configs,omitempty" json:"wechat_configs,omitempty"`
-	PushoverConfigs  []*PushoverConfig  `yaml:"pushover_configs,omitempty" json:"pushover_configs,omitempty"`
-	VictorOpsConfigs []*VictorOpsConfig `yaml:"victorops_configs,omitempty" json:"victorops_configs,omitempty"`
-	SNSConfigs       []*SNSConfig       `yaml:"sns_configs,omitempty" json:"sns_configs,omitempty"`
-	MSTeamsConfigs   []*MSTeamsConfig   `yaml:"msteams_configs,omitempty" json:"msteams_configs,omitempty"`
+	// FallbackReceiver is notified when an integration of the receiver gives
+	// up on a notification.
+	FallbackReceiver string `yaml:"fallback_receiver,omitempty" json:"fallback_receiver,omitempty"`
+	// Digest turns the receiver into a digest receiver, notified of a summary
+	// of its alerts on a schedule instead of in real time.
+	Digest *DigestConfig `yaml:"digest,omitempty" json:"digest,omitempty"`
+
+	EmailConfigs      []*EmailConfig      `yaml:"email_configs,omitempty" json:"email_configs,omitempty"`
+	PagerdutyConfigs  []*PagerdutyConfig  `yaml:"pagerduty_configs,omitempty" json:"pagerduty_configs,omitempty"`
+	SlackConfigs      []*SlackConfig      `yaml:"slack_configs,omitempty" json:"slack_configs,omitempty"`
+	WebhookConfigs    []*WebhookConfig    `yaml:"webhook_configs,omitempty" json:"webhook_configs,omitempty"`
+	OpsGenieConfigs   []*OpsGenieConfig   `yaml:"opsgenie_configs,omitempty" json:"opsgenie_configs,omitempty"`
+	TelegramConfigs   []*TelegramConfig   `yaml:"telegram_configs,omitempty" json:"telegram_configs,omitempty"`
+	WechatConfigs     []*WechatConfig     `yaml:"wechat_configs,omitempty" json:"wechat_configs,omitempty"`
+	PushoverConfigs   []*PushoverConfig   `yaml:"pushover_configs,omitempty" json:"pushover_configs,omitempty"`
+	VictorOpsConfigs  []*VictorOpsConfig  `yaml:"victorops_configs,omitempty" json:"victorops_configs,omitempty"`
+	SNSConfigs        []*SNSConfig        `yaml:"sns_configs,omitempty" json:"sns_configs,omitempty"`
+	MSTeamsConfigs    []*MSTeamsConfig    `yaml:"msteams_configs,omitempty" json:"msteams_configs,omitempty"`
+	ServiceNowConfigs []*ServiceNowConfig `yaml:"servicenow_configs,omitempty" json:"servicenow_configs,omitempty"`
+	SMSConfigs        []*SMSConfig        `yaml:"sms_configs,omitempty" json:"sms_configs,omitempty"`
+	KafkaConfigs      []*KafkaConfig      `yaml:"kafka_configs,omitempty" json:"kafka_configs,omitempty"`
+	NATSConfigs       []*NATSConfig       `yaml:"nats_configs,omitempty" json:"nats_configs,omitempty"`
+	MQTTConfigs       []*MQTTConfig       `yaml:"mqtt_configs,omitempty" json:"mqtt_configs,omitempty"`
+	ExecConfigs       []*ExecConfig       `yaml:"exec_configs,omitempty" json:"exec_configs,omitempty"`
+	PluginConfigs     []*PluginConfig     `yaml:"plugin_configs,omitempty" json:"plugin_configs,omitempty"`
 }
 
 func (c *Receiver) Validate() error {
@@ -1102,6 +1730,70 @@ func (c *Receiver) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// DigestConfig configures the schedule of the digests of a receiver. Exactly
+// one of Interval and TimeOfDay must be set.
+type DigestConfig struct {
+	// Interval sends a digest every interval, aligned on the UTC clock.
+	Interval model.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	// TimeOfDay sends a digest every day at the time of day, as HH:MM.
+	TimeOfDay string `yaml:"time_of_day,omitempty" json:"time_of_day,omitempty"`
+	// Location is the time zone of the time of day. It defaults to UTC.
+	Location string `yaml:"location,omitempty" json:"location,omitempty"`
+
+	hour, minute int
+	loc          *time.Location
+}
+
+// Validate checks the schedule of the digests.
+func (c *DigestConfig) Validate() error {
+	if (c.Interval == 0) == (c.TimeOfDay == "") {
+		return fmt.Errorf("exactly one of interval and time_of_day must be set")
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if c.Interval > 0 && c.Location != "" {
+		return fmt.Errorf("location can only be set with time_of_day")
+	}
+	if c.TimeOfDay == "" {
+		return nil
+	}
+	t, err := time.Parse("15:04", c.TimeOfDay)
+	if err != nil {
+		return fmt.Errorf("invalid time_of_day %q, expected HH:MM", c.TimeOfDay)
+	}
+	c.hour, c.minute = t.Hour(), t.Minute()
+	c.loc = time.UTC
+	if c.Location != "" {
+		if c.loc, err = time.LoadLocation(c.Location); err != nil {
+			return fmt.Errorf("invalid location %q: %w", c.Location, err)
+		}
+	}
+	return nil
+}
+
+// Previous returns the last time a digest is due at or before t.
+func (c *DigestConfig) Previous(t time.Time) time.Time {
+	if c.Interval > 0 {
+		return t.Truncate(time.Duration(c.Interval))
+	}
+	lt := t.In(c.loc)
+	due := time.Date(lt.Year(), lt.Month(), lt.Day(), c.hour, c.minute, 0, 0, c.loc)
+	if due.After(t) {
+		due = time.Date(lt.Year(), lt.Month(), lt.Day()-1, c.hour, c.minute, 0, 0, c.loc)
+	}
+	return due
+}
+
+// Next returns the first time a digest is due after t.
+func (c *DigestConfig) Next(t time.Time) time.Time {
+	if c.Interval > 0 {
+		return c.Previous(t).Add(time.Duration(c.Interval))
+	}
+	prev := c.Previous(t).In(c.loc)
+	return time.Date(prev.Year(), prev.Month(), prev.Day()+1, c.hour, c.minute, 0, 0, c.loc)
+}
+
 // MatchRegexps represents a map of Regexp.
 type MatchRegexps map[string]Regexp
 
@@ -28,6 +28,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/pkg/relabel"
 )
 
 func TestLoadEmptyString(t *testing.T) {
@@ -249,6 +251,630 @@ route:
 
 }
 
+func TestEscalationPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "valid",
+			in: `
+escalation_policies:
+- name: on-call
+  steps:
+  - receiver: primary
+    delay: 15m
+  - receiver: secondary
+    delay: 30m
+receivers:
+- name: slack
+- name: primary
+- name: secondary
+route:
+  receiver: slack
+  routes:
+  - matchers: ['severity="critical"']
+    escalation_policy: on-call
+`,
+		},
+		{
+			name: "undefined policy",
+			in: `
+receivers:
+- name: slack
+route:
+  receiver: slack
+  escalation_policy: on-call
+`,
+			err: `undefined escalation policy "on-call" used in route`,
+		},
+		{
+			name: "undefined receiver",
+			in: `
+escalation_policies:
+- name: on-call
+  steps:
+  - receiver: primary
+receivers:
+- name: slack
+route:
+  receiver: slack
+`,
+			err: `undefined receiver "primary" used in escalation policy "on-call"`,
+		},
+		{
+			name: "duplicated policy",
+			in: `
+escalation_policies:
+- name: on-call
+  steps:
+  - receiver: slack
+- name: on-call
+  steps:
+  - receiver: slack
+receivers:
+- name: slack
+route:
+  receiver: slack
+`,
+			err: `escalation policy "on-call" is not unique`,
+		},
+		{
+			name: "missing steps",
+			in: `
+escalation_policies:
+- name: on-call
+receivers:
+- name: slack
+route:
+  receiver: slack
+`,
+			err: `missing steps in escalation policy "on-call"`,
+		},
+		{
+			name: "unsorted steps",
+			in: `
+escalation_policies:
+- name: on-call
+  steps:
+  - receiver: slack
+    delay: 30m
+  - receiver: slack
+    delay: 15m
+receivers:
+- name: slack
+route:
+  receiver: slack
+`,
+			err: `steps of escalation policy "on-call" must be sorted by delay`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Load(tc.in)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.EscalationPolicies, 1)
+			require.Equal(t, model.Duration(30*time.Minute), c.EscalationPolicies[0].Steps[1].Delay)
+			require.Equal(t, "on-call", c.Route.Routes[0].EscalationPolicy)
+		})
+	}
+}
+
+func TestFallbackReceivers(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "valid",
+			in: `
+receivers:
+- name: pagerduty
+  fallback_receiver: slack
+- name: slack
+  fallback_receiver: email
+- name: email
+route:
+  receiver: pagerduty
+`,
+		},
+		{
+			name: "undefined receiver",
+			in: `
+receivers:
+- name: pagerduty
+  fallback_receiver: slack
+route:
+  receiver: pagerduty
+`,
+			err: `undefined fallback receiver "slack" used in receiver "pagerduty"`,
+		},
+		{
+			name: "self",
+			in: `
+receivers:
+- name: pagerduty
+  fallback_receiver: pagerduty
+route:
+  receiver: pagerduty
+`,
+			err: `fallback receivers of receiver "pagerduty" form a cycle`,
+		},
+		{
+			name: "cycle",
+			in: `
+receivers:
+- name: pagerduty
+  fallback_receiver: slack
+- name: slack
+  fallback_receiver: pagerduty
+route:
+  receiver: pagerduty
+`,
+			err: `form a cycle`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := Load(tc.in)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "slack", c.Receivers[0].FallbackReceiver)
+		})
+	}
+}
+
+func TestDigestConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "interval",
+			in:   `interval: 1h`,
+		},
+		{
+			name: "time of day",
+			in: `
+time_of_day: "09:30"
+location: Europe/Paris`,
+		},
+		{
+			name: "missing schedule",
+			in:   `location: Europe/Paris`,
+			err:  "exactly one of interval and time_of_day must be set",
+		},
+		{
+			name: "both schedules",
+			in: `
+interval: 1h
+time_of_day: "09:30"`,
+			err: "exactly one of interval and time_of_day must be set",
+		},
+		{
+			name: "location with interval",
+			in: `
+interval: 1h
+location: Europe/Paris`,
+			err: "location can only be set with time_of_day",
+		},
+		{
+			name: "invalid time of day",
+			in:   `time_of_day: "25:00"`,
+			err:  `invalid time_of_day "25:00", expected HH:MM`,
+		},
+		{
+			name: "invalid location",
+			in: `
+time_of_day: "09:30"
+location: Nowhere/Special`,
+			err: `invalid location "Nowhere/Special"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c DigestConfig
+			require.NoError(t, yaml.UnmarshalStrict([]byte(tc.in), &c))
+			err := c.Validate()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// Receivers with an invalid digest are rejected.
+	_, err := Load(`
+receivers:
+- name: digest
+  digest:
+    time_of_day: "9h"
+route:
+  receiver: digest
+`)
+	require.EqualError(t, err, `invalid digest in receiver "digest": invalid time_of_day "9h", expected HH:MM`)
+}
+
+func TestFlapDetectionConfig(t *testing.T) {
+	c, err := Load(`
+flap_detection:
+  threshold: 3
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+	require.NoError(t, err)
+	require.Equal(t, &FlapDetectionConfig{Window: model.Duration(time.Hour), Threshold: 3}, c.FlapDetection)
+
+	_, err = Load(`
+flap_detection:
+  window: 0s
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+	require.EqualError(t, err, "window must be positive in flap detection config")
+
+	_, err = Load(`
+flap_detection:
+  threshold: 1
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+	require.EqualError(t, err, "threshold must be at least 2 in flap detection config")
+}
+
+func TestTracingConfig(t *testing.T) {
+	c, err := Load(`
+tracing:
+  endpoint: otel-collector:4317
+  sampling_fraction: 0.5
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+	require.NoError(t, err)
+	require.Equal(t, &TracingConfig{
+		ClientType:       OTLPClientGRPC,
+		Endpoint:         "otel-collector:4317",
+		SamplingFraction: 0.5,
+		Timeout:          model.Duration(10 * time.Second),
+	}, c.Tracing)
+
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  `client_type: http`,
+			err: "missing endpoint in tracing config",
+		},
+		{
+			in:  `endpoint: http://otel-collector:4318`,
+			err: `endpoint "http://otel-collector:4318" in tracing config must be host:port, without a scheme`,
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  client_type: thrift`,
+			err: `unknown client_type "thrift" in tracing config, expected grpc or http`,
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  sampling_fraction: 2`,
+			err: "sampling_fraction must be between 0 and 1 in tracing config",
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  compression: snappy`,
+			err: `unsupported compression "snappy" in tracing config, expected gzip`,
+		},
+	} {
+		_, err := Load(`
+tracing:
+  ` + strings.TrimSpace(tc.in) + `
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestLogEventsConfig(t *testing.T) {
+	c, err := Load(`
+log_events:
+  client_type: http
+  endpoint: otel-collector:4318
+  batch_size: 100
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+	require.NoError(t, err)
+	require.Equal(t, &LogEventsConfig{
+		ClientType:    OTLPClientHTTP,
+		Endpoint:      "otel-collector:4318",
+		Timeout:       model.Duration(10 * time.Second),
+		BatchSize:     100,
+		BufferSize:    4096,
+		FlushInterval: model.Duration(5 * time.Second),
+	}, c.LogEvents)
+
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  `client_type: grpc`,
+			err: "missing endpoint in log events config",
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  batch_size: 0`,
+			err: "batch_size must be positive in log events config",
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  batch_size: 100
+  buffer_size: 10`,
+			err: "buffer_size must be at least batch_size in log events config",
+		},
+		{
+			in: `
+  endpoint: otel-collector:4317
+  flush_interval: 0s`,
+			err: "flush_interval must be positive in log events config",
+		},
+	} {
+		_, err := Load(`
+log_events:
+  ` + strings.TrimSpace(tc.in) + `
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+`)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestLimitsConfig(t *testing.T) {
+	c, err := Load(`
+limits:
+  max_aggregation_groups: 10000
+receivers:
+- name: team-X
+route:
+  receiver: team-X
+  routes:
+  - matchers: ['team="X"']
+    max_alerts_per_group: 100
+`)
+	require.NoError(t, err)
+	require.Equal(t, &LimitsConfig{
+		MaxAggregationGroups: 10000,
+		Overflow:             OverflowDrop,
+	}, c.Limits)
+	require.Equal(t, 100, *c.Route.Routes[0].MaxAlertsPerGroup)
+	require.Nil(t, c.Route.Routes[0].MaxAggregationGroups)
+
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in: `
+limits:
+  max_aggregation_groups: -1`,
+			err: "max_aggregation_groups cannot be negative in limits config",
+		},
+		{
+			in: `
+limits:
+  overflow: reject`,
+			err: `unknown overflow policy "reject" in limits config, expected drop or overflow_group`,
+		},
+		{
+			in: `
+route:
+  receiver: team-X
+  routes:
+  - max_alerts_per_group: -1`,
+			err: "max_alerts_per_group cannot be negative",
+		},
+	} {
+		in := tc.in
+		if !strings.Contains(in, "route:") {
+			in += `
+route:
+  receiver: team-X`
+		}
+		_, err := Load(in + `
+receivers:
+- name: team-X
+`)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestLookupTables(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "file",
+			in: `
+- name: services
+  file: services.csv
+  key_label: service
+  labels: [team]
+  annotations: [runbook_url]`,
+		},
+		{
+			name: "managed",
+			in: `
+- name: teams
+  key_label: team
+  annotations: [slack_channel]`,
+		},
+		{
+			name: "missing name",
+			in: `
+- key_label: team
+  labels: [escalation]`,
+			err: "missing name in lookup table",
+		},
+		{
+			name: "invalid key label",
+			in: `
+- name: teams
+  key_label: team-name
+  labels: [escalation]`,
+			err: `invalid key_label "team-name" in lookup table "teams"`,
+		},
+		{
+			name: "no columns",
+			in: `
+- name: teams
+  key_label: team`,
+			err: `at least one of labels & annotations must be configured for lookup table "teams"`,
+		},
+		{
+			name: "invalid label",
+			in: `
+- name: teams
+  key_label: team
+  labels: [slack-channel]`,
+			err: `invalid label "slack-channel" in lookup table "teams"`,
+		},
+		{
+			name: "unsupported file",
+			in: `
+- name: teams
+  file: teams.json
+  key_label: team
+  labels: [escalation]`,
+			err: `unsupported file "teams.json" in lookup table "teams", expected .csv, .yaml or .yml`,
+		},
+		{
+			name: "duplicated name",
+			in: `
+- name: teams
+  key_label: team
+  labels: [escalation]
+- name: teams
+  key_label: team
+  labels: [escalation]`,
+			err: `lookup table "teams" is not unique`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load("receivers:\n- name: team-X\nroute:\n  receiver: team-X\nlookup_tables:" + tc.in)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// Relative files are resolved against the directory of the configuration.
+	c, err := LoadFile("testdata/conf.lookup-tables.yml")
+	require.NoError(t, err)
+	require.Equal(t, "testdata/services.csv", c.LookupTables[0].File)
+}
+
+func TestRelabelConfigs(t *testing.T) {
+	c, err := Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+relabel_configs:
+- source_labels: [sev]
+  target_label: severity
+- source_labels: [env]
+  regex: test
+  action: drop
+`)
+	require.NoError(t, err)
+	require.Len(t, c.RelabelConfigs, 2)
+	require.Equal(t, relabel.Drop, c.RelabelConfigs[1].Action)
+
+	_, err = Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+relabel_configs:
+- source_labels: [sev]
+`)
+	require.EqualError(t, err, `relabel action "replace" requires target_label`)
+}
+
+func TestDigestConfigSchedule(t *testing.T) {
+	hourly := &DigestConfig{Interval: model.Duration(time.Hour)}
+	require.NoError(t, hourly.Validate())
+	now := time.Date(2026, 3, 28, 10, 42, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 3, 28, 10, 0, 0, 0, time.UTC), hourly.Previous(now))
+	require.Equal(t, time.Date(2026, 3, 28, 11, 0, 0, 0, time.UTC), hourly.Next(now))
+
+	daily := &DigestConfig{TimeOfDay: "09:30", Location: "Europe/Paris"}
+	require.NoError(t, daily.Validate())
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		now, prev, next time.Time
+	}{
+		{
+			now:  time.Date(2026, 3, 28, 10, 0, 0, 0, paris),
+			prev: time.Date(2026, 3, 28, 9, 30, 0, 0, paris),
+			next: time.Date(2026, 3, 29, 9, 30, 0, 0, paris),
+		},
+		{
+			now:  time.Date(2026, 3, 28, 9, 0, 0, 0, paris),
+			prev: time.Date(2026, 3, 27, 9, 30, 0, 0, paris),
+			next: time.Date(2026, 3, 28, 9, 30, 0, 0, paris),
+		},
+		{
+			// The time of day is kept across daylight saving time changes.
+			now:  time.Date(2026, 3, 29, 12, 0, 0, 0, paris),
+			prev: time.Date(2026, 3, 29, 9, 30, 0, 0, paris),
+			next: time.Date(2026, 3, 30, 9, 30, 0, 0, paris),
+		},
+		{
+			now:  time.Date(2026, 3, 29, 9, 30, 0, 0, paris).UTC(),
+			prev: time.Date(2026, 3, 29, 9, 30, 0, 0, paris),
+			next: time.Date(2026, 3, 30, 9, 30, 0, 0, paris),
+		},
+	} {
+		require.True(t, tc.prev.Equal(daily.Previous(tc.now)), "previous of %s: %s", tc.now, daily.Previous(tc.now))
+		require.True(t, tc.next.Equal(daily.Next(tc.now)), "next of %s: %s", tc.now, daily.Next(tc.now))
+	}
+}
+
 func TestGroupByHasNoDuplicatedLabels(t *testing.T) {
 	in := `
 route:
@@ -1224,3 +1850,64 @@ func TestAddAndDeleteRoute(t *testing.T) {
 	assert.Equal(check2, true, "deleted receiver still exists")
 	assert.Equal(config.Route.Routes[0], &route, "deleting route did not work")
 }
+
+func TestPluginConfigs(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in: `
+plugins:
+- name: jira
+  socket: /run/jira.sock
+route:
+  receiver: team
+receivers:
+- name: team
+  plugin_configs:
+  - plugin: jira
+    config:
+      project: OPS
+      fields:
+        component: backend
+`,
+		},
+		{
+			in: `
+plugins:
+- name: jira
+  command: am-jira
+  socket: /run/jira.sock
+route:
+  receiver: team
+receivers:
+- name: team
+`,
+			err: `exactly one of command & socket must be configured for plugin "jira"`,
+		},
+		{
+			in: `
+route:
+  receiver: team
+receivers:
+- name: team
+  plugin_configs:
+  - plugin: jira
+`,
+			err: `undefined plugin "jira" used in receiver "team"`,
+		},
+	} {
+		cfg, err := Load(tc.in)
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+
+		// Nested objects can be passed to the plugin as JSON.
+		b, err := json.Marshal(cfg.Receivers[0].PluginConfigs[0].Config)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"project":"OPS","fields":{"component":"backend"}}`, string(b))
+	}
+}
@@ -154,17 +154,226 @@ var (
 		},
 		Text: `{{ template "msteams.default.text" . }}`,
 	}
+
+	// DefaultSMSConfig defines default values for SMS configurations.
+	DefaultSMSConfig = SMSConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: false,
+		},
+		Provider:         SMSProviderTwilio,
+		Message:          `{{ template "sms.default.message" . }}`,
+		MaxMessageLength: 160,
+		SendInterval:     duration(1 * time.Second),
+		FromParam:        "from",
+		ToParam:          "to",
+		MessageParam:     "message",
+	}
+
+	// DefaultServiceNowSeverityMapping maps common severity label values to
+	// ServiceNow impact and urgency values (1 = high, 2 = medium, 3 = low).
+	DefaultServiceNowSeverityMapping = map[string]ServiceNowImpactUrgency{
+		"critical": {Impact: "1", Urgency: "1"},
+		"error":    {Impact: "2", Urgency: "1"},
+		"high":     {Impact: "2", Urgency: "1"},
+		"warning":  {Impact: "2", Urgency: "2"},
+		"info":     {Impact: "3", Urgency: "3"},
+	}
+
+	// DefaultServiceNowConfig defines default values for ServiceNow configurations.
+	DefaultServiceNowConfig = ServiceNowConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Table:            "incident",
+		ShortDescription: `{{ template "servicenow.default.short_description" . }}`,
+		Description:      `{{ template "servicenow.default.description" . }}`,
+		SeverityLabel:    "severity",
+		DefaultImpact:    "3",
+		DefaultUrgency:   "3",
+		ResolvedState:    "6",
+		CloseCode:        "Solved (Permanently)",
+		CloseNotes:       `{{ template "servicenow.default.close_notes" . }}`,
+	}
+
+	// DefaultKafkaConfig defines default values for Kafka configurations.
+	DefaultKafkaConfig = KafkaConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Acks:        KafkaAcksAll,
+		Compression: "none",
+		Timeout:     duration(10 * time.Second),
+	}
+
+	// DefaultNATSConfig defines default values for NATS configurations.
+	DefaultNATSConfig = NATSConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Timeout: duration(10 * time.Second),
+	}
+
+	// DefaultMQTTConfig defines default values for MQTT configurations.
+	DefaultMQTTConfig = MQTTConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		QoS:     1,
+		Timeout: duration(10 * time.Second),
+	}
+
+	// DefaultExecConfig defines default values for exec configurations.
+	DefaultExecConfig = ExecConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Timeout:        duration(30 * time.Second),
+		MaxConcurrency: 1,
+		// EX_TEMPFAIL from sysexits.h.
+		RetryExitCodes: []int{75},
+	}
 )
 
 // NotifierConfig contains base options common across all notifier configurations.
 type NotifierConfig struct {
 	VSendResolved bool `yaml:"send_resolved" json:"send_resolved"`
+
+	// RetryPolicy configures how failed notifications are retried. It isn't
+	// named retry as pushover configs already use that key.
+	RetryPolicy *RetryConfig `yaml:"retry_policy,omitempty" json:"retry_policy,omitempty"`
+	// CircuitBreaker stops sending notifications to an integration that keeps
+	// failing.
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker,omitempty" json:"circuit_breaker,omitempty"`
 }
 
 func (nc *NotifierConfig) SendResolved() bool {
 	return nc.VSendResolved
 }
 
+// RetryConfig returns the retry policy of the notifier, nil if none is
+// configured.
+func (nc *NotifierConfig) RetryConfig() *RetryConfig {
+	return nc.RetryPolicy
+}
+
+// CircuitBreakerConfig returns the circuit breaker configuration of the
+// notifier, nil if none is configured.
+func (nc *NotifierConfig) CircuitBreakerConfig() *CircuitBreakerConfig {
+	return nc.CircuitBreaker
+}
+
+// DefaultCircuitBreakerConfig defines default values for circuit breakers.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	OpenDuration:     duration(time.Minute),
+}
+
+// CircuitBreakerConfig configures the circuit breaker of an integration. The
+// breaker opens after FailureThreshold consecutive failed attempts. While
+// open, notifications are dropped without being sent. After OpenDuration, a
+// single attempt is let through: the breaker closes if it succeeds and opens
+// again otherwise.
+type CircuitBreakerConfig struct {
+	FailureThreshold int      `yaml:"failure_threshold,omitempty" json:"failure_threshold,omitempty"`
+	OpenDuration     duration `yaml:"open_duration,omitempty" json:"open_duration,omitempty"`
+}
+
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold <= 0 {
+		return fmt.Errorf("failure_threshold must be positive in circuit breaker config")
+	}
+	if c.OpenDuration <= 0 {
+		return fmt.Errorf("open_duration must be positive in circuit breaker config")
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *CircuitBreakerConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultCircuitBreakerConfig
+	type plain CircuitBreakerConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (CircuitBreakerConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *CircuitBreakerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultCircuitBreakerConfig
+	type plain CircuitBreakerConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// Failure reasons of notifications that can be listed in the retry_on field
+// of a retry policy.
+const (
+	RetryOnClientError = "clientError"
+	RetryOnServerError = "serverError"
+	RetryOnOther       = "other"
+)
+
+// RetryConfig configures how failed notifications are retried. Unset fields
+// keep the default behavior of retrying with an exponential backoff until
+// the notification times out.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts, 0 meaning unlimited.
+	MaxAttempts int `yaml:"max_attempts,omitempty" json:"max_attempts,omitempty"`
+	// InitialBackoff and MaxBackoff bound the exponential backoff between
+	// attempts.
+	InitialBackoff duration `yaml:"initial_backoff,omitempty" json:"initial_backoff,omitempty"`
+	MaxBackoff     duration `yaml:"max_backoff,omitempty" json:"max_backoff,omitempty"`
+	// AttemptTimeout is the timeout of a single attempt.
+	AttemptTimeout duration `yaml:"attempt_timeout,omitempty" json:"attempt_timeout,omitempty"`
+	// RetryOn lists the failure reasons that are retried. If empty, all
+	// failures the notifier considers recoverable are retried.
+	RetryOn []string `yaml:"retry_on,omitempty" json:"retry_on,omitempty"`
+}
+
+func (c *RetryConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts must not be negative in retry config")
+	}
+	if c.InitialBackoff < 0 || c.MaxBackoff < 0 || c.AttemptTimeout < 0 {
+		return fmt.Errorf("durations must not be negative in retry config")
+	}
+	if c.InitialBackoff > 0 && c.MaxBackoff > 0 && c.InitialBackoff > c.MaxBackoff {
+		return fmt.Errorf("initial_backoff must not be greater than max_backoff in retry config")
+	}
+	for _, r := range c.RetryOn {
+		switch r {
+		case RetryOnClientError, RetryOnServerError, RetryOnOther:
+		default:
+			return fmt.Errorf("unknown failure reason %q in retry config", r)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *RetryConfig) UnmarshalJSON(data []byte) error {
+	type plain RetryConfig
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RetryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = RetryConfig{}
+	type plain RetryConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
 // EmailConfig configures notifications via mail.
 type EmailConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
@@ -456,6 +665,49 @@ type WebhookConfig struct {
 	// Alerts exceeding this threshold will be truncated. Setting this to 0
 	// allows an unlimited number of alerts.
 	MaxAlerts uint64 `yaml:"max_alerts" json:"max_alerts"`
+
+	// Headers are added to the request. The values are templated.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Body is the template of the request body. If empty, the webhook
+	// message is sent as JSON.
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+	// Signature configures the signing of requests.
+	Signature *WebhookSignatureConfig `yaml:"signature,omitempty" json:"signature,omitempty"`
+	// CloudEvents sends the webhook message as CloudEvents 1.0.
+	CloudEvents *WebhookCloudEventsConfig `yaml:"cloudevents,omitempty" json:"cloudevents,omitempty"`
+}
+
+const (
+	// CloudEventsStructured sends the event attributes and the message in
+	// a single JSON document.
+	CloudEventsStructured = "structured"
+	// CloudEventsBinary sends the event attributes as ce-* headers and the
+	// message as the request body.
+	CloudEventsBinary = "binary"
+	// CloudEventsBatch sends a JSON array holding one event per alert.
+	CloudEventsBatch = "batch"
+)
+
+// WebhookCloudEventsConfig configures the CloudEvents payload mode of
+// webhooks.
+type WebhookCloudEventsConfig struct {
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty"`
+	// Source is the source attribute of the events. If empty, the external
+	// URL of the Alertmanager is used.
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+}
+
+// WebhookSignatureConfig configures the HMAC-SHA256 signature of webhook
+// requests. The signature covers the timestamp and the body, joined by a dot.
+type WebhookSignatureConfig struct {
+	Secret Secret `yaml:"secret" json:"secret"`
+	// PreviousSecret is used during secret rotation: requests carry a
+	// signature for each secret until the receiver has switched to the new
+	// one.
+	PreviousSecret Secret `yaml:"previous_secret,omitempty" json:"previous_secret,omitempty"`
+
+	Header          string `yaml:"header,omitempty" json:"header,omitempty"`
+	TimestampHeader string `yaml:"timestamp_header,omitempty" json:"timestamp_header,omitempty"`
 }
 
 func (c *WebhookConfig) Validate() error {
@@ -467,6 +719,36 @@ func (c *WebhookConfig) Validate() error {
 		return fmt.Errorf("scheme required for webhook url")
 	}
 
+	if sig := c.Signature; sig != nil {
+		if sig.Secret == "" {
+			return fmt.Errorf("missing signature secret in webhook config")
+		}
+		if sig.Header == "" {
+			sig.Header = "X-Alertmanager-Signature"
+		}
+		if sig.TimestampHeader == "" {
+			sig.TimestampHeader = "X-Alertmanager-Timestamp"
+		}
+		for k := range c.Headers {
+			if strings.EqualFold(k, sig.Header) || strings.EqualFold(k, sig.TimestampHeader) {
+				return fmt.Errorf("header %q conflicts with the signature headers in webhook config", k)
+			}
+		}
+	}
+
+	if ce := c.CloudEvents; ce != nil {
+		switch ce.Mode {
+		case "":
+			ce.Mode = CloudEventsStructured
+		case CloudEventsStructured, CloudEventsBinary, CloudEventsBatch:
+		default:
+			return fmt.Errorf("unknown CloudEvents mode %q in webhook config", ce.Mode)
+		}
+		if c.Body != "" {
+			return fmt.Errorf("body and cloudevents are mutually exclusive in webhook config")
+		}
+	}
+
 	if c.HTTPConfig != nil {
 		if err := c.HTTPConfig.Validate(); err != nil {
 			return err
@@ -773,3 +1055,541 @@ func (c *MSTeamsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type plain MSTeamsConfig
 	return unmarshal((*plain)(c))
 }
+
+// ServiceNowImpactUrgency is the impact and urgency pair an incident is
+// created with for a given severity.
+type ServiceNowImpactUrgency struct {
+	Impact  string `yaml:"impact" json:"impact"`
+	Urgency string `yaml:"urgency" json:"urgency"`
+}
+
+// ServiceNowConfig configures notifications via the ServiceNow Table API.
+type ServiceNowConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// APIURL is the base URL of the ServiceNow instance,
+	// e.g. https://example.service-now.com/.
+	APIURL   *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password Secret `yaml:"password,omitempty" json:"password,omitempty"`
+	// Table is the name of the table incidents are recorded in.
+	Table string `yaml:"table,omitempty" json:"table,omitempty"`
+
+	ShortDescription string            `yaml:"short_description,omitempty" json:"short_description,omitempty"`
+	Description      string            `yaml:"description,omitempty" json:"description,omitempty"`
+	CallerID         string            `yaml:"caller_id,omitempty" json:"caller_id,omitempty"`
+	AssignmentGroup  string            `yaml:"assignment_group,omitempty" json:"assignment_group,omitempty"`
+	Category         string            `yaml:"category,omitempty" json:"category,omitempty"`
+	Subcategory      string            `yaml:"subcategory,omitempty" json:"subcategory,omitempty"`
+	CMDBCI           string            `yaml:"cmdb_ci,omitempty" json:"cmdb_ci,omitempty"`
+	CustomFields     map[string]string `yaml:"custom_fields,omitempty" json:"custom_fields,omitempty"`
+
+	// SeverityLabel is the alert label whose value selects the impact and
+	// urgency from SeverityMapping.
+	SeverityLabel   string                             `yaml:"severity_label,omitempty" json:"severity_label,omitempty"`
+	SeverityMapping map[string]ServiceNowImpactUrgency `yaml:"severity_mapping,omitempty" json:"severity_mapping,omitempty"`
+	DefaultImpact   string                             `yaml:"default_impact,omitempty" json:"default_impact,omitempty"`
+	DefaultUrgency  string                             `yaml:"default_urgency,omitempty" json:"default_urgency,omitempty"`
+
+	// ResolvedState is the incident state set once the alert group resolves.
+	ResolvedState string `yaml:"resolved_state,omitempty" json:"resolved_state,omitempty"`
+	CloseCode     string `yaml:"close_code,omitempty" json:"close_code,omitempty"`
+	CloseNotes    string `yaml:"close_notes,omitempty" json:"close_notes,omitempty"`
+}
+
+// UnmarshalJSON implements JSON interface and includes default params,
+// the interface has been added to inject default params when
+// the config is created through API
+func (c *ServiceNowConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultServiceNowConfig
+	type plain ServiceNowConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (ServiceNowConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ServiceNowConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultServiceNowConfig
+	type plain ServiceNowConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *ServiceNowConfig) Validate() error {
+	if c.APIURL == nil {
+		return fmt.Errorf("missing api_url in ServiceNow config")
+	}
+	if c.Username == "" || c.Password == "" {
+		return fmt.Errorf("missing username or password in ServiceNow config")
+	}
+	if c.Table == "" {
+		return fmt.Errorf("missing table in ServiceNow config")
+	}
+	if c.SeverityMapping == nil {
+		c.SeverityMapping = make(map[string]ServiceNowImpactUrgency, len(DefaultServiceNowSeverityMapping))
+		for k, v := range DefaultServiceNowSeverityMapping {
+			c.SeverityMapping[k] = v
+		}
+	}
+	if !strings.HasSuffix(c.APIURL.Path, "/") {
+		c.APIURL.Path += "/"
+	}
+	return nil
+}
+
+const (
+	// SMSProviderTwilio sends messages through the Twilio Messages API or
+	// any gateway implementing it.
+	SMSProviderTwilio = "twilio"
+	// SMSProviderGeneric posts a URL-encoded form to an arbitrary gateway.
+	SMSProviderGeneric = "generic"
+)
+
+// SMSConfig configures notifications via an SMS gateway.
+type SMSConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	APIURL   *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+
+	// Twilio account credentials.
+	AccountSID string `yaml:"account_sid,omitempty" json:"account_sid,omitempty"`
+	AuthToken  Secret `yaml:"auth_token,omitempty" json:"auth_token,omitempty"`
+
+	From    string   `yaml:"from,omitempty" json:"from,omitempty"`
+	To      []string `yaml:"to,omitempty" json:"to,omitempty"`
+	Message string   `yaml:"message,omitempty" json:"message,omitempty"`
+
+	// MaxMessageLength is the number of runes a message is truncated to.
+	MaxMessageLength int `yaml:"max_message_length,omitempty" json:"max_message_length,omitempty"`
+	// SendInterval is the minimum time between two messages sent by the
+	// integration, to stay within the rate limits of the gateway.
+	SendInterval duration `yaml:"send_interval,omitempty" json:"send_interval,omitempty"`
+
+	// Form parameter names and additional parameters for the generic provider.
+	FromParam    string            `yaml:"from_param,omitempty" json:"from_param,omitempty"`
+	ToParam      string            `yaml:"to_param,omitempty" json:"to_param,omitempty"`
+	MessageParam string            `yaml:"message_param,omitempty" json:"message_param,omitempty"`
+	FormParams   map[string]string `yaml:"form_params,omitempty" json:"form_params,omitempty"`
+}
+
+// UnmarshalJSON implements JSON interface and includes default params,
+// the interface has been added to inject default params when
+// the config is created through API
+func (c *SMSConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultSMSConfig
+	type plain SMSConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (SMSConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SMSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultSMSConfig
+	type plain SMSConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *SMSConfig) Validate() error {
+	if len(c.To) == 0 {
+		return fmt.Errorf("missing to in SMS config")
+	}
+	if c.MaxMessageLength <= 0 {
+		return fmt.Errorf("max_message_length must be positive in SMS config")
+	}
+	switch c.Provider {
+	case SMSProviderTwilio:
+		if c.AccountSID == "" || c.AuthToken == "" {
+			return fmt.Errorf("missing account_sid or auth_token in SMS config")
+		}
+		if c.From == "" {
+			return fmt.Errorf("missing from in SMS config")
+		}
+	case SMSProviderGeneric:
+		if c.APIURL == nil {
+			return fmt.Errorf("missing api_url in SMS config")
+		}
+		if c.ToParam == "" || c.MessageParam == "" {
+			return fmt.Errorf("missing to_param or message_param in SMS config")
+		}
+	default:
+		return fmt.Errorf("unknown provider %q in SMS config, must be %s or %s", c.Provider, SMSProviderTwilio, SMSProviderGeneric)
+	}
+	return nil
+}
+
+// Kafka producer acknowledgement modes.
+const (
+	KafkaAcksAll    = "all"
+	KafkaAcksLeader = "leader"
+	KafkaAcksNone   = "none"
+)
+
+// KafkaSASLConfig configures SASL authentication against the Kafka brokers.
+type KafkaSASLConfig struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	Mechanism string `yaml:"mechanism" json:"mechanism"`
+	Username  string `yaml:"username" json:"username"`
+	Password  Secret `yaml:"password" json:"password"`
+}
+
+// KafkaConfig configures notifications produced to a Kafka topic.
+type KafkaConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	Brokers []string `yaml:"brokers,omitempty" json:"brokers,omitempty"`
+	Topic   string   `yaml:"topic,omitempty" json:"topic,omitempty"`
+	// Message is the template of the record value. If empty, the record value
+	// is the JSON-encoded webhook message.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	// MaxAlerts is the maximum number of alerts included in the webhook
+	// message. 0 means all alerts are included.
+	MaxAlerts uint64 `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
+
+	Acks        string `yaml:"acks,omitempty" json:"acks,omitempty"`
+	Compression string `yaml:"compression,omitempty" json:"compression,omitempty"`
+	// Timeout bounds the time spent producing a single notification.
+	Timeout duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// TLSConfig enables TLS towards the brokers when set.
+	TLSConfig *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	SASL      *KafkaSASLConfig     `yaml:"sasl,omitempty" json:"sasl,omitempty"`
+}
+
+// UnmarshalJSON implements JSON interface and includes default params,
+// the interface has been added to inject default params when
+// the config is created through API
+func (c *KafkaConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultKafkaConfig
+	type plain KafkaConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (KafkaConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *KafkaConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultKafkaConfig
+	type plain KafkaConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *KafkaConfig) Validate() error {
+	if len(c.Brokers) == 0 {
+		return fmt.Errorf("missing brokers in Kafka config")
+	}
+	if c.Topic == "" {
+		return fmt.Errorf("missing topic in Kafka config")
+	}
+	switch c.Acks {
+	case KafkaAcksAll, KafkaAcksLeader, KafkaAcksNone:
+	default:
+		return fmt.Errorf("unknown acks %q in Kafka config, must be one of %s, %s or %s", c.Acks, KafkaAcksAll, KafkaAcksLeader, KafkaAcksNone)
+	}
+	switch c.Compression {
+	case "none", "gzip", "snappy", "lz4", "zstd":
+	default:
+		return fmt.Errorf("unknown compression %q in Kafka config, must be one of none, gzip, snappy, lz4 or zstd", c.Compression)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in Kafka config")
+	}
+	if c.SASL != nil {
+		switch c.SASL.Mechanism {
+		case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+		default:
+			return fmt.Errorf("unknown SASL mechanism %q in Kafka config, must be one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", c.SASL.Mechanism)
+		}
+		if c.SASL.Username == "" || c.SASL.Password == "" {
+			return fmt.Errorf("missing SASL username or password in Kafka config")
+		}
+	}
+	return nil
+}
+
+// NATSConfig configures notifications published to a NATS subject.
+type NATSConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	Servers []string `yaml:"servers,omitempty" json:"servers,omitempty"`
+	Subject string   `yaml:"subject,omitempty" json:"subject,omitempty"`
+	// Message is the template of the published payload. If empty, the
+	// payload is the JSON-encoded webhook message.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	// MaxAlerts is the maximum number of alerts included in the webhook
+	// message. 0 means all alerts are included.
+	MaxAlerts uint64 `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
+	// Timeout bounds the time spent publishing a single notification.
+	Timeout duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// Authentication, at most one of user/password, token or a credentials
+	// file can be set.
+	Username        string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password        Secret `yaml:"password,omitempty" json:"password,omitempty"`
+	Token           Secret `yaml:"token,omitempty" json:"token,omitempty"`
+	CredentialsFile string `yaml:"credentials_file,omitempty" json:"credentials_file,omitempty"`
+
+	// TLSConfig enables TLS towards the servers when set.
+	TLSConfig *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+}
+
+// UnmarshalJSON implements JSON interface and includes default params,
+// the interface has been added to inject default params when
+// the config is created through API
+func (c *NATSConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultNATSConfig
+	type plain NATSConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (NATSConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *NATSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultNATSConfig
+	type plain NATSConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *NATSConfig) Validate() error {
+	if len(c.Servers) == 0 {
+		return fmt.Errorf("missing servers in NATS config")
+	}
+	if c.Subject == "" {
+		return fmt.Errorf("missing subject in NATS config")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in NATS config")
+	}
+	if (c.Password != "" && c.Username == "") || (c.Username != "" && c.Password == "") {
+		return fmt.Errorf("username and password must be set together in NATS config")
+	}
+	n := 0
+	for _, set := range []bool{c.Username != "", c.Token != "", c.CredentialsFile != ""} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return fmt.Errorf("at most one of username & password, token & credentials_file must be configured in NATS config")
+	}
+	return nil
+}
+
+// MQTTConfig configures notifications published to an MQTT topic.
+type MQTTConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Broker is the broker URL, e.g. tcp://localhost:1883 or ssl://localhost:8883.
+	Broker   string `yaml:"broker,omitempty" json:"broker,omitempty"`
+	ClientID string `yaml:"client_id,omitempty" json:"client_id,omitempty"`
+	Topic    string `yaml:"topic,omitempty" json:"topic,omitempty"`
+	QoS      byte   `yaml:"qos" json:"qos"`
+	Retain   bool   `yaml:"retain" json:"retain"`
+	// Message is the template of the published payload. If empty, the
+	// payload is the JSON-encoded webhook message.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	// MaxAlerts is the maximum number of alerts included in the webhook
+	// message. 0 means all alerts are included.
+	MaxAlerts uint64 `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
+	// Timeout bounds the time spent publishing a single notification.
+	Timeout duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password Secret `yaml:"password,omitempty" json:"password,omitempty"`
+
+	// TLSConfig configures the connection to ssl:// and wss:// brokers.
+	TLSConfig *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+}
+
+// UnmarshalJSON implements JSON interface and includes default params,
+// the interface has been added to inject default params when
+// the config is created through API
+func (c *MQTTConfig) UnmarshalJSON(data []byte) error {
+	s := DefaultMQTTConfig
+	type plain MQTTConfig
+	sp := (plain)(s)
+	if err := json.Unmarshal(data, &sp); err != nil {
+		return err
+	}
+	*c = (MQTTConfig)(sp)
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *MQTTConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultMQTTConfig
+	type plain MQTTConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *MQTTConfig) Validate() error {
+	if c.Broker == "" {
+		return fmt.Errorf("missing broker in MQTT config")
+	}
+	if c.Topic == "" {
+		return fmt.Errorf("missing topic in MQTT config")
+	}
+	if c.QoS > 2 {
+		return fmt.Errorf("qos must be 0, 1 or 2 in MQTT config")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in MQTT config")
+	}
+	return nil
+}
+
+// ExecConfig configures notifications delivered by running a local command.
+type ExecConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Command is the path of the executable. It is run directly, not through
+	// a shell.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+	// Args are templated arguments passed to the command.
+	Args       []string          `yaml:"args,omitempty" json:"args,omitempty"`
+	WorkingDir string            `yaml:"working_dir,omitempty" json:"working_dir,omitempty"`
+	Env        map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// MaxAlerts is the maximum number of alerts included in the message
+	// written to stdin. 0 means all alerts are included.
+	MaxAlerts uint64 `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
+
+	// Timeout is the time after which the command is killed.
+	Timeout duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// MaxConcurrency is the maximum number of concurrently running commands
+	// for the integration.
+	MaxConcurrency int `yaml:"max_concurrency,omitempty" json:"max_concurrency,omitempty"`
+	// RetryExitCodes are the exit codes signalling a temporary failure. Other
+	// non-zero exit codes are not retried.
+	RetryExitCodes []int `yaml:"retry_exit_codes,omitempty" json:"retry_exit_codes,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Receivers created
+// through the API are decoded from JSON; exec configs are rejected there as
+// they run arbitrary commands on the Alertmanager host.
+func (c *ExecConfig) UnmarshalJSON([]byte) error {
+	return fmt.Errorf("exec configs can only be set in the configuration file")
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ExecConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultExecConfig
+	type plain ExecConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func (c *ExecConfig) Validate() error {
+	if c.Command == "" {
+		return fmt.Errorf("missing command in exec config")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive in exec config")
+	}
+	if c.MaxConcurrency <= 0 {
+		return fmt.Errorf("max_concurrency must be positive in exec config")
+	}
+	for _, code := range c.RetryExitCodes {
+		if code <= 0 || code > 255 {
+			return fmt.Errorf("invalid retry exit code %d in exec config", code)
+		}
+	}
+	return nil
+}
+
+// PluginConfig configures notifications delivered by a notifier plugin.
+type PluginConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Plugin is the name of a plugin declared in the top-level plugins
+	// section.
+	Plugin string `yaml:"plugin" json:"plugin"`
+	// Config is passed to the plugin as a JSON object. Its schema is
+	// defined by the plugin, which validates it.
+	Config map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *PluginConfig) UnmarshalJSON(data []byte) error {
+	type plain PluginConfig
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *PluginConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain PluginConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	// The YAML decoder produces maps with interface{} keys for nested
+	// objects which can't be encoded as JSON.
+	for k, v := range c.Config {
+		c.Config[k] = stringKeys(v)
+	}
+	return c.Validate()
+}
+
+func (c *PluginConfig) Validate() error {
+	if c.Plugin == "" {
+		return fmt.Errorf("missing plugin in plugin config")
+	}
+	return nil
+}
+
+// stringKeys recursively converts maps decoded from YAML to maps with string
+// keys.
+func stringKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = stringKeys(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
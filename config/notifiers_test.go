@@ -14,8 +14,10 @@
 package config
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -608,3 +610,386 @@ func TestWeChatTypeMatcher(t *testing.T) {
 func newBoolPointer(b bool) *bool {
 	return &b
 }
+
+func TestServiceNowCredentialsArePresent(t *testing.T) {
+	in := `
+api_url: 'https://example.service-now.com'
+username: 'admin'
+`
+	var cfg ServiceNowConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+
+	expected := "missing username or password in ServiceNow config"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%v", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%v\ngot:\n%v", expected, err.Error())
+	}
+}
+
+func TestServiceNowDefaults(t *testing.T) {
+	in := `
+api_url: 'https://example.service-now.com'
+username: 'admin'
+password: 'secret'
+`
+	var cfg ServiceNowConfig
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Table != "incident" {
+		t.Errorf("expected default table %q, got %q", "incident", cfg.Table)
+	}
+	if cfg.APIURL.Path != "/" {
+		t.Errorf("expected api_url path to end with a slash, got %q", cfg.APIURL.Path)
+	}
+	if got := cfg.SeverityMapping["critical"]; got != (ServiceNowImpactUrgency{Impact: "1", Urgency: "1"}) {
+		t.Errorf("unexpected default mapping for critical: %v", got)
+	}
+}
+
+func TestSMSProviderValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in: `
+account_sid: 'AC123'
+auth_token: 'secret'
+from: '+15550000000'
+`,
+			expected: "missing to in SMS config",
+		},
+		{
+			in: `
+to: ['+15551111111']
+from: '+15550000000'
+`,
+			expected: "missing account_sid or auth_token in SMS config",
+		},
+		{
+			in: `
+provider: generic
+to: ['+15551111111']
+`,
+			expected: "missing api_url in SMS config",
+		},
+		{
+			in: `
+provider: carrier-pigeon
+to: ['+15551111111']
+`,
+			expected: "unknown provider \"carrier-pigeon\" in SMS config, must be twilio or generic",
+		},
+	} {
+		var cfg SMSConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestKafkaValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in: `
+topic: alerts
+`,
+			expected: "missing brokers in Kafka config",
+		},
+		{
+			in: `
+brokers: ['localhost:9092']
+`,
+			expected: "missing topic in Kafka config",
+		},
+		{
+			in: `
+brokers: ['localhost:9092']
+topic: alerts
+acks: some
+`,
+			expected: "unknown acks \"some\" in Kafka config, must be one of all, leader or none",
+		},
+		{
+			in: `
+brokers: ['localhost:9092']
+topic: alerts
+compression: brotli
+`,
+			expected: "unknown compression \"brotli\" in Kafka config, must be one of none, gzip, snappy, lz4 or zstd",
+		},
+		{
+			in: `
+brokers: ['localhost:9092']
+topic: alerts
+sasl:
+  mechanism: GSSAPI
+  username: alertmanager
+  password: secret
+`,
+			expected: "unknown SASL mechanism \"GSSAPI\" in Kafka config, must be one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512",
+		},
+		{
+			in: `
+brokers: ['localhost:9092']
+topic: alerts
+sasl:
+  mechanism: PLAIN
+  username: alertmanager
+`,
+			expected: "missing SASL username or password in Kafka config",
+		},
+	} {
+		var cfg KafkaConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestNATSAuthValidation(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in: `
+subject: alerts
+`,
+			expected: "missing servers in NATS config",
+		},
+		{
+			in: `
+servers: ['nats://localhost:4222']
+subject: alerts
+username: alertmanager
+`,
+			expected: "username and password must be set together in NATS config",
+		},
+		{
+			in: `
+servers: ['nats://localhost:4222']
+subject: alerts
+token: secret
+credentials_file: /etc/nats/alertmanager.creds
+`,
+			expected: "at most one of username & password, token & credentials_file must be configured in NATS config",
+		},
+	} {
+		var cfg NATSConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+		if err == nil {
+			t.Fatalf("no error returned, expected:\n%v", tc.expected)
+		}
+		if err.Error() != tc.expected {
+			t.Errorf("\nexpected:\n%v\ngot:\n%v", tc.expected, err.Error())
+		}
+	}
+}
+
+func TestMQTTDefaults(t *testing.T) {
+	in := `
+broker: tcp://localhost:1883
+topic: alerts
+`
+	var cfg MQTTConfig
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.QoS != 1 {
+		t.Errorf("expected default qos 1, got %d", cfg.QoS)
+	}
+
+	in += "qos: 3\n"
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != "qos must be 0, 1 or 2 in MQTT config" {
+		t.Errorf("expected qos validation error, got %v", err)
+	}
+}
+
+func TestExecConfig(t *testing.T) {
+	in := `
+command: /usr/local/bin/remediate
+retry_exit_codes: [256]
+`
+	var cfg ExecConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != "invalid retry exit code 256 in exec config" {
+		t.Errorf("expected exit code validation error, got %v", err)
+	}
+
+	// Exec configs run commands on the host and are not accepted from the API.
+	err = json.Unmarshal([]byte(`{"command": "/usr/local/bin/remediate"}`), &cfg)
+	if err == nil || err.Error() != "exec configs can only be set in the configuration file" {
+		t.Errorf("expected JSON unmarshal error, got %v", err)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	in := `
+url: https://example.com/hook
+signature:
+  previous_secret: old
+`
+	var cfg WebhookConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != "missing signature secret in webhook config" {
+		t.Errorf("expected missing secret error, got %v", err)
+	}
+
+	in = `
+url: https://example.com/hook
+headers:
+  x-alertmanager-timestamp: "0"
+signature:
+  secret: new
+`
+	err = yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != `header "x-alertmanager-timestamp" conflicts with the signature headers in webhook config` {
+		t.Errorf("expected header conflict error, got %v", err)
+	}
+
+	in = `
+url: https://example.com/hook
+signature:
+  secret: new
+`
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Signature.Header != "X-Alertmanager-Signature" || cfg.Signature.TimestampHeader != "X-Alertmanager-Timestamp" {
+		t.Errorf("unexpected signature headers: %q, %q", cfg.Signature.Header, cfg.Signature.TimestampHeader)
+	}
+}
+
+func TestWebhookCloudEvents(t *testing.T) {
+	in := `
+url: https://example.com/hook
+cloudevents:
+  mode: streaming
+`
+	var cfg WebhookConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != `unknown CloudEvents mode "streaming" in webhook config` {
+		t.Errorf("expected unknown mode error, got %v", err)
+	}
+
+	in = `
+url: https://example.com/hook
+body: '{{ .Status }}'
+cloudevents: {}
+`
+	err = yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != "body and cloudevents are mutually exclusive in webhook config" {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+
+	in = `
+url: https://example.com/hook
+cloudevents: {}
+`
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.CloudEvents.Mode != CloudEventsStructured {
+		t.Errorf("expected default mode %q, got %q", CloudEventsStructured, cfg.CloudEvents.Mode)
+	}
+}
+
+func TestRetryConfig(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in: `
+url: https://example.com/hook
+retry_policy:
+  max_attempts: 3
+  initial_backoff: 1s
+  max_backoff: 30s
+  attempt_timeout: 5s
+  retry_on: [serverError, other]
+`,
+		},
+		{
+			in: `
+url: https://example.com/hook
+retry_policy:
+  max_attempts: -1
+`,
+			err: "max_attempts must not be negative in retry config",
+		},
+		{
+			in: `
+url: https://example.com/hook
+retry_policy:
+  initial_backoff: 1m
+  max_backoff: 1s
+`,
+			err: "initial_backoff must not be greater than max_backoff in retry config",
+		},
+		{
+			in: `
+url: https://example.com/hook
+retry_policy:
+  retry_on: [timeout]
+`,
+			err: `unknown failure reason "timeout" in retry config`,
+		},
+	} {
+		var cfg WebhookConfig
+		err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+		if tc.err == "" {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.RetryConfig().MaxAttempts != 3 || time.Duration(cfg.RetryConfig().AttemptTimeout) != 5*time.Second {
+				t.Errorf("unexpected retry config: %+v", cfg.RetryConfig())
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("expected error %q, got %v", tc.err, err)
+		}
+	}
+}
+
+func TestCircuitBreakerConfig(t *testing.T) {
+	in := `
+url: https://example.com/hook
+circuit_breaker: {}
+`
+	var cfg WebhookConfig
+	if err := yaml.UnmarshalStrict([]byte(in), &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *cfg.CircuitBreakerConfig() != DefaultCircuitBreakerConfig {
+		t.Errorf("expected default circuit breaker config, got %+v", cfg.CircuitBreakerConfig())
+	}
+
+	in = `
+url: https://example.com/hook
+circuit_breaker:
+  failure_threshold: 0
+`
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	if err == nil || err.Error() != "failure_threshold must be positive in circuit breaker config" {
+		t.Errorf("expected failure threshold error, got %v", err)
+	}
+}
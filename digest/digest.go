@@ -0,0 +1,363 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package digest accumulates the alerts of digest receivers and notifies them
+// of a summary of their alerts on a schedule instead of in real time.
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/snapshot"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Window is the summary of the alerts of a receiver between two digests.
+type Window struct {
+	Receiver string
+	Start    time.Time
+	End      time.Time
+	// Fired are the alerts that started firing during the window.
+	Fired []*types.Alert
+	// Resolved are the alerts that were resolved during the window.
+	Resolved []*types.Alert
+	// Open are the alerts still firing at the end of the window, and notified
+	// to the receiver during the window.
+	Open []*types.Alert
+}
+
+// Alerts returns the alerts notified with the digest: the open alerts and the
+// resolved ones.
+func (w *Window) Alerts() []*types.Alert {
+	alerts := make([]*types.Alert, 0, len(w.Open)+len(w.Resolved))
+	alerts = append(alerts, w.Open...)
+	return append(alerts, w.Resolved...)
+}
+
+// NotifyFunc notifies the receiver of the window of its digest.
+type NotifyFunc func(ctx context.Context, w *Window) error
+
+// Options configures the digests.
+type Options struct {
+	// SnapshotFile is the file the accumulated alerts are persisted to. If
+	// empty, they are only kept in memory.
+	SnapshotFile string
+	// Timeout is the timeout of the notification of a digest.
+	Timeout time.Duration
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	alerts        *prometheus.GaugeVec
+	sentTotal     *prometheus.CounterVec
+	failuresTotal *prometheus.CounterVec
+}
+
+func newMetrics(r prometheus.Registerer) *metrics {
+	m := &metrics{
+		alerts: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alertmanager_digest_alerts",
+			Help: "Number of alerts accumulated for the next digest of receivers.",
+		}, []string{"receiver"}),
+		sentTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_digests_total",
+			Help: "Number of digests notified to receivers.",
+		}, []string{"receiver"}),
+		failuresTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_digests_failed_total",
+			Help: "Number of digests that failed to be notified to receivers.",
+		}, []string{"receiver"}),
+	}
+	if r != nil {
+		r.MustRegister(m.alerts, m.sentTotal, m.failuresTotal)
+	}
+	return m
+}
+
+// pending holds the alerts of a receiver since its last digest.
+type pending struct {
+	Receiver string         `json:"receiver"`
+	Start    time.Time      `json:"start"`
+	Alerts   []*types.Alert `json:"alerts"`
+	// SeenAt is the last time each alert was added.
+	SeenAt map[model.Fingerprint]time.Time `json:"seenAt"`
+
+	alerts map[model.Fingerprint]*types.Alert
+}
+
+// Digests accumulates the alerts of the digest receivers.
+type Digests struct {
+	opts    Options
+	logger  log.Logger
+	metrics *metrics
+	now     func() time.Time
+
+	mtx       sync.Mutex
+	schedules map[string]*config.DigestConfig
+	pending   map[string]*pending
+	// dirty is set when the state changed since it was last persisted.
+	dirty bool
+}
+
+// New returns new digests, loading the accumulated alerts from the snapshot
+// file if it exists.
+func New(o Options) (*Digests, error) {
+	if o.Timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	d := &Digests{
+		opts:      o,
+		logger:    o.Logger,
+		metrics:   newMetrics(o.Metrics),
+		now:       time.Now,
+		schedules: map[string]*config.DigestConfig{},
+		pending:   map[string]*pending{},
+	}
+	if o.SnapshotFile == "" {
+		return d, nil
+	}
+
+	b, err := os.ReadFile(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, err
+	}
+	var ps []*pending
+	if err := json.Unmarshal(b, &ps); err != nil {
+		return nil, errors.Wrap(err, "failed to load digest snapshot")
+	}
+	for _, p := range ps {
+		p.alerts = make(map[model.Fingerprint]*types.Alert, len(p.Alerts))
+		for _, a := range p.Alerts {
+			p.alerts[a.Fingerprint()] = a
+		}
+		p.Alerts = nil
+		if p.SeenAt == nil {
+			p.SeenAt = map[model.Fingerprint]time.Time{}
+		}
+		d.pending[p.Receiver] = p
+	}
+	return d, nil
+}
+
+// SetSchedules sets the schedules of the digest receivers. The alerts
+// accumulated for receivers without a schedule are dropped.
+func (d *Digests) SetSchedules(schedules map[string]*config.DigestConfig) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.schedules = schedules
+	for name := range d.pending {
+		if _, ok := schedules[name]; !ok {
+			delete(d.pending, name)
+			d.metrics.alerts.DeleteLabelValues(name)
+			d.dirty = true
+		}
+	}
+}
+
+// Scheduled returns whether the receiver is a digest receiver.
+func (d *Digests) Scheduled(receiver string) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	_, ok := d.schedules[receiver]
+	return ok
+}
+
+// Add records the alerts for the next digest of the receiver. The alerts which
+// aren't added again during a window, for instance because they were
+// silenced, are dropped when the window closes.
+func (d *Digests) Add(receiver string, alerts ...*types.Alert) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	schedule, ok := d.schedules[receiver]
+	if !ok {
+		return errors.Errorf("receiver %q has no digest schedule", receiver)
+	}
+	p, ok := d.pending[receiver]
+	if !ok {
+		p = &pending{
+			Receiver: receiver,
+			Start:    schedule.Previous(d.now()),
+			SeenAt:   map[model.Fingerprint]time.Time{},
+			alerts:   map[model.Fingerprint]*types.Alert{},
+		}
+		d.pending[receiver] = p
+	}
+	now := d.now()
+	for _, a := range alerts {
+		fp := a.Fingerprint()
+		p.alerts[fp] = a
+		p.SeenAt[fp] = now
+	}
+	d.metrics.alerts.WithLabelValues(receiver).Set(float64(len(p.alerts)))
+	d.dirty = true
+	return nil
+}
+
+// Run notifies the digests that are due every interval, until stopc is
+// closed. The accumulated alerts are persisted after each check that changed
+// them.
+func (d *Digests) Run(interval time.Duration, stopc <-chan struct{}, notify NotifyFunc) {
+	// Once stopc is closed, Flush returns right away and the alerts are
+	// persisted one last time.
+	snapshot.Maintain(interval, stopc, func() {
+		d.Flush(stopc, notify)
+		d.persist()
+	})
+}
+
+// Flush notifies the receivers of their digests that are due. The window of
+// a digest is only closed once it is notified, the failed digests are
+// notified again at the next flush.
+func (d *Digests) Flush(stopc <-chan struct{}, notify NotifyFunc) {
+	for _, w := range d.due(d.now()) {
+		select {
+		case <-stopc:
+			return
+		default:
+		}
+		if len(w.Open) == 0 && len(w.Resolved) == 0 {
+			d.close(w)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), d.opts.Timeout)
+		err := notify(ctx, w)
+		cancel()
+		if err != nil {
+			d.metrics.failuresTotal.WithLabelValues(w.Receiver).Inc()
+			level.Error(d.logger).Log("msg", "Failed to notify the digest", "receiver", w.Receiver, "err", err)
+			continue
+		}
+		d.metrics.sentTotal.WithLabelValues(w.Receiver).Inc()
+		d.close(w)
+	}
+}
+
+// due returns the windows of the receivers whose digest is due at now.
+func (d *Digests) due(now time.Time) []*Window {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	var windows []*Window
+	for name, p := range d.pending {
+		schedule, ok := d.schedules[name]
+		if !ok {
+			continue
+		}
+		end := schedule.Previous(now)
+		if !end.After(p.Start) {
+			continue
+		}
+		w := &Window{Receiver: name, Start: p.Start, End: end}
+		for fp, a := range p.alerts {
+			if !a.StartsAt.Before(w.Start) && a.StartsAt.Before(w.End) {
+				w.Fired = append(w.Fired, a)
+			}
+			if a.ResolvedAt(w.End) {
+				if !a.EndsAt.Before(w.Start) {
+					w.Resolved = append(w.Resolved, a)
+				}
+				continue
+			}
+			if !p.SeenAt[fp].Before(w.Start) {
+				w.Open = append(w.Open, a)
+			}
+		}
+		sortAlerts(w.Fired)
+		sortAlerts(w.Resolved)
+		sortAlerts(w.Open)
+		windows = append(windows, w)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Receiver < windows[j].Receiver
+	})
+	return windows
+}
+
+// close starts the next window of the receiver of w, dropping the alerts
+// resolved by the end of w and those not added during w.
+func (d *Digests) close(w *Window) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	p, ok := d.pending[w.Receiver]
+	if !ok || !p.Start.Equal(w.Start) {
+		return
+	}
+	for fp, a := range p.alerts {
+		if a.ResolvedAt(w.End) || p.SeenAt[fp].Before(w.Start) {
+			delete(p.alerts, fp)
+			delete(p.SeenAt, fp)
+		}
+	}
+	p.Start = w.End
+	d.metrics.alerts.WithLabelValues(w.Receiver).Set(float64(len(p.alerts)))
+	d.dirty = true
+}
+
+// sortAlerts sorts the alerts by start time, and then by fingerprint.
+func sortAlerts(alerts []*types.Alert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].StartsAt.Equal(alerts[j].StartsAt) {
+			return alerts[i].StartsAt.Before(alerts[j].StartsAt)
+		}
+		return alerts[i].Fingerprint() < alerts[j].Fingerprint()
+	})
+}
+
+// persist writes the accumulated alerts to the snapshot file if they changed.
+func (d *Digests) persist() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.dirty || d.opts.SnapshotFile == "" {
+		return
+	}
+	ps := make([]*pending, 0, len(d.pending))
+	for _, p := range d.pending {
+		c := *p
+		c.Alerts = make([]*types.Alert, 0, len(p.alerts))
+		for _, a := range p.alerts {
+			c.Alerts = append(c.Alerts, a)
+		}
+		ps = append(ps, &c)
+	}
+	if err := snapshot.WriteJSON(d.opts.SnapshotFile, ps); err != nil {
+		level.Error(d.logger).Log("msg", "Failed to persist the digests", "err", err)
+		return
+	}
+	d.dirty = false
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestDigests(t *testing.T, snapshot string, now *time.Time) *Digests {
+	d, err := New(Options{
+		SnapshotFile: snapshot,
+		Timeout:      time.Second,
+		Metrics:      prometheus.NewRegistry(),
+	})
+	require.NoError(t, err)
+	d.now = func() time.Time { return *now }
+	hourly := &config.DigestConfig{Interval: model.Duration(time.Hour)}
+	require.NoError(t, hourly.Validate())
+	d.SetSchedules(map[string]*config.DigestConfig{"digest": hourly})
+	return d
+}
+
+func newAlert(name string, startsAt, endsAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+		},
+	}
+}
+
+func TestDigestsWindows(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)
+	d := newTestDigests(t, "", &now)
+
+	require.True(t, d.Scheduled("digest"))
+	require.False(t, d.Scheduled("team-X"))
+	require.Error(t, d.Add("team-X", newAlert("Foo", now, now.Add(time.Hour))))
+
+	var (
+		before   = newAlert("Before", now.Add(-2*time.Hour), now.Add(5*time.Hour))
+		fired    = newAlert("Fired", now, now.Add(5*time.Hour))
+		resolved = newAlert("Resolved", now.Add(-2*time.Hour), now.Add(10*time.Minute))
+	)
+	require.NoError(t, d.Add("digest", before, fired, resolved))
+
+	var windows []*Window
+	notify := func(ctx context.Context, w *Window) error {
+		windows = append(windows, w)
+		return nil
+	}
+
+	// Nothing is due before the end of the window.
+	d.Flush(nil, notify)
+	require.Empty(t, windows)
+
+	now = time.Date(2026, 1, 1, 11, 0, 30, 0, time.UTC)
+	d.Flush(nil, notify)
+	require.Len(t, windows, 1)
+	w := windows[0]
+	require.Equal(t, "digest", w.Receiver)
+	require.Equal(t, time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), w.Start)
+	require.Equal(t, time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC), w.End)
+	require.Equal(t, []*types.Alert{fired}, w.Fired)
+	require.Equal(t, []*types.Alert{resolved}, w.Resolved)
+	require.Equal(t, []*types.Alert{before, fired}, w.Open)
+	require.Equal(t, 1.0, testutil.ToFloat64(d.metrics.sentTotal.WithLabelValues("digest")))
+	require.Equal(t, 2.0, testutil.ToFloat64(d.metrics.alerts.WithLabelValues("digest")))
+
+	// The resolved alerts are dropped and the open alerts are reported until
+	// they are resolved, as long as they are added again.
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, d.Add("digest", before, fired))
+	now = now.Add(30 * time.Minute)
+	d.Flush(nil, notify)
+	require.Len(t, windows, 2)
+	w = windows[1]
+	require.Empty(t, w.Fired)
+	require.Empty(t, w.Resolved)
+	require.Equal(t, []*types.Alert{before, fired}, w.Open)
+
+	// The alerts which aren't added again, for instance because they were
+	// silenced, are dropped.
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, d.Add("digest", fired))
+	now = now.Add(30 * time.Minute)
+	d.Flush(nil, notify)
+	require.Len(t, windows, 3)
+	require.Equal(t, []*types.Alert{fired}, windows[2].Open)
+	require.Equal(t, 1.0, testutil.ToFloat64(d.metrics.alerts.WithLabelValues("digest")))
+
+	// Failed notifications are counted and the window is notified again at
+	// the next flush.
+	now = now.Add(30 * time.Minute)
+	fired.EndsAt = now.Add(-time.Minute)
+	require.NoError(t, d.Add("digest", fired))
+	now = now.Add(30 * time.Minute)
+	d.Flush(nil, func(ctx context.Context, w *Window) error {
+		return errors.New("fail")
+	})
+	require.Equal(t, 1.0, testutil.ToFloat64(d.metrics.failuresTotal.WithLabelValues("digest")))
+	require.Equal(t, 1.0, testutil.ToFloat64(d.metrics.alerts.WithLabelValues("digest")))
+	d.Flush(nil, notify)
+	require.Len(t, windows, 4)
+	w = windows[3]
+	require.Equal(t, windows[2].End, w.Start)
+	require.Equal(t, []*types.Alert{fired}, w.Resolved)
+	require.Equal(t, 0.0, testutil.ToFloat64(d.metrics.alerts.WithLabelValues("digest")))
+
+	// The alerts of receivers without a schedule are dropped.
+	d.SetSchedules(map[string]*config.DigestConfig{})
+	require.False(t, d.Scheduled("digest"))
+	require.Empty(t, d.pending)
+}
+
+func TestDigestsSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "digests")
+	now := time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)
+	d := newTestDigests(t, snapshot, &now)
+	alert := newAlert("Foo", now, now.Add(5*time.Hour))
+	require.NoError(t, d.Add("digest", alert))
+
+	stopc := make(chan struct{})
+	close(stopc)
+	d.Run(time.Hour, stopc, nil)
+
+	loaded := newTestDigests(t, snapshot, &now)
+	now = now.Add(time.Hour)
+	var windows []*Window
+	loaded.Flush(nil, func(ctx context.Context, w *Window) error {
+		windows = append(windows, w)
+		return nil
+	})
+	require.Len(t, windows, 1)
+	require.Equal(t, time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), windows[0].Start)
+	require.Len(t, windows[0].Fired, 1)
+	require.Equal(t, alert.Labels, windows[0].Fired[0].Labels)
+}
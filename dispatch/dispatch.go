@@ -15,6 +15,7 @@ package dispatch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync"
@@ -24,18 +25,30 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
-
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/escalation"
+	"github.com/prometheus/alertmanager/groupstate"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/store"
 	"github.com/prometheus/alertmanager/types"
 )
 
+var tracer = otel.Tracer("github.com/prometheus/alertmanager/dispatch")
+
 // DispatcherMetrics represents metrics associated to a dispatcher.
 type DispatcherMetrics struct {
 	aggrGroups            prometheus.Gauge
 	processingDuration    prometheus.Summary
 	aggrGroupLimitReached prometheus.Counter
+	escalations           *prometheus.CounterVec
+	aggrGroupsRestored    prometheus.Counter
+	limitedAlerts         *prometheus.CounterVec
 }
 
 // NewDispatcherMetrics returns a new registered DispatchMetrics.
@@ -59,12 +72,32 @@ func NewDispatcherMetrics(registerLimitMetrics bool, r prometheus.Registerer) *D
 				Help: "Number of times when dispatcher failed to create new aggregation group due to limit.",
 			},
 		),
+		escalations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_escalations_total",
+				Help: "Number of escalation steps reached by aggregation groups.",
+			},
+			[]string{"policy"},
+		),
+		aggrGroupsRestored: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_aggregation_groups_restored_total",
+				Help: "Number of aggregation groups which resumed their flush schedule from the persisted group state.",
+			},
+		),
+		limitedAlerts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_limited_alerts_total",
+				Help: "Number of alerts which exceeded an aggregation group limit, by limit and by what happened to them.",
+			},
+			[]string{"limit", "action"},
+		),
 	}
 
 	if r != nil {
-		r.MustRegister(m.aggrGroups, m.processingDuration)
+		r.MustRegister(m.aggrGroups, m.processingDuration, m.escalations, m.aggrGroupsRestored)
 		if registerLimitMetrics {
-			r.MustRegister(m.aggrGroupLimitReached)
+			r.MustRegister(m.aggrGroupLimitReached, m.limitedAlerts)
 		}
 	}
 
@@ -80,12 +113,17 @@ type Dispatcher struct {
 	metrics *DispatcherMetrics
 	limits  Limits
 
+	escalation *Escalation
+	// groupState is nil if the state of the groups isn't persisted.
+	groupState *groupstate.Store
+
 	marker  types.Marker
 	timeout func(time.Duration) time.Duration
 
 	mtx                sync.RWMutex
 	aggrGroupsPerRoute map[*Route]map[model.Fingerprint]*aggrGroup
 	aggrGroupsNum      int
+	limitedPerRoute    map[*Route]*routeLimited
 
 	done   chan struct{}
 	ctx    context.Context
@@ -100,9 +138,66 @@ type Limits interface {
 	// 0 or negative value = unlimited.
 	// If dispatcher hits this limit, it will not create additional groups, but will log an error instead.
 	MaxNumberOfAggregationGroups() int
+	// MaxNumberOfAlertsPerGroup returns max number of alerts that an aggregation group can have.
+	// 0 or negative value = unlimited.
+	// Updates of the alerts of a full group are still inserted.
+	MaxNumberOfAlertsPerGroup() int
+	// OverflowPolicy returns what happens to the alerts exceeding a limit,
+	// either of the dispatcher or of their route.
+	OverflowPolicy() config.OverflowPolicy
+}
+
+// NewLimits returns the limits of the configuration, which may be nil.
+func NewLimits(c *config.LimitsConfig) Limits {
+	if c == nil {
+		return nilLimits{}
+	}
+	return configLimits{c: c}
+}
+
+type configLimits struct {
+	c *config.LimitsConfig
+}
+
+func (l configLimits) MaxNumberOfAggregationGroups() int     { return l.c.MaxAggregationGroups }
+func (l configLimits) MaxNumberOfAlertsPerGroup() int        { return l.c.MaxAlertsPerGroup }
+func (l configLimits) OverflowPolicy() config.OverflowPolicy { return l.c.Overflow }
+
+// OverflowLabel is the label of the overflow group of a route, which holds
+// the alerts exceeding the limits with the overflow_group policy.
+const OverflowLabel = model.LabelName("alertmanager_overflow")
+
+var (
+	overflowLabels      = model.LabelSet{OverflowLabel: "true"}
+	overflowFingerprint = overflowLabels.Fingerprint()
+)
+
+// The limits reported in the alertmanager_dispatcher_limited_alerts_total
+// metric.
+const (
+	limitAggregationGroups = "max_aggregation_groups"
+	limitAlertsPerGroup    = "max_alerts_per_group"
+)
+
+// routeLimited counts the alerts of a route which exceeded a limit.
+type routeLimited struct {
+	dropped    uint64
+	overflowed uint64
+	last       time.Time
 }
 
-// NewDispatcher returns a new Dispatcher.
+// Escalation holds the escalation policies referenced by routes and the
+// escalation state of the aggregation groups.
+type Escalation struct {
+	Policies map[string]*config.EscalationPolicy
+	Log      *escalation.Log
+	// Acker pauses the escalation of groups whose firing alerts are all
+	// acknowledged. It may be nil.
+	Acker types.Acknowledger
+}
+
+// NewDispatcher returns a new Dispatcher. The aggregation groups resume
+// their flush schedule from the group state, which may be nil.
 func NewDispatcher(
 	ap provider.Alerts,
 	r *Route,
@@ -110,6 +205,8 @@ func NewDispatcher(
 	mk types.Marker,
 	to func(time.Duration) time.Duration,
 	lim Limits,
+	esc *Escalation,
+	gs *groupstate.Store,
 	l log.Logger,
 	m *DispatcherMetrics,
 ) *Dispatcher {
@@ -126,6 +223,9 @@ func NewDispatcher(
 		logger:  log.With(l, "component", "dispatcher"),
 		metrics: m,
 		limits:  lim,
+
+		escalation: esc,
+		groupState: gs,
 	}
 	return disp
 }
@@ -137,6 +237,7 @@ func (d *Dispatcher) Run() {
 	d.mtx.Lock()
 	d.aggrGroupsPerRoute = map[*Route]map[model.Fingerprint]*aggrGroup{}
 	d.aggrGroupsNum = 0
+	d.limitedPerRoute = map[*Route]*routeLimited{}
 	d.metrics.aggrGroups.Set(0)
 	d.ctx, d.cancel = context.WithCancel(context.Background())
 	d.mtx.Unlock()
@@ -171,10 +272,19 @@ func (d *Dispatcher) run(it provider.AlertIterator) {
 			}
 
 			now := time.Now()
-			for _, r := range d.route.MatchWithReceiver(alert.Labels, alert.Receivers) {
+			_, span := tracer.Start(d.ctx, "dispatch.route", trace.WithAttributes(
+				attribute.String("alert.name", alert.Name()),
+				attribute.String("alert.fingerprint", alert.Fingerprint().String()),
+			))
+			routes := d.route.MatchWithReceiver(alert.Labels, alert.Receivers)
+			receivers := make([]string, 0, len(routes))
+			for _, r := range routes {
 				level.Debug(d.logger).Log("msg", "Processing alert", "alert", alert, "receiver", r.RouteOpts.Receiver)
 				d.processAlert(alert, r)
+				receivers = append(receivers, r.RouteOpts.Receiver)
 			}
+			span.SetAttributes(attribute.StringSlice("receivers", receivers))
+			span.End()
 			d.metrics.processingDuration.Observe(time.Since(now).Seconds())
 
 		case <-cleanup.C:
@@ -184,6 +294,9 @@ func (d *Dispatcher) run(it provider.AlertIterator) {
 				for _, ag := range groups {
 					if ag.empty() {
 						ag.stop()
+						if d.groupState != nil {
+							d.groupState.Delete(ag.GroupKey())
+						}
 						delete(groups, ag.fingerprint())
 						d.aggrGroupsNum--
 						d.metrics.aggrGroups.Dec()
@@ -281,6 +394,169 @@ func (d *Dispatcher) Groups(routeFilter func(*Route) bool, alertFilter func(*typ
 	return groups, receivers
 }
 
+// LimitStatus reports the aggregation group limits and the alerts which
+// exceeded them.
+type LimitStatus struct {
+	MaxAggregationGroups int                 `json:"maxAggregationGroups"`
+	MaxAlertsPerGroup    int                 `json:"maxAlertsPerGroup"`
+	Overflow             string              `json:"overflow"`
+	AggregationGroups    int                 `json:"aggregationGroups"`
+	Routes               []*RouteLimitStatus `json:"routes"`
+}
+
+// RouteLimitStatus reports the limits of a route and the alerts of the route
+// which exceeded a limit since the configuration was loaded.
+type RouteLimitStatus struct {
+	Route                string `json:"route"`
+	Receiver             string `json:"receiver"`
+	MaxAggregationGroups int    `json:"maxAggregationGroups"`
+	// MaxAlertsPerGroup is the lowest of the limits of the dispatcher and
+	// of the route.
+	MaxAlertsPerGroup int `json:"maxAlertsPerGroup"`
+	AggregationGroups int `json:"aggregationGroups"`
+	// OverflowAlerts is the number of alerts in the overflow group.
+	OverflowAlerts int        `json:"overflowAlerts"`
+	Dropped        uint64     `json:"dropped"`
+	Overflowed     uint64     `json:"overflowed"`
+	LastLimitedAt  *time.Time `json:"lastLimitedAt,omitempty"`
+}
+
+// LimitStatus returns the limits of the dispatcher and of every route.
+func (d *Dispatcher) LimitStatus() *LimitStatus {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	st := &LimitStatus{
+		MaxAggregationGroups: d.limits.MaxNumberOfAggregationGroups(),
+		MaxAlertsPerGroup:    d.limits.MaxNumberOfAlertsPerGroup(),
+		Overflow:             string(d.limits.OverflowPolicy()),
+		AggregationGroups:    d.aggrGroupsNum,
+		Routes:               []*RouteLimitStatus{},
+	}
+	d.route.Walk(func(r *Route) {
+		rs := &RouteLimitStatus{
+			Route:                r.Key(),
+			Receiver:             r.RouteOpts.Receiver,
+			MaxAggregationGroups: r.RouteOpts.MaxAggregationGroups,
+			MaxAlertsPerGroup:    d.maxAlertsPerGroup(r),
+		}
+		if groups, ok := d.aggrGroupsPerRoute[r]; ok {
+			rs.AggregationGroups = routeGroupsNum(groups)
+			if ag, ok := groups[overflowFingerprint]; ok && ag.overflow {
+				rs.OverflowAlerts = ag.alerts.Len()
+			}
+		}
+		if l, ok := d.limitedPerRoute[r]; ok {
+			rs.Dropped, rs.Overflowed = l.dropped, l.overflowed
+			last := l.last
+			rs.LastLimitedAt = &last
+		}
+		st.Routes = append(st.Routes, rs)
+	})
+	return st
+}
+
+// Explanation explains how the dispatcher routes an alert.
+type Explanation struct {
+	Route  *RouteExplanation   `json:"route"`
+	Groups []*GroupExplanation `json:"groups"`
+}
+
+// GroupExplanation describes the aggregation group of a route to which an
+// alert is dispatched.
+type GroupExplanation struct {
+	Route             string         `json:"route"`
+	Receiver          string         `json:"receiver"`
+	GroupKey          string         `json:"groupKey"`
+	GroupLabels       model.LabelSet `json:"groupLabels"`
+	MuteTimeIntervals []string       `json:"muteTimeIntervals"`
+	// NextFlush is nil if the group doesn't exist.
+	NextFlush *time.Time `json:"nextFlush,omitempty"`
+}
+
+// Explain returns how the alert is routed, and the aggregation groups it is
+// dispatched to. The alert doesn't need to have been received.
+func (d *Dispatcher) Explain(alert *types.Alert) *Explanation {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	e := &Explanation{
+		Route:  d.route.ExplainWithReceiver(alert.Labels, alert.Receivers),
+		Groups: []*GroupExplanation{},
+	}
+	for _, r := range d.route.MatchWithReceiver(alert.Labels, alert.Receivers) {
+		groupLabels := getGroupLabels(alert, r)
+		ge := &GroupExplanation{
+			Route:             r.Key(),
+			Receiver:          r.RouteOpts.Receiver,
+			GroupKey:          groupKey(r.Key(), groupLabels),
+			GroupLabels:       groupLabels,
+			MuteTimeIntervals: r.RouteOpts.MuteTimeIntervals,
+		}
+		if ag, ok := d.aggrGroupsPerRoute[r][groupLabels.Fingerprint()]; ok {
+			next := ag.NextFlush()
+			ge.NextFlush = &next
+		}
+		e.Groups = append(e.Groups, ge)
+	}
+	return e
+}
+
+// GroupStatus describes an aggregation group.
+type GroupStatus struct {
+	GroupKey  string         `json:"groupKey"`
+	Route     string         `json:"route"`
+	Labels    model.LabelSet `json:"labels"`
+	RouteOpts *RouteOpts     `json:"routeOpts"`
+	Alerts    int            `json:"alerts"`
+	// Overflow is true for the overflow group of the route.
+	Overflow  bool      `json:"overflow"`
+	CreatedAt time.Time `json:"createdAt"`
+	NextFlush time.Time `json:"nextFlush"`
+	// LastFlush is nil if the group hasn't flushed.
+	LastFlush *time.Time `json:"lastFlush,omitempty"`
+}
+
+// GroupStatuses returns the status of the aggregation groups, sorted by group
+// key.
+func (d *Dispatcher) GroupStatuses() []*GroupStatus {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	statuses := make([]*GroupStatus, 0, d.aggrGroupsNum)
+	for _, groups := range d.aggrGroupsPerRoute {
+		for _, ag := range groups {
+			statuses = append(statuses, ag.status())
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].GroupKey < statuses[j].GroupKey
+	})
+	return statuses
+}
+
+// ErrGroupNotFound is returned when no aggregation group has the given key.
+var ErrGroupNotFound = errors.New("aggregation group not found")
+
+// FlushGroup flushes the aggregation group with the given key immediately,
+// instead of waiting for its group_wait or group_interval. If renotify is
+// true, the firing alerts of the group are notified even if they were
+// already, which is useful once a broken receiver is fixed.
+func (d *Dispatcher) FlushGroup(groupKey string, renotify bool) error {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	for _, groups := range d.aggrGroupsPerRoute {
+		for _, ag := range groups {
+			if ag.GroupKey() == groupKey {
+				ag.flushNow(renotify)
+				return nil
+			}
+		}
+	}
+	return ErrGroupNotFound
+}
+
 // Stop the dispatcher.
 func (d *Dispatcher) Stop() {
 	if d == nil {
@@ -321,27 +597,123 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 
 	ag, ok := routeGroups[fp]
 	if ok {
-		ag.insert(alert)
+		if !ag.insertWithin(alert, d.maxAlertsPerGroup(route)) {
+			level.Error(d.logger).Log("msg", "Too many alerts in aggregation group, cannot insert alert", "aggrGroup", ag, "limit", d.maxAlertsPerGroup(route), "alert", alert.Name())
+			d.overflow(alert, route, routeGroups, limitAlertsPerGroup)
+		}
 		return
 	}
 
-	// If the group does not exist, create it. But check the limit first.
+	// If the group does not exist, create it. But check the limits first.
 	if limit := d.limits.MaxNumberOfAggregationGroups(); limit > 0 && d.aggrGroupsNum >= limit {
 		d.metrics.aggrGroupLimitReached.Inc()
 		level.Error(d.logger).Log("msg", "Too many aggregation groups, cannot create new group for alert", "groups", d.aggrGroupsNum, "limit", limit, "alert", alert.Name())
+		d.overflow(alert, route, routeGroups, limitAggregationGroups)
+		return
+	}
+	if limit := route.RouteOpts.MaxAggregationGroups; limit > 0 && routeGroupsNum(routeGroups) >= limit {
+		d.metrics.aggrGroupLimitReached.Inc()
+		level.Error(d.logger).Log("msg", "Too many aggregation groups for route, cannot create new group for alert", "route", route.Key(), "limit", limit, "alert", alert.Name())
+		d.overflow(alert, route, routeGroups, limitAggregationGroups)
 		return
 	}
 
-	ag = newAggrGroup(d.ctx, groupLabels, route, d.timeout, d.logger)
-	routeGroups[fp] = ag
-	d.aggrGroupsNum++
-	d.metrics.aggrGroups.Inc()
+	ag = d.newAggrGroup(groupLabels, route, routeGroups)
 
 	// Insert the 1st alert in the group before starting the group's run()
 	// function, to make sure that when the run() will be executed the 1st
 	// alert is already there.
 	ag.insert(alert)
 
+	d.runAggrGroup(ag)
+}
+
+// overflow handles an alert of the route which exceeded a limit, according
+// to the overflow policy. The alert is either dropped or inserted into the
+// overflow group of the route, which is subject to the limit on the number
+// of alerts per group but not to the limits on the number of groups.
+func (d *Dispatcher) overflow(alert *types.Alert, route *Route, routeGroups map[model.Fingerprint]*aggrGroup, limit string) {
+	action := "dropped"
+	if d.limits.OverflowPolicy() == config.OverflowGroup {
+		ag, ok := routeGroups[overflowFingerprint]
+		if !ok {
+			ag = d.newAggrGroup(overflowLabels, route, routeGroups)
+			ag.overflow = true
+			ag.insert(alert)
+			d.runAggrGroup(ag)
+			action = "overflowed"
+		} else if ag.insertWithin(alert, d.maxAlertsPerGroup(route)) {
+			action = "overflowed"
+		}
+	}
+	d.metrics.limitedAlerts.WithLabelValues(limit, action).Inc()
+
+	l, ok := d.limitedPerRoute[route]
+	if !ok {
+		l = &routeLimited{}
+		d.limitedPerRoute[route] = l
+	}
+	if action == "dropped" {
+		l.dropped++
+	} else {
+		l.overflowed++
+	}
+	l.last = time.Now()
+}
+
+// maxAlertsPerGroup returns the maximum number of alerts of the groups of
+// the route, the lowest of the limits of the dispatcher and of the route.
+func (d *Dispatcher) maxAlertsPerGroup(route *Route) int {
+	global, local := d.limits.MaxNumberOfAlertsPerGroup(), route.RouteOpts.MaxAlertsPerGroup
+	if global <= 0 || (local > 0 && local < global) {
+		return local
+	}
+	return global
+}
+
+// routeGroupsNum returns the number of aggregation groups of a route, not
+// counting its overflow group.
+func routeGroupsNum(routeGroups map[model.Fingerprint]*aggrGroup) int {
+	n := len(routeGroups)
+	if ag, ok := routeGroups[overflowFingerprint]; ok && ag.overflow {
+		n--
+	}
+	return n
+}
+
+// newAggrGroup creates an aggregation group of the route. The group must be
+// started by runAggrGroup once its first alert is inserted.
+func (d *Dispatcher) newAggrGroup(groupLabels model.LabelSet, route *Route, routeGroups map[model.Fingerprint]*aggrGroup) *aggrGroup {
+	ag := newAggrGroup(d.ctx, groupLabels, route, d.timeout, d.logger)
+	if d.escalation != nil {
+		if p, ok := d.escalation.Policies[route.RouteOpts.EscalationPolicy]; ok {
+			ag.escalation = &groupEscalation{
+				policy:      p,
+				log:         d.escalation.Log,
+				acker:       d.escalation.Acker,
+				escalations: d.metrics.escalations.WithLabelValues(p.Name),
+			}
+		}
+	}
+	if d.groupState != nil {
+		ag.groupState = d.groupState
+		now := time.Now()
+		if e, ok := d.groupState.Get(ag.GroupKey()); ok {
+			ag.restore(e, now)
+			d.metrics.aggrGroupsRestored.Inc()
+			level.Debug(d.logger).Log("msg", "Restored aggregation group", "aggrGroup", ag, "next_flush", e.NextFlush)
+		} else {
+			ag.saveState(now.Add(ag.opts.GroupWait), time.Time{})
+		}
+	}
+	routeGroups[ag.fingerprint()] = ag
+	d.aggrGroupsNum++
+	d.metrics.aggrGroups.Inc()
+	return ag
+}
+
+// runAggrGroup starts the aggregation group.
+func (d *Dispatcher) runAggrGroup(ag *aggrGroup) {
 	go ag.run(func(ctx context.Context, alerts ...*types.Alert) bool {
 		_, _, err := d.stage.Exec(ctx, d.logger, alerts...)
 		if err != nil {
@@ -385,8 +757,32 @@ type aggrGroup struct {
 	next    *time.Timer
 	timeout func(time.Duration) time.Duration
 
+	// escalation is nil if the route has no escalation policy.
+	escalation *groupEscalation
+	// groupState is nil if the state of the group isn't persisted.
+	groupState *groupstate.Store
+	// overflow is true for the overflow group of the route.
+	overflow bool
+
 	mtx        sync.RWMutex
 	hasFlushed bool
+	// nextFlush is when the timer of the next flush fires.
+	nextFlush time.Time
+	// lastFlush is zero if the group hasn't flushed.
+	lastFlush time.Time
+	// renotify is true if the next flush notifies the alerts even if they
+	// were already.
+	renotify  bool
+	createdAt time.Time
+}
+
+// groupEscalation escalates an aggregation group through an escalation
+// policy.
+type groupEscalation struct {
+	policy      *config.EscalationPolicy
+	log         *escalation.Log
+	acker       types.Acknowledger
+	escalations prometheus.Counter
 }
 
 // newAggrGroup returns a new aggregation group.
@@ -408,66 +804,303 @@ func newAggrGroup(ctx context.Context, labels model.LabelSet, r *Route, to func(
 
 	// Set an initial one-time wait before flushing
 	// the first batch of notifications.
+	ag.createdAt = time.Now()
 	ag.next = time.NewTimer(ag.opts.GroupWait)
+	ag.nextFlush = ag.createdAt.Add(ag.opts.GroupWait)
 
 	return ag
 }
 
+// restore resumes the flush schedule of the group from its persisted state.
+// The wait is capped by the current group_wait or group_interval of the
+// route, which may have been lowered since.
+func (ag *aggrGroup) restore(e groupstate.Entry, now time.Time) {
+	ag.mtx.Lock()
+	defer ag.mtx.Unlock()
+
+	ag.hasFlushed = e.Flushed()
+	ag.lastFlush = e.LastFlush
+	maxWait := ag.opts.GroupWait
+	if ag.hasFlushed {
+		maxWait = ag.opts.GroupInterval
+	}
+	wait := e.NextFlush.Sub(now)
+	switch {
+	case wait < 0:
+		wait = 0
+	case wait > maxWait:
+		wait = maxWait
+	}
+	ag.resetNext(now, wait)
+}
+
+// resetNext schedules the next flush of the group in d from now. The caller
+// must hold the lock of the group.
+func (ag *aggrGroup) resetNext(now time.Time, d time.Duration) {
+	ag.next.Reset(d)
+	ag.nextFlush = now.Add(d)
+}
+
+// NextFlush returns when the group is next flushed.
+func (ag *aggrGroup) NextFlush() time.Time {
+	ag.mtx.RLock()
+	defer ag.mtx.RUnlock()
+	return ag.nextFlush
+}
+
+// flushNow flushes the group immediately, notifying the alerts again if
+// renotify is true.
+func (ag *aggrGroup) flushNow(renotify bool) {
+	ag.mtx.Lock()
+	defer ag.mtx.Unlock()
+	ag.renotify = ag.renotify || renotify
+	ag.resetNext(time.Now(), 0)
+}
+
+// status returns the status of the group.
+func (ag *aggrGroup) status() *GroupStatus {
+	ag.mtx.RLock()
+	defer ag.mtx.RUnlock()
+
+	st := &GroupStatus{
+		GroupKey:  ag.GroupKey(),
+		Route:     ag.routeKey,
+		Labels:    ag.labels,
+		RouteOpts: ag.opts,
+		Alerts:    ag.alerts.Len(),
+		Overflow:  ag.overflow,
+		CreatedAt: ag.createdAt,
+		NextFlush: ag.nextFlush,
+	}
+	if ag.hasFlushed {
+		last := ag.lastFlush
+		st.LastFlush = &last
+	}
+	return st
+}
+
+// saveState persists the flush schedule of the group, if enabled.
+func (ag *aggrGroup) saveState(next, last time.Time) {
+	if ag.groupState == nil {
+		return
+	}
+	ag.groupState.Set(groupstate.Entry{
+		GroupKey:  ag.GroupKey(),
+		RouteKey:  ag.routeKey,
+		Labels:    ag.labels,
+		NextFlush: next,
+		LastFlush: last,
+	})
+}
+
 func (ag *aggrGroup) fingerprint() model.Fingerprint {
 	return ag.labels.Fingerprint()
 }
 
 func (ag *aggrGroup) GroupKey() string {
-	return fmt.Sprintf("%s:%s", ag.routeKey, ag.labels)
+	return groupKey(ag.routeKey, ag.labels)
+}
+
+// groupKey returns the key of the aggregation group of a route with the given
+// group labels.
+func groupKey(routeKey string, groupLabels model.LabelSet) string {
+	return fmt.Sprintf("%s:%s", routeKey, groupLabels)
 }
 
 func (ag *aggrGroup) String() string {
 	return ag.GroupKey()
 }
 
+// notifyContext returns the context of the notifications sent at now.
+func (ag *aggrGroup) notifyContext(now time.Time) (context.Context, context.CancelFunc) {
+	// Give the notifications time until the next flush to
+	// finish before terminating them.
+	ctx, cancel := context.WithTimeout(ag.ctx, ag.timeout(ag.opts.GroupInterval))
+
+	// The now time we retrieve from the ticker is the only reliable
+	// point of time reference for the subsequent notification pipeline.
+	// Calculating the current time directly is prone to flaky behavior,
+	// which usually only becomes apparent in tests.
+	ctx = notify.WithNow(ctx, now)
+
+	// Populate context with information needed along the pipeline.
+	ctx = notify.WithGroupKey(ctx, ag.GroupKey())
+	ctx = notify.WithGroupLabels(ctx, ag.labels)
+	ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
+	ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
+	ctx = notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
+
+	return ctx, cancel
+}
+
+// startSpan starts a span of the notification of the group.
+func (ag *aggrGroup) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("group.key", ag.GroupKey()),
+		attribute.String("receiver", ag.opts.Receiver),
+	))
+}
+
 func (ag *aggrGroup) run(nf notifyFunc) {
 	defer close(ag.done)
 	defer ag.next.Stop()
 
+	// The escalation timer fires when the next step of the escalation
+	// policy is due.
+	var (
+		escalationTimer *time.Timer
+		escalationC     <-chan time.Time
+	)
+	scheduleEscalation := func(at, now time.Time) {
+		if escalationTimer != nil {
+			escalationTimer.Stop()
+			escalationTimer, escalationC = nil, nil
+		}
+		if at.IsZero() {
+			return
+		}
+		escalationTimer = time.NewTimer(at.Sub(now))
+		escalationC = escalationTimer.C
+	}
+	defer scheduleEscalation(time.Time{}, time.Time{})
+
 	for {
 		select {
 		case now := <-ag.next.C:
-
-			// Give the notifications time until the next flush to
-			// finish before terminating them.
-			ctx, cancel := context.WithTimeout(ag.ctx, ag.timeout(ag.opts.GroupInterval))
-
-			// The now time we retrieve from the ticker is the only reliable
-			// point of time reference for the subsequent notification pipeline.
-			// Calculating the current time directly is prone to flaky behavior,
-			// which usually only becomes apparent in tests.
-			ctx = notify.WithNow(ctx, now)
-
-			// Populate context with information needed along the pipeline.
-			ctx = notify.WithGroupKey(ctx, ag.GroupKey())
-			ctx = notify.WithGroupLabels(ctx, ag.labels)
-			ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
-			ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
-			ctx = notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
+			ctx, cancel := ag.notifyContext(now)
+			ctx, span := ag.startSpan(ctx, "dispatch.aggrGroup.flush")
 
 			// Wait the configured interval before calling flush again.
 			ag.mtx.Lock()
-			ag.next.Reset(ag.opts.GroupInterval)
+			ag.resetNext(now, ag.opts.GroupInterval)
 			ag.hasFlushed = true
+			ag.lastFlush = now
+			renotify := ag.renotify
+			ag.renotify = false
 			ag.mtx.Unlock()
+			if renotify {
+				ctx = notify.WithRenotify(ctx, true)
+			}
+			ag.saveState(now.Add(ag.opts.GroupInterval), now)
 
+			var nextStep time.Time
 			ag.flush(func(alerts ...*types.Alert) bool {
-				return nf(ctx, alerts...)
+				span.SetAttributes(attribute.Int("alerts", len(alerts)))
+				ok := nf(ctx, alerts...)
+				// The receivers of the escalation are notified along
+				// with the route's receiver so that they get the
+				// resolved alerts before these are deleted.
+				escalated, next := ag.escalate(ctx, nf, now, alerts)
+				nextStep = next
+				if !ok || !escalated {
+					span.SetStatus(codes.Error, "notification failed")
+				}
+				return ok && escalated
 			})
+			span.End()
+			scheduleEscalation(nextStep, now)
 
 			cancel()
 
+		case now := <-escalationC:
+			ctx, cancel := ag.notifyContext(now)
+			ctx, span := ag.startSpan(ctx, "dispatch.aggrGroup.escalate")
+			ok, nextStep := ag.escalate(ctx, nf, now, ag.alertSlice())
+			if !ok {
+				span.SetStatus(codes.Error, "notification failed")
+			}
+			span.End()
+			scheduleEscalation(nextStep, now)
+			cancel()
+
 		case <-ag.ctx.Done():
 			return
 		}
 	}
 }
 
+// escalate notifies the receivers of the steps of the escalation policy
+// reached by the group. It returns false if a notification failed, and when
+// the next step is due if the alerts are still firing. The escalation doesn't
+// progress while all the firing alerts are acknowledged.
+func (ag *aggrGroup) escalate(ctx context.Context, nf notifyFunc, now time.Time, alerts []*types.Alert) (bool, time.Time) {
+	esc := ag.escalation
+	if esc == nil || len(alerts) == 0 {
+		return true, time.Time{}
+	}
+
+	firing, acknowledged := false, true
+	for _, a := range alerts {
+		if a.Resolved() {
+			continue
+		}
+		firing = true
+		if esc.acker == nil {
+			acknowledged = false
+			break
+		}
+		if _, ok := esc.acker.Acknowledged(ag.GroupKey(), a.Fingerprint()); !ok {
+			acknowledged = false
+		}
+	}
+
+	steps := esc.policy.Steps
+	e, _ := esc.log.Get(ag.GroupKey())
+	// The entry of another policy is stale after a configuration change.
+	if e.Active() && e.Policy != esc.policy.Name {
+		e.StartedAt, e.Step = time.Time{}, 0
+	}
+	changed := false
+	if firing {
+		if !e.Active() {
+			e = escalation.Entry{GroupKey: e.GroupKey, Policy: esc.policy.Name, StartedAt: now}
+			changed = true
+		}
+		for !acknowledged && e.Step < len(steps) && !now.Before(e.StartedAt.Add(time.Duration(steps[e.Step].Delay))) {
+			level.Info(ag.logger).Log("msg", "Escalating", "policy", esc.policy.Name, "step", e.Step, "receiver", steps[e.Step].Receiver)
+			esc.escalations.Inc()
+			e.Step++
+			changed = true
+		}
+		// Keep the entry from expiring while the alerts are firing.
+		if now.Sub(e.UpdatedAt) > esc.log.Retention()/2 {
+			changed = true
+		}
+	}
+	if e.Step > len(steps) {
+		e.Step = len(steps)
+	}
+
+	ok := true
+	notified := map[string]struct{}{ag.opts.Receiver: {}}
+	for _, s := range steps[:e.Step] {
+		if _, dup := notified[s.Receiver]; dup {
+			continue
+		}
+		notified[s.Receiver] = struct{}{}
+		if !nf(notify.WithReceiverName(ctx, s.Receiver), alerts...) {
+			ok = false
+		}
+	}
+
+	// The escalation starts over once the alerts stopped firing and the
+	// receivers got the resolved alerts.
+	if !firing && e.Active() && ok {
+		e.StartedAt, e.Step = time.Time{}, 0
+		changed = true
+	}
+	if changed {
+		if err := esc.log.Set(e); err != nil {
+			level.Error(ag.logger).Log("msg", "Failed to update the escalation state", "err", err)
+		}
+	}
+
+	if !firing || acknowledged || e.Step >= len(steps) {
+		return ok, time.Time{}
+	}
+	return ok, e.StartedAt.Add(time.Duration(steps[e.Step].Delay))
+}
+
 func (ag *aggrGroup) stop() {
 	// Calling cancel will terminate all in-process notifications
 	// and the run() loop.
@@ -486,20 +1119,30 @@ func (ag *aggrGroup) insert(alert *types.Alert) {
 	ag.mtx.Lock()
 	defer ag.mtx.Unlock()
 	if !ag.hasFlushed && alert.StartsAt.Add(ag.opts.GroupWait).Before(time.Now()) {
-		ag.next.Reset(0)
+		ag.resetNext(time.Now(), 0)
 	}
 }
 
+// insertWithin inserts the alert into the aggregation group unless the group
+// already has limit alerts, and reports whether it was inserted. The updates
+// of the alerts of the group are always inserted. A limit of 0 or less means
+// unlimited.
+func (ag *aggrGroup) insertWithin(alert *types.Alert, limit int) bool {
+	if limit > 0 && ag.alerts.Len() >= limit {
+		if _, err := ag.alerts.Get(alert.Fingerprint()); err != nil {
+			return false
+		}
+	}
+	ag.insert(alert)
+	return true
+}
+
 func (ag *aggrGroup) empty() bool {
 	return ag.alerts.Empty()
 }
 
-// flush sends notifications for all new alerts.
-func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
-	if ag.empty() {
-		return
-	}
-
+// alertSlice returns a sorted copy of the alerts of the group.
+func (ag *aggrGroup) alertSlice() types.AlertSlice {
 	var (
 		alerts      = ag.alerts.List()
 		alertsSlice = make(types.AlertSlice, 0, len(alerts))
@@ -514,6 +1157,16 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 		alertsSlice = append(alertsSlice, &a)
 	}
 	sort.Stable(alertsSlice)
+	return alertsSlice
+}
+
+// flush sends notifications for all new alerts.
+func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
+	if ag.empty() {
+		return
+	}
+
+	alertsSlice := ag.alertSlice()
 
 	level.Debug(ag.logger).Log("msg", "flushing", "alerts", fmt.Sprintf("%v", alertsSlice), "receiver", ag.opts.Receiver)
 
@@ -542,4 +1195,6 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 
 type nilLimits struct{}
 
-func (n nilLimits) MaxNumberOfAggregationGroups() int { return 0 }
+func (n nilLimits) MaxNumberOfAggregationGroups() int     { return 0 }
+func (n nilLimits) MaxNumberOfAlertsPerGroup() int        { return 0 }
+func (n nilLimits) OverflowPolicy() config.OverflowPolicy { return config.OverflowDrop }
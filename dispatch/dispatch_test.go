@@ -29,6 +29,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/escalation"
+	"github.com/prometheus/alertmanager/groupstate"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider/mem"
 	"github.com/prometheus/alertmanager/types"
@@ -374,7 +376,7 @@ route:
 
 	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, nil, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -514,7 +516,7 @@ route:
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
 	lim := limits{groups: 6}
 	m := NewDispatcherMetrics(true, prometheus.NewRegistry())
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, lim, logger, m)
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, lim, nil, nil, logger, m)
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -637,7 +639,7 @@ func TestDispatcherRace(t *testing.T) {
 	defer alerts.Close()
 
 	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
-	dispatcher := NewDispatcher(alerts, nil, nil, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	dispatcher := NewDispatcher(alerts, nil, nil, marker, timeout, nil, nil, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
 	dispatcher.Stop()
 }
@@ -665,7 +667,7 @@ func TestDispatcherRaceOnFirstAlertNotDeliveredWhenGroupWaitIsZero(t *testing.T)
 
 	timeout := func(d time.Duration) time.Duration { return d }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
-	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, nil, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
 	defer dispatcher.Stop()
 
@@ -689,10 +691,502 @@ func TestDispatcherRaceOnFirstAlertNotDeliveredWhenGroupWaitIsZero(t *testing.T)
 	require.Equal(t, numAlerts, len(recorder.Alerts()))
 }
 
+func TestDispatcherGroupState(t *testing.T) {
+	logger := log.NewNopLogger()
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "default",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      time.Hour,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+		},
+	}
+	groupKey := func(ls model.LabelSet) string {
+		return fmt.Sprintf("%s:%s", route.Key(), ls)
+	}
+	gs, err := groupstate.New(groupstate.Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	// The group of A flushed before the restart and is due again shortly.
+	now := time.Now()
+	gs.Set(groupstate.Entry{
+		GroupKey:  groupKey(model.LabelSet{"alertname": "A"}),
+		NextFlush: now.Add(20 * time.Millisecond),
+		LastFlush: now.Add(-time.Hour + 20*time.Millisecond),
+	})
+
+	timeout := func(d time.Duration) time.Duration { return d }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, nil, gs, logger, m)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	require.NoError(t, alerts.Put(
+		newAlert(model.LabelSet{"alertname": "A"}),
+		newAlert(model.LabelSet{"alertname": "B"}),
+	))
+
+	// Only the restored group flushes, without waiting for group_wait.
+	require.Eventually(t, func() bool { return len(recorder.Alerts()) == 1 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, model.LabelValue("A"), recorder.Alerts()[0].Labels["alertname"])
+	require.Equal(t, 1.0, testutil.ToFloat64(m.aggrGroupsRestored))
+
+	// The flush is recorded in the group state.
+	require.Eventually(t, func() bool {
+		e, ok := gs.Get(groupKey(model.LabelSet{"alertname": "A"}))
+		return ok && e.LastFlush.After(now)
+	}, time.Second, 10*time.Millisecond)
+	e, _ := gs.Get(groupKey(model.LabelSet{"alertname": "A"}))
+	require.Equal(t, route.Key(), e.RouteKey)
+	require.Equal(t, model.LabelSet{"alertname": "A"}, e.Labels)
+	require.Equal(t, e.LastFlush.Add(time.Hour), e.NextFlush)
+
+	// The new group waits for group_wait.
+	e, ok := gs.Get(groupKey(model.LabelSet{"alertname": "B"}))
+	require.True(t, ok)
+	require.False(t, e.Flushed())
+	require.WithinDuration(t, now.Add(time.Hour), e.NextFlush, time.Second)
+}
+
+func TestDispatcherExplain(t *testing.T) {
+	conf, err := config.Load(`receivers:
+- name: 'prod'
+- name: 'testing'
+
+route:
+  group_by: ['alertname']
+  group_wait: 1h
+  receiver: 'prod'
+  routes:
+  - matchers: ['env="testing"']
+    receiver: 'testing'
+    mute_time_intervals: ['weekends']
+  - matchers: ['env="prod"']
+    receiver: 'prod'
+    group_by: ['alertname', 'cluster']
+
+mute_time_intervals:
+- name: 'weekends'
+  time_intervals:
+  - weekdays: ['saturday', 'sunday']`)
+	require.NoError(t, err)
+
+	logger := log.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, nil, nil, nil, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	prod := newAlert(model.LabelSet{"alertname": "A", "env": "prod", "cluster": "c1"})
+	require.NoError(t, alerts.Put(prod))
+	require.Eventually(t, func() bool {
+		groups, _ := dispatcher.Groups(func(*Route) bool { return true }, func(*types.Alert, time.Time) bool { return true })
+		return len(groups) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	e := dispatcher.Explain(prod)
+	require.True(t, e.Route.Matched)
+	require.False(t, e.Route.Selected)
+	require.Len(t, e.Route.Routes, 2)
+	require.Equal(t, []MatcherExplanation{{Matcher: `env="testing"`, Matched: false}}, e.Route.Routes[0].Matchers)
+	require.True(t, e.Route.Routes[1].Selected)
+	require.Len(t, e.Groups, 1)
+	require.Equal(t, "prod", e.Groups[0].Receiver)
+	require.Equal(t, model.LabelSet{"alertname": "A", "cluster": "c1"}, e.Groups[0].GroupLabels)
+	require.Equal(t, `{}/{env="prod"}:{alertname="A", cluster="c1"}`, e.Groups[0].GroupKey)
+	require.NotNil(t, e.Groups[0].NextFlush)
+	require.WithinDuration(t, time.Now().Add(time.Hour), *e.Groups[0].NextFlush, time.Minute)
+
+	// The alert doesn't need to have been received.
+	e = dispatcher.Explain(&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "B", "env": "testing"}}})
+	require.Len(t, e.Route.Routes, 1)
+	require.True(t, e.Route.Routes[0].Selected)
+	require.Len(t, e.Groups, 1)
+	require.Equal(t, "testing", e.Groups[0].Receiver)
+	require.Equal(t, []string{"weekends"}, e.Groups[0].MuteTimeIntervals)
+	require.Nil(t, e.Groups[0].NextFlush)
+
+	// The alert restricted to other receivers isn't dispatched.
+	e = dispatcher.Explain(&types.Alert{
+		Alert:     model.Alert{Labels: model.LabelSet{"alertname": "B", "env": "testing"}},
+		Receivers: []string{"prod"},
+	})
+	require.False(t, e.Route.Routes[0].Selected)
+	require.True(t, e.Route.Routes[0].FilteredByReceivers)
+	require.Empty(t, e.Groups)
+}
+
+func TestDispatcherFlushGroup(t *testing.T) {
+	logger := log.NewNopLogger()
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "default",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      time.Hour,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+		},
+	}
+	var (
+		mtx      sync.Mutex
+		flushes  []bool
+		flushedC = make(chan struct{}, 2)
+	)
+	stage := notify.StageFunc(func(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		renotify, _ := notify.Renotify(ctx)
+		mtx.Lock()
+		flushes = append(flushes, renotify)
+		mtx.Unlock()
+		flushedC <- struct{}{}
+		return ctx, alerts, nil
+	})
+	dispatcher := NewDispatcher(alerts, route, stage, marker, nil, nil, nil, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	// The alert starts now so that the group waits for group_wait.
+	a := newAlert(model.LabelSet{"alertname": "A"})
+	a.StartsAt = time.Now()
+	require.NoError(t, alerts.Put(a))
+	require.Eventually(t, func() bool { return len(dispatcher.GroupStatuses()) == 1 }, time.Second, 10*time.Millisecond)
+
+	st := dispatcher.GroupStatuses()[0]
+	require.Equal(t, `{}:{alertname="A"}`, st.GroupKey)
+	require.Equal(t, model.LabelSet{"alertname": "A"}, st.Labels)
+	require.Equal(t, "default", st.RouteOpts.Receiver)
+	require.Equal(t, 1, st.Alerts)
+	require.Nil(t, st.LastFlush)
+	require.WithinDuration(t, st.CreatedAt.Add(time.Hour), st.NextFlush, time.Millisecond)
+
+	require.Equal(t, ErrGroupNotFound, dispatcher.FlushGroup(`{}:{alertname="B"}`, false))
+
+	require.NoError(t, dispatcher.FlushGroup(st.GroupKey, false))
+	<-flushedC
+	require.NoError(t, dispatcher.FlushGroup(st.GroupKey, true))
+	<-flushedC
+
+	mtx.Lock()
+	require.Equal(t, []bool{false, true}, flushes)
+	mtx.Unlock()
+
+	st = dispatcher.GroupStatuses()[0]
+	require.NotNil(t, st.LastFlush)
+	require.WithinDuration(t, st.LastFlush.Add(time.Hour), st.NextFlush, time.Millisecond)
+}
+
 type limits struct {
-	groups int
+	groups   int
+	alerts   int
+	overflow config.OverflowPolicy
 }
 
 func (l limits) MaxNumberOfAggregationGroups() int {
 	return l.groups
 }
+
+func (l limits) MaxNumberOfAlertsPerGroup() int {
+	return l.alerts
+}
+
+func (l limits) OverflowPolicy() config.OverflowPolicy {
+	if l.overflow == "" {
+		return config.OverflowDrop
+	}
+	return l.overflow
+}
+
+func TestGroupsWithOverflow(t *testing.T) {
+	logger := log.NewNopLogger()
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:             "default",
+			GroupBy:              map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:            10 * time.Millisecond,
+			GroupInterval:        10 * time.Millisecond,
+			RepeatInterval:       time.Hour,
+			MaxAggregationGroups: 1,
+		},
+	}
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(true, prometheus.NewRegistry())
+	lim := limits{alerts: 2, overflow: config.OverflowGroup}
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, nil, lim, nil, nil, logger, m)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	// The alerts are put one at a time as their order matters.
+	processed := func() int {
+		n := 0
+		groups, _ := dispatcher.Groups(func(*Route) bool { return true }, func(*types.Alert, time.Time) bool { return true })
+		for _, g := range groups {
+			n += len(g.Alerts)
+		}
+		for _, rs := range dispatcher.LimitStatus().Routes {
+			n += int(rs.Dropped)
+		}
+		return n
+	}
+	for i, a := range []*types.Alert{
+		newAlert(model.LabelSet{"alertname": "A", "instance": "1"}),
+		newAlert(model.LabelSet{"alertname": "A", "instance": "2"}),
+		// The group of A is full.
+		newAlert(model.LabelSet{"alertname": "A", "instance": "3"}),
+		// The route has no room for the group of B.
+		newAlert(model.LabelSet{"alertname": "B", "instance": "1"}),
+		// The overflow group is full.
+		newAlert(model.LabelSet{"alertname": "C", "instance": "1"}),
+	} {
+		require.NoError(t, alerts.Put(a))
+		require.Eventually(t, func() bool { return processed() == i+1 }, time.Second, time.Millisecond)
+	}
+
+	overflowKey := fmt.Sprintf("%s:%s", route.Key(), model.LabelSet{OverflowLabel: "true"})
+	require.Eventually(t, func() bool { return len(recorder.Alerts()) == 4 }, time.Second, 10*time.Millisecond)
+	recorder.mtx.RLock()
+	require.Len(t, recorder.alerts, 2)
+	require.Len(t, recorder.alerts[overflowKey], 2)
+	recorder.mtx.RUnlock()
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.limitedAlerts.WithLabelValues(limitAlertsPerGroup, "overflowed")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.limitedAlerts.WithLabelValues(limitAggregationGroups, "overflowed")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.limitedAlerts.WithLabelValues(limitAggregationGroups, "dropped")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.aggrGroupLimitReached))
+
+	st := dispatcher.LimitStatus()
+	require.Equal(t, "overflow_group", st.Overflow)
+	require.Equal(t, 2, st.AggregationGroups)
+	require.Len(t, st.Routes, 1)
+	rs := st.Routes[0]
+	require.Equal(t, 1, rs.MaxAggregationGroups)
+	require.Equal(t, 2, rs.MaxAlertsPerGroup)
+	require.Equal(t, 1, rs.AggregationGroups)
+	require.Equal(t, 2, rs.OverflowAlerts)
+	require.Equal(t, uint64(1), rs.Dropped)
+	require.Equal(t, uint64(2), rs.Overflowed)
+	require.NotNil(t, rs.LastLimitedAt)
+
+	// Updates of the alerts of a full group are still inserted.
+	update := newAlert(model.LabelSet{"alertname": "A", "instance": "1"})
+	update.Annotations = model.LabelSet{"summary": "updated"}
+	require.NoError(t, alerts.Put(update))
+	require.Eventually(t, func() bool {
+		groups, _ := dispatcher.Groups(func(*Route) bool { return true }, func(*types.Alert, time.Time) bool { return true })
+		for _, g := range groups {
+			for _, a := range g.Alerts {
+				if a.Annotations["summary"] == "updated" {
+					return true
+				}
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(1), dispatcher.LimitStatus().Routes[0].Dropped)
+}
+
+func TestMaxAlertsPerGroup(t *testing.T) {
+	d := &Dispatcher{limits: limits{alerts: 10}}
+	for _, tc := range []struct {
+		route, want int
+	}{
+		{route: 0, want: 10},
+		{route: 5, want: 5},
+		{route: 20, want: 10},
+	} {
+		require.Equal(t, tc.want, d.maxAlertsPerGroup(&Route{RouteOpts: RouteOpts{MaxAlertsPerGroup: tc.route}}))
+	}
+	d.limits = nilLimits{}
+	require.Equal(t, 5, d.maxAlertsPerGroup(&Route{RouteOpts: RouteOpts{MaxAlertsPerGroup: 5}}))
+}
+
+func TestAggrGroupEscalation(t *testing.T) {
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "slack",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      10 * time.Millisecond,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+		},
+	}
+	policy := &config.EscalationPolicy{
+		Name: "on-call",
+		Steps: []config.EscalationStep{
+			{Receiver: "primary"},
+			{Receiver: "secondary", Delay: model.Duration(100 * time.Millisecond)},
+		},
+	}
+	escLog, err := escalation.New(escalation.Options{Retention: time.Hour})
+	require.NoError(t, err)
+	escalations := prometheus.NewCounter(prometheus.CounterOpts{Name: "test"})
+
+	var (
+		mtx      sync.Mutex
+		notified []string
+	)
+	ntfy := func(ctx context.Context, alerts ...*types.Alert) bool {
+		rcv, _ := notify.ReceiverName(ctx)
+		mtx.Lock()
+		notified = append(notified, rcv)
+		mtx.Unlock()
+		return true
+	}
+	// The receivers notified by a flush or an escalation are collected once
+	// they all got notified.
+	collect := func(n int) []string {
+		deadline := time.After(time.Second)
+		for {
+			mtx.Lock()
+			if len(notified) >= n {
+				res := notified[:n]
+				notified = notified[n:]
+				mtx.Unlock()
+				return res
+			}
+			mtx.Unlock()
+			select {
+			case <-deadline:
+				t.Fatalf("expected %d notifications", n)
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}
+	lset := model.LabelSet{"alertname": "HighLatency"}
+	ag := newAggrGroup(context.Background(), lset, route, nil, log.NewNopLogger())
+	ag.escalation = &groupEscalation{policy: policy, log: escLog, escalations: escalations}
+	go ag.run(ntfy)
+	defer ag.stop()
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "instance": "1"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+		UpdatedAt: time.Now(),
+	}
+	ag.insert(alert)
+
+	// The first step has no delay and is notified along with the route's
+	// receiver.
+	require.Equal(t, []string{"slack", "primary"}, collect(2))
+	// The second step is notified once its delay elapsed.
+	require.Equal(t, []string{"primary", "secondary"}, collect(2))
+
+	e, ok := escLog.Get(ag.GroupKey())
+	require.True(t, ok)
+	require.True(t, e.Active())
+	require.Equal(t, "on-call", e.Policy)
+	require.Equal(t, 2, e.Step)
+	require.Equal(t, 2.0, testutil.ToFloat64(escalations))
+
+	// The escalated receivers get the resolved alerts and the escalation
+	// starts over.
+	resolved := *alert
+	resolved.EndsAt = time.Now().Add(-time.Minute)
+	ok, next := ag.escalate(context.Background(), ntfy, time.Now(), []*types.Alert{&resolved})
+	require.True(t, ok)
+	require.True(t, next.IsZero())
+	require.Equal(t, []string{"primary", "secondary"}, collect(2))
+
+	e, ok = escLog.Get(ag.GroupKey())
+	require.True(t, ok)
+	require.False(t, e.Active())
+	require.Equal(t, 0, e.Step)
+}
+
+type fakeAcker map[model.Fingerprint]bool
+
+func (a fakeAcker) Acknowledged(_ string, fp model.Fingerprint) (*types.Acknowledgement, bool) {
+	if !a[fp] {
+		return nil, false
+	}
+	return &types.Acknowledgement{By: "me"}, true
+}
+
+func TestAggrGroupEscalationAcknowledged(t *testing.T) {
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "slack",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      time.Hour,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+		},
+	}
+	policy := &config.EscalationPolicy{
+		Name: "on-call",
+		Steps: []config.EscalationStep{
+			{Receiver: "primary"},
+			{Receiver: "secondary", Delay: model.Duration(time.Minute)},
+		},
+	}
+	escLog, err := escalation.New(escalation.Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	var notified []string
+	ntfy := func(ctx context.Context, alerts ...*types.Alert) bool {
+		rcv, _ := notify.ReceiverName(ctx)
+		notified = append(notified, rcv)
+		return true
+	}
+	acker := fakeAcker{}
+	lset := model.LabelSet{"alertname": "HighLatency"}
+	ag := newAggrGroup(context.Background(), lset, route, nil, log.NewNopLogger())
+	ag.escalation = &groupEscalation{
+		policy:      policy,
+		log:         escLog,
+		acker:       acker,
+		escalations: prometheus.NewCounter(prometheus.CounterOpts{Name: "test"}),
+	}
+
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "1"}, EndsAt: time.Now().Add(time.Hour)}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "2"}, EndsAt: time.Now().Add(time.Hour)}},
+	}
+	now := time.Now()
+	_, next := ag.escalate(context.Background(), ntfy, now, alerts)
+	require.Equal(t, []string{"primary"}, notified)
+	require.Equal(t, now.Add(time.Minute), next)
+
+	// The escalation is paused while all the firing alerts are acknowledged.
+	acker[alerts[0].Fingerprint()] = true
+	acker[alerts[1].Fingerprint()] = true
+	notified = nil
+	_, next = ag.escalate(context.Background(), ntfy, now.Add(2*time.Minute), alerts)
+	require.True(t, next.IsZero())
+	require.Equal(t, []string{"primary"}, notified)
+	e, _ := escLog.Get(ag.GroupKey())
+	require.Equal(t, 1, e.Step)
+
+	// It resumes once an alert isn't acknowledged anymore.
+	acker[alerts[1].Fingerprint()] = false
+	notified = nil
+	_, next = ag.escalate(context.Background(), ntfy, now.Add(2*time.Minute), alerts)
+	require.True(t, next.IsZero())
+	require.Equal(t, []string{"primary", "secondary"}, notified)
+	e, _ = escLog.Get(ag.GroupKey())
+	require.Equal(t, 2, e.Step)
+}
@@ -68,6 +68,10 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 		opts.Receiver = cr.Receiver
 	}
 
+	if cr.EscalationPolicy != "" {
+		opts.EscalationPolicy = cr.EscalationPolicy
+	}
+
 	if cr.GroupBy != nil {
 		opts.GroupBy = map[model.LabelName]struct{}{}
 		for _, ln := range cr.GroupBy {
@@ -89,6 +93,12 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 	if cr.RepeatInterval != nil {
 		opts.RepeatInterval = time.Duration(*cr.RepeatInterval)
 	}
+	if cr.MaxAggregationGroups != nil {
+		opts.MaxAggregationGroups = *cr.MaxAggregationGroups
+	}
+	if cr.MaxAlertsPerGroup != nil {
+		opts.MaxAlertsPerGroup = *cr.MaxAlertsPerGroup
+	}
 
 	// Build matchers.
 	var matchers labels.Matchers
@@ -193,6 +203,93 @@ func (r *Route) Match(lset model.LabelSet) []*Route {
 	return all
 }
 
+// RouteExplanation explains how a route matches a label set.
+type RouteExplanation struct {
+	Route    string               `json:"route"`
+	Receiver string               `json:"receiver"`
+	Matchers []MatcherExplanation `json:"matchers"`
+	// Matched is true if all the matchers of the route matched.
+	Matched  bool `json:"matched"`
+	Continue bool `json:"continue"`
+	// Selected is true if the route matched and none of its children did,
+	// so that the alerts are dispatched to the route.
+	Selected bool `json:"selected"`
+	// FilteredByReceivers is true if the route would be selected but its
+	// receiver isn't one of the receivers the alert is restricted to.
+	FilteredByReceivers bool `json:"filteredByReceivers"`
+	// Routes explains the child routes which were evaluated, in order. The
+	// siblings following a matching child without continue aren't evaluated.
+	Routes []*RouteExplanation `json:"routes,omitempty"`
+}
+
+// MatcherExplanation tells whether a matcher of a route matched.
+type MatcherExplanation struct {
+	Matcher string `json:"matcher"`
+	Matched bool   `json:"matched"`
+}
+
+// Explain traverses the route tree like Match and records, for each route
+// evaluated, which of its matchers matched.
+func (r *Route) Explain(lset model.LabelSet) *RouteExplanation {
+	e := &RouteExplanation{
+		Route:    r.Key(),
+		Receiver: r.RouteOpts.Receiver,
+		Matchers: make([]MatcherExplanation, 0, len(r.Matchers)),
+		Matched:  true,
+		Continue: r.Continue,
+	}
+	for _, m := range r.Matchers {
+		matched := m.Matches(string(lset[model.LabelName(m.Name)]))
+		e.Matchers = append(e.Matchers, MatcherExplanation{Matcher: m.String(), Matched: matched})
+		e.Matched = e.Matched && matched
+	}
+	if !e.Matched {
+		return e
+	}
+
+	var childMatched bool
+	for _, cr := range r.Routes {
+		ce := cr.Explain(lset)
+		e.Routes = append(e.Routes, ce)
+		if ce.Matched {
+			childMatched = true
+			if !cr.Continue {
+				break
+			}
+		}
+	}
+	e.Selected = !childMatched
+
+	return e
+}
+
+// ExplainWithReceiver explains the routing of the label set like Explain,
+// restricted to the given receivers like MatchWithReceiver. An empty list of
+// receivers doesn't restrict the routing.
+func (r *Route) ExplainWithReceiver(lset model.LabelSet, receivers []string) *RouteExplanation {
+	e := r.Explain(lset)
+	if len(receivers) == 0 {
+		return e
+	}
+
+	receiverMap := make(map[string]bool, len(receivers))
+	for _, r := range receivers {
+		receiverMap[r] = true
+	}
+	var filter func(*RouteExplanation)
+	filter = func(e *RouteExplanation) {
+		if e.Selected && !receiverMap[e.Receiver] {
+			e.Selected = false
+			e.FilteredByReceivers = true
+		}
+		for _, ce := range e.Routes {
+			filter(ce)
+		}
+	}
+	filter(e)
+	return e
+}
+
 // Key returns a key for the route. It does not uniquely identify the route in general.
 func (r *Route) Key() string {
 	b := strings.Builder{}
@@ -236,6 +333,15 @@ type RouteOpts struct {
 
 	// A list of time intervals for which the route is muted.
 	MuteTimeIntervals []string
+
+	// The escalation policy notifying further receivers while the alerts
+	// of a group keep firing.
+	EscalationPolicy string
+
+	// The maximum number of aggregation groups of the route and of alerts
+	// per group, within the limits of the dispatcher. 0 means unlimited.
+	MaxAggregationGroups int
+	MaxAlertsPerGroup    int
 }
 
 func (ro *RouteOpts) String() string {
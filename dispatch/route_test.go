@@ -383,6 +383,116 @@ routes:
 	require.Equal(t, child2.RouteOpts.GroupByAll, false)
 }
 
+func TestInheritParentLimits(t *testing.T) {
+	in := `
+routes:
+- match:
+    env: 'parent'
+  max_aggregation_groups: 100
+  max_alerts_per_group: 50
+
+  routes:
+  - match:
+      env: 'child1'
+
+  - match:
+      env: 'child2'
+    max_aggregation_groups: 0
+`
+
+	var ctree config.Route
+	if err := yaml.UnmarshalStrict([]byte(in), &ctree); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := NewRoute(&ctree, nil)
+	parent := tree.Routes[0]
+	child1 := parent.Routes[0]
+	child2 := parent.Routes[1]
+	require.Equal(t, 0, tree.RouteOpts.MaxAggregationGroups)
+	require.Equal(t, 100, parent.RouteOpts.MaxAggregationGroups)
+	require.Equal(t, 100, child1.RouteOpts.MaxAggregationGroups)
+	require.Equal(t, 50, child1.RouteOpts.MaxAlertsPerGroup)
+	require.Equal(t, 0, child2.RouteOpts.MaxAggregationGroups)
+	require.Equal(t, 50, child2.RouteOpts.MaxAlertsPerGroup)
+}
+
+func TestRouteExplain(t *testing.T) {
+	in := `
+receiver: 'notify-def'
+
+routes:
+- matchers: ['{owner="team-A"}']
+  receiver: 'notify-A'
+  continue: true
+
+  routes:
+  - matchers: ['{env="testing"}']
+    receiver: 'notify-testing'
+
+- matchers: ['{level="critical"}']
+  receiver: 'notify-critical'
+
+- matchers: ['{owner=~"team-.*"}']
+  receiver: 'notify-teams'
+`
+
+	var ctree config.Route
+	if err := yaml.UnmarshalStrict([]byte(in), &ctree); err != nil {
+		t.Fatal(err)
+	}
+	tree := NewRoute(&ctree, nil)
+
+	e := tree.Explain(model.LabelSet{"owner": "team-A", "level": "critical"})
+	require.True(t, e.Matched)
+	require.False(t, e.Selected)
+	// The last route isn't evaluated as the critical route matched without
+	// continue.
+	require.Len(t, e.Routes, 2)
+
+	teamA := e.Routes[0]
+	require.Equal(t, "notify-A", teamA.Receiver)
+	require.True(t, teamA.Matched)
+	require.True(t, teamA.Continue)
+	require.True(t, teamA.Selected)
+	require.Len(t, teamA.Routes, 1)
+	require.Equal(t, []MatcherExplanation{{Matcher: `env="testing"`, Matched: false}}, teamA.Routes[0].Matchers)
+	require.False(t, teamA.Routes[0].Matched)
+
+	require.True(t, e.Routes[1].Selected)
+
+	// The selected routes are the routes matched by Match.
+	var selected []string
+	var walk func(*RouteExplanation)
+	walk = func(e *RouteExplanation) {
+		if e.Selected {
+			selected = append(selected, e.Receiver)
+		}
+		for _, ce := range e.Routes {
+			walk(ce)
+		}
+	}
+	walk(e)
+	var matched []string
+	for _, r := range tree.Match(model.LabelSet{"owner": "team-A", "level": "critical"}) {
+		matched = append(matched, r.RouteOpts.Receiver)
+	}
+	require.Equal(t, matched, selected)
+
+	// The routes aren't evaluated further than their failed matchers.
+	e = tree.Explain(model.LabelSet{"owner": "team-B"})
+	require.Len(t, e.Routes, 3)
+	require.Nil(t, e.Routes[0].Routes)
+	require.True(t, e.Routes[2].Selected)
+
+	// The selected routes are restricted to the receivers of the alert.
+	e = tree.ExplainWithReceiver(model.LabelSet{"owner": "team-A", "level": "critical"}, []string{"notify-critical"})
+	require.False(t, e.Routes[0].Selected)
+	require.True(t, e.Routes[0].FilteredByReceivers)
+	require.True(t, e.Routes[1].Selected)
+	require.False(t, e.Routes[1].FilteredByReceivers)
+}
+
 func TestRouteMatchers(t *testing.T) {
 	in := `
 receiver: 'notify-def'
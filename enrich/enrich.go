@@ -0,0 +1,364 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enrich adds labels and annotations to the alerts at ingestion,
+// looked up in tables by the value of one of their labels.
+package enrich
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/snapshot"
+	"github.com/prometheus/alertmanager/types"
+)
+
+var (
+	// ErrNotFound is returned if a lookup table isn't configured.
+	ErrNotFound = errors.New("lookup table not found")
+	// ErrNotManaged is returned when setting the rows of a lookup table
+	// read from a file.
+	ErrNotManaged = errors.New("lookup table is read from a file")
+)
+
+// Rows maps the keys of a lookup table to their row, mapping the columns to
+// their value.
+type Rows map[string]map[string]string
+
+// TableStatus describes a lookup table.
+type TableStatus struct {
+	Name     string `json:"name"`
+	KeyLabel string `json:"keyLabel"`
+	File     string `json:"file,omitempty"`
+	Managed  bool   `json:"managed"`
+	Rows     int    `json:"rows"`
+}
+
+// Options configures the enricher.
+type Options struct {
+	// SnapshotFile is the file the rows of the tables managed through the
+	// API are persisted to. If empty, they are only kept in memory.
+	SnapshotFile string
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	rows    *prometheus.GaugeVec
+	lookups *prometheus.CounterVec
+}
+
+func newMetrics(r prometheus.Registerer) *metrics {
+	m := &metrics{
+		rows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alertmanager_lookup_table_rows",
+			Help: "Number of rows of the lookup tables.",
+		}, []string{"table"}),
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_lookup_table_lookups_total",
+			Help: "Number of alerts looked up in the lookup tables, by whether their key was found.",
+		}, []string{"table", "result"}),
+	}
+	if r != nil {
+		r.MustRegister(m.rows, m.lookups)
+	}
+	return m
+}
+
+type table struct {
+	conf *config.LookupTable
+	rows Rows
+}
+
+// Enricher enriches the alerts from the configured lookup tables.
+type Enricher struct {
+	opts    Options
+	logger  log.Logger
+	metrics *metrics
+
+	mtx    sync.RWMutex
+	tables []*table
+	// managed holds the rows of the tables managed through the API.
+	managed map[string]Rows
+}
+
+// New returns a new enricher, loading the rows of the tables managed through
+// the API from the snapshot file if it exists.
+func New(o Options) (*Enricher, error) {
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	e := &Enricher{
+		opts:    o,
+		logger:  o.Logger,
+		metrics: newMetrics(o.Metrics),
+		managed: map[string]Rows{},
+	}
+	if o.SnapshotFile == "" {
+		return e, nil
+	}
+
+	b, err := os.ReadFile(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return e, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &e.managed); err != nil {
+		return nil, errors.Wrap(err, "failed to load lookup tables snapshot")
+	}
+	return e, nil
+}
+
+// ApplyConfig replaces the lookup tables, reading the rows of the tables
+// backed by a file. The rows of the tables no longer managed through the API
+// are dropped. On error, the previous tables are kept.
+func (e *Enricher) ApplyConfig(lts []*config.LookupTable) error {
+	u, err := e.Prepare(lts)
+	if err != nil {
+		return err
+	}
+	u.Commit()
+	return nil
+}
+
+// Update holds the lookup tables of a configuration, not applied yet.
+type Update struct {
+	e      *Enricher
+	tables []*table
+}
+
+// Prepare reads the rows of the lookup tables backed by a file.
+func (e *Enricher) Prepare(lts []*config.LookupTable) (*Update, error) {
+	tables := make([]*table, 0, len(lts))
+	for _, lt := range lts {
+		if lt.File == "" {
+			tables = append(tables, &table{conf: lt})
+			continue
+		}
+		rows, err := LoadFile(lt.File, lt.KeyLabel)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load lookup table %q", lt.Name)
+		}
+		tables = append(tables, &table{conf: lt, rows: rows})
+	}
+	return &Update{e: e, tables: tables}, nil
+}
+
+// Commit replaces the lookup tables by the tables of the update.
+func (u *Update) Commit() {
+	e, tables := u.e, u.tables
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	managed := make(map[string]Rows, len(e.managed))
+	for _, t := range tables {
+		if t.conf.File == "" {
+			t.rows = e.managed[t.conf.Name]
+			managed[t.conf.Name] = t.rows
+		}
+	}
+	var dropped bool
+	for name := range e.managed {
+		if _, ok := managed[name]; !ok {
+			dropped = true
+		}
+	}
+
+	e.metrics.rows.Reset()
+	for _, t := range tables {
+		e.metrics.rows.WithLabelValues(t.conf.Name).Set(float64(len(t.rows)))
+	}
+	e.tables, e.managed = tables, managed
+	if dropped {
+		e.persist()
+	}
+}
+
+// Tables returns the lookup tables, in the order they are applied.
+func (e *Enricher) Tables() []TableStatus {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	res := make([]TableStatus, 0, len(e.tables))
+	for _, t := range e.tables {
+		res = append(res, TableStatus{
+			Name:     t.conf.Name,
+			KeyLabel: t.conf.KeyLabel,
+			File:     t.conf.File,
+			Managed:  t.conf.File == "",
+			Rows:     len(t.rows),
+		})
+	}
+	return res
+}
+
+// Rows returns the rows of a lookup table.
+func (e *Enricher) Rows(name string) (Rows, error) {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	t := e.table(name)
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	if t.rows == nil {
+		return Rows{}, nil
+	}
+	return t.rows, nil
+}
+
+// SetRows replaces the rows of a lookup table managed through the API.
+func (e *Enricher) SetRows(name string, rows Rows) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	t := e.table(name)
+	if t == nil {
+		return ErrNotFound
+	}
+	if t.conf.File != "" {
+		return ErrNotManaged
+	}
+	t.rows = rows
+	e.managed[name] = rows
+	e.metrics.rows.WithLabelValues(name).Set(float64(len(rows)))
+	e.persist()
+	return nil
+}
+
+// table returns the lookup table with the given name. The lock must be held.
+func (e *Enricher) table(name string) *table {
+	for _, t := range e.tables {
+		if t.conf.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Enrich adds to the alerts the columns of the rows matching their key
+// labels. The tables are applied in order, so that a table can look up the
+// labels added by the previous ones. The existing labels and annotations of
+// the alerts are never overwritten.
+func (e *Enricher) Enrich(alerts ...*types.Alert) {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	for _, t := range e.tables {
+		for _, a := range alerts {
+			key, ok := a.Labels[model.LabelName(t.conf.KeyLabel)]
+			if !ok {
+				continue
+			}
+			row, ok := t.rows[string(key)]
+			if !ok {
+				e.metrics.lookups.WithLabelValues(t.conf.Name, "miss").Inc()
+				continue
+			}
+			e.metrics.lookups.WithLabelValues(t.conf.Name, "hit").Inc()
+			for _, c := range t.conf.Labels {
+				if v := row[c]; v != "" {
+					if _, ok := a.Labels[model.LabelName(c)]; !ok {
+						a.Labels[model.LabelName(c)] = model.LabelValue(v)
+					}
+				}
+			}
+			for _, c := range t.conf.Annotations {
+				if v := row[c]; v != "" {
+					if a.Annotations == nil {
+						a.Annotations = model.LabelSet{}
+					}
+					if _, ok := a.Annotations[model.LabelName(c)]; !ok {
+						a.Annotations[model.LabelName(c)] = model.LabelValue(v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// LoadFile reads the rows of a lookup table from a file. A CSV file has a
+// header row naming the columns, the keys being in the column named after the
+// key label. A YAML file maps the keys to their row.
+func LoadFile(filename, keyLabel string) (Rows, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		rows := Rows{}
+		if err := yaml.NewDecoder(f).Decode(&rows); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	case ".csv":
+	default:
+		return nil, errors.Errorf("unsupported file %q", filename)
+	}
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header row")
+	}
+	header, keyCol := records[0], -1
+	for i, c := range header {
+		if c == keyLabel {
+			keyCol = i
+		}
+	}
+	if keyCol < 0 {
+		return nil, errors.Errorf("missing key column %q", keyLabel)
+	}
+	rows := make(Rows, len(records)-1)
+	for _, r := range records[1:] {
+		row := make(map[string]string, len(header)-1)
+		for i, c := range header {
+			if i != keyCol {
+				row[c] = r[i]
+			}
+		}
+		rows[r[keyCol]] = row
+	}
+	return rows, nil
+}
+
+// persist writes the rows of the tables managed through the API to the
+// snapshot file. The lock must be held.
+func (e *Enricher) persist() {
+	if e.opts.SnapshotFile == "" {
+		return
+	}
+	if err := snapshot.WriteJSON(e.opts.SnapshotFile, e.managed); err != nil {
+		level.Error(e.logger).Log("msg", "Failed to persist the lookup tables", "err", err)
+	}
+}
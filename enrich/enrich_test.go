@@ -0,0 +1,148 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrich
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+	return filename
+}
+
+func TestLoadFile(t *testing.T) {
+	csvFile := writeFile(t, "services.csv", `team,service,tier
+payments,checkout,1
+search,catalog,2
+`)
+	rows, err := LoadFile(csvFile, "service")
+	require.NoError(t, err)
+	require.Equal(t, Rows{
+		"checkout": {"team": "payments", "tier": "1"},
+		"catalog":  {"team": "search", "tier": "2"},
+	}, rows)
+
+	_, err = LoadFile(csvFile, "instance")
+	require.EqualError(t, err, `missing key column "instance"`)
+
+	yamlFile := writeFile(t, "services.yaml", `
+checkout:
+  team: payments
+  tier: "1"
+`)
+	rows, err = LoadFile(yamlFile, "service")
+	require.NoError(t, err)
+	require.Equal(t, Rows{"checkout": {"team": "payments", "tier": "1"}}, rows)
+
+	_, err = LoadFile(writeFile(t, "services.csv", "service,team\ncheckout\n"), "service")
+	require.Error(t, err)
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.csv"), "service")
+	require.Error(t, err)
+}
+
+func TestEnrich(t *testing.T) {
+	e, err := New(Options{Metrics: prometheus.NewRegistry()})
+	require.NoError(t, err)
+	services := writeFile(t, "services.csv", `service,team,tier,runbook_url
+checkout,payments,1,https://runbooks/checkout
+`)
+	require.NoError(t, e.ApplyConfig([]*config.LookupTable{
+		{Name: "services", File: services, KeyLabel: "service", Labels: []string{"team", "tier"}, Annotations: []string{"runbook_url"}},
+		{Name: "teams", KeyLabel: "team", Labels: []string{"escalation"}},
+	}))
+	require.NoError(t, e.SetRows("teams", Rows{"payments": {"escalation": "pager"}}))
+
+	var (
+		enriched = &types.Alert{Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency", "service": "checkout", "tier": "0"},
+		}}
+		unknown = &types.Alert{Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency", "service": "cart"},
+		}}
+		unkeyed = &types.Alert{Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency"},
+		}}
+	)
+	e.Enrich(enriched, unknown, unkeyed)
+
+	// The existing labels are kept, and the labels added by a table are
+	// looked up by the next ones.
+	require.Equal(t, model.LabelSet{
+		"alertname":  "HighLatency",
+		"service":    "checkout",
+		"tier":       "0",
+		"team":       "payments",
+		"escalation": "pager",
+	}, enriched.Labels)
+	require.Equal(t, model.LabelSet{"runbook_url": "https://runbooks/checkout"}, enriched.Annotations)
+	require.Equal(t, model.LabelSet{"alertname": "HighLatency", "service": "cart"}, unknown.Labels)
+	require.Nil(t, unknown.Annotations)
+	require.Equal(t, model.LabelSet{"alertname": "HighLatency"}, unkeyed.Labels)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(e.metrics.lookups.WithLabelValues("services", "hit")))
+	require.Equal(t, 1.0, testutil.ToFloat64(e.metrics.lookups.WithLabelValues("services", "miss")))
+	require.Equal(t, 1.0, testutil.ToFloat64(e.metrics.rows.WithLabelValues("teams")))
+
+	require.Equal(t, ErrNotManaged, e.SetRows("services", Rows{}))
+	require.Equal(t, ErrNotFound, e.SetRows("unknown", Rows{}))
+	_, err = e.Rows("unknown")
+	require.Equal(t, ErrNotFound, err)
+
+	// A file failing to load keeps the previous tables.
+	require.Error(t, e.ApplyConfig([]*config.LookupTable{
+		{Name: "services", File: filepath.Join(t.TempDir(), "missing.csv"), KeyLabel: "service", Labels: []string{"team"}},
+	}))
+	require.Len(t, e.Tables(), 2)
+}
+
+func TestEnricherSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "lookup_tables")
+	tables := []*config.LookupTable{
+		{Name: "teams", KeyLabel: "team", Labels: []string{"escalation"}},
+	}
+
+	e, err := New(Options{SnapshotFile: snapshot})
+	require.NoError(t, err)
+	require.NoError(t, e.ApplyConfig(tables))
+	rows := Rows{"payments": {"escalation": "pager"}}
+	require.NoError(t, e.SetRows("teams", rows))
+
+	loaded, err := New(Options{SnapshotFile: snapshot})
+	require.NoError(t, err)
+	require.NoError(t, loaded.ApplyConfig(tables))
+	got, err := loaded.Rows("teams")
+	require.NoError(t, err)
+	require.Equal(t, rows, got)
+
+	// The rows of the tables no longer configured are dropped.
+	require.NoError(t, loaded.ApplyConfig(nil))
+	loaded, err = New(Options{SnapshotFile: snapshot})
+	require.NoError(t, err)
+	require.NoError(t, loaded.ApplyConfig(tables))
+	got, err = loaded.Rows("teams")
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package escalation keeps the state of the escalation of aggregation groups
+// through their escalation policy. The state is shared by the peers of a
+// cluster so that a group escalates on the same schedule on all of them.
+package escalation
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/snapshot"
+)
+
+// Entry is the escalation state of an aggregation group.
+type Entry struct {
+	GroupKey string `json:"groupKey"`
+	Policy   string `json:"policy"`
+	// StartedAt is when the alerts of the group started firing. It is zero
+	// once they stopped firing.
+	StartedAt time.Time `json:"startedAt,omitempty"`
+	// Step is the number of steps of the policy reached by the group.
+	Step      int       `json:"step"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Active returns whether the alerts of the group are firing.
+func (e *Entry) Active() bool {
+	return !e.StartedAt.IsZero()
+}
+
+// Options configures a log.
+type Options struct {
+	// SnapshotFile is the file the state is persisted to by Maintenance. If
+	// empty, the state is only kept in memory.
+	SnapshotFile string
+	// Retention is how long entries are kept after their last update.
+	Retention time.Duration
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	entries                 prometheus.GaugeFunc
+	propagatedMessagesTotal prometheus.Counter
+}
+
+// Log holds the escalation state of aggregation groups by group key.
+type Log struct {
+	opts      Options
+	logger    log.Logger
+	now       func() time.Time
+	metrics   *metrics
+	broadcast func([]byte)
+
+	mtx sync.RWMutex
+	st  state
+}
+
+type state map[string]*Entry
+
+// merge merges the entry into the state and returns whether it changed the
+// state. The most recently updated entry of a group wins.
+func (s state) merge(e *Entry, now time.Time) bool {
+	if !e.ExpiresAt.After(now) {
+		return false
+	}
+	prev, ok := s[e.GroupKey]
+	if ok && !prev.UpdatedAt.Before(e.UpdatedAt) {
+		return false
+	}
+	s[e.GroupKey] = e
+	return true
+}
+
+func (s state) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range s {
+		if err := enc.Encode(e); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeState(r io.Reader) (state, error) {
+	st := state{}
+	dec := json.NewDecoder(r)
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		st[e.GroupKey] = &e
+	}
+	return st, nil
+}
+
+// New returns a new log, loading the state from the snapshot file if it
+// exists.
+func New(o Options) (*Log, error) {
+	if o.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	l := &Log{
+		opts:      o,
+		logger:    o.Logger,
+		now:       func() time.Time { return time.Now().UTC() },
+		broadcast: func([]byte) {},
+		st:        state{},
+	}
+	l.metrics = &metrics{
+		entries: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_escalation_entries",
+			Help: "Number of aggregation groups in the escalation state.",
+		}, func() float64 {
+			l.mtx.RLock()
+			defer l.mtx.RUnlock()
+			return float64(len(l.st))
+		}),
+		propagatedMessagesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_escalation_gossip_messages_propagated_total",
+			Help: "Number of received gossip messages that have been further gossiped.",
+		}),
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(l.metrics.entries, l.metrics.propagatedMessagesTotal)
+	}
+
+	if o.SnapshotFile == "" {
+		return l, nil
+	}
+	f, err := os.Open(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	st, err := decodeState(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load escalation snapshot")
+	}
+	now := l.now()
+	for _, e := range st {
+		l.st.merge(e, now)
+	}
+	return l, nil
+}
+
+// Retention returns how long entries are kept after their last update.
+func (l *Log) Retention() time.Duration {
+	return l.opts.Retention
+}
+
+// Get returns the escalation state of the group.
+func (l *Log) Get(groupKey string) (Entry, bool) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	e, ok := l.st[groupKey]
+	if !ok || !e.ExpiresAt.After(l.now()) {
+		return Entry{GroupKey: groupKey}, false
+	}
+	return *e, true
+}
+
+// Set updates the escalation state of the group and gossips it to the
+// cluster.
+func (l *Log) Set(e Entry) error {
+	now := l.now()
+	e.UpdatedAt = now
+	e.ExpiresAt = now.Add(l.opts.Retention)
+
+	b, err := state{e.GroupKey: &e}.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	// The local clock may lag behind the peer having last updated the entry.
+	if prev, ok := l.st[e.GroupKey]; ok && prev.UpdatedAt.After(now) {
+		return nil
+	}
+	l.st[e.GroupKey] = &e
+	l.broadcast(b)
+	return nil
+}
+
+// GC removes the expired entries.
+func (l *Log) GC() int {
+	now := l.now()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	var n int
+	for k, e := range l.st {
+		if !e.ExpiresAt.After(now) {
+			delete(l.st, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Snapshot writes the state to w.
+func (l *Log) Snapshot(w io.Writer) (int64, error) {
+	b, err := l.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, bytes.NewReader(b))
+}
+
+// Maintenance garbage collects the state and persists it to the snapshot file
+// every interval, and once more when stopc is closed.
+func (l *Log) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	snapshot.Maintain(interval, stopc, func() {
+		l.GC()
+		if l.opts.SnapshotFile == "" {
+			return
+		}
+		if err := snapshot.Write(l.opts.SnapshotFile, func(w io.Writer) error {
+			_, err := l.Snapshot(w)
+			return err
+		}); err != nil {
+			level.Error(l.logger).Log("msg", "Failed to persist the escalation state", "err", err)
+		}
+	})
+}
+
+// MarshalBinary serializes the state.
+func (l *Log) MarshalBinary() ([]byte, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	return l.st.MarshalBinary()
+}
+
+// Merge merges escalation state received from the cluster with the local
+// state.
+func (l *Log) Merge(b []byte) error {
+	st, err := decodeState(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	now := l.now()
+
+	for _, e := range st {
+		if merged := l.st.merge(e, now); merged && !cluster.OversizedMessage(b) {
+			// If this is the first we've seen the message and it's
+			// not oversized, gossip it to other nodes. We don't
+			// propagate oversized messages because they're sent to
+			// all nodes already.
+			l.broadcast(b)
+			l.metrics.propagatedMessagesTotal.Inc()
+			level.Debug(l.logger).Log("msg", "gossiping new entry", "entry", e.GroupKey)
+		}
+	}
+	return nil
+}
+
+// SetBroadcast sets a broadcast callback that will be invoked with serialized
+// state on updates.
+func (l *Log) SetBroadcast(f func([]byte)) {
+	l.mtx.Lock()
+	l.broadcast = f
+	l.mtx.Unlock()
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package escalation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLog(t *testing.T, snapshot string) *Log {
+	l, err := New(Options{
+		SnapshotFile: snapshot,
+		Retention:    time.Hour,
+		Metrics:      prometheus.NewRegistry(),
+	})
+	require.NoError(t, err)
+	return l
+}
+
+func TestLogSetAndGet(t *testing.T) {
+	l := newTestLog(t, "")
+	var broadcasts int
+	l.SetBroadcast(func([]byte) { broadcasts++ })
+
+	_, ok := l.Get("1")
+	require.False(t, ok)
+
+	started := time.Now().UTC()
+	require.NoError(t, l.Set(Entry{GroupKey: "1", Policy: "on-call", StartedAt: started, Step: 1}))
+	require.Equal(t, 1, broadcasts)
+
+	e, ok := l.Get("1")
+	require.True(t, ok)
+	require.True(t, e.Active())
+	require.Equal(t, "on-call", e.Policy)
+	require.Equal(t, 1, e.Step)
+	require.Equal(t, started, e.StartedAt)
+	require.Equal(t, e.UpdatedAt.Add(time.Hour), e.ExpiresAt)
+
+	// Expired entries are ignored and garbage collected.
+	now := time.Now().UTC()
+	l.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, ok = l.Get("1")
+	require.False(t, ok)
+	require.Equal(t, 1, l.GC())
+}
+
+func TestLogMerge(t *testing.T) {
+	l := newTestLog(t, "")
+	var broadcasts int
+	l.SetBroadcast(func([]byte) { broadcasts++ })
+	require.NoError(t, l.Set(Entry{GroupKey: "1", Policy: "on-call", StartedAt: time.Now(), Step: 1}))
+
+	now := time.Now().UTC()
+	merge := func(e *Entry) {
+		b, err := state{e.GroupKey: e}.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, l.Merge(b))
+	}
+
+	// Entries updated before the local one are dropped.
+	merge(&Entry{GroupKey: "1", Policy: "on-call", Step: 0, UpdatedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)})
+	e, _ := l.Get("1")
+	require.Equal(t, 1, e.Step)
+	require.Equal(t, 1, broadcasts)
+
+	// More recent entries win and are gossiped further.
+	merge(&Entry{GroupKey: "1", Policy: "on-call", StartedAt: now, Step: 2, UpdatedAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)})
+	e, _ = l.Get("1")
+	require.Equal(t, 2, e.Step)
+	require.Equal(t, 2, broadcasts)
+
+	// Expired entries are dropped.
+	merge(&Entry{GroupKey: "2", UpdatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)})
+	_, ok := l.Get("2")
+	require.False(t, ok)
+}
+
+func TestLogSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "escalation")
+	l := newTestLog(t, snapshot)
+	started := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, l.Set(Entry{GroupKey: "1", Policy: "on-call", StartedAt: started, Step: 1}))
+
+	stopc := make(chan struct{})
+	close(stopc)
+	l.Maintenance(time.Hour, stopc)
+
+	loaded := newTestLog(t, snapshot)
+	e, ok := loaded.Get("1")
+	require.True(t, ok)
+	require.Equal(t, "on-call", e.Policy)
+	require.Equal(t, 1, e.Step)
+	require.True(t, started.Equal(e.StartedAt))
+}
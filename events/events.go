@@ -0,0 +1,442 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events publishes the state changes of the alerts, from their
+// reception to their notification, and exports them in batches.
+package events
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// Received is published when an alert starts firing.
+	Received Type = "received"
+	// Silenced is published when an alert gets silenced.
+	Silenced Type = "silenced"
+	// Inhibited is published when an alert gets inhibited.
+	Inhibited Type = "inhibited"
+	// Notified is published when an integration notified an alert.
+	Notified Type = "notified"
+	// Failed is published when an integration gave up notifying an alert.
+	Failed Type = "failed"
+	// Resolved is published when an alert is resolved.
+	Resolved Type = "resolved"
+)
+
+// Event is a state change of an alert.
+type Event struct {
+	Time        time.Time
+	Type        Type
+	Fingerprint model.Fingerprint
+	// Labels are unset if the alert isn't known to the bus.
+	Labels model.LabelSet
+	// Receiver, Integration and GroupKey are set for the notified and
+	// failed events.
+	Receiver    string
+	Integration string
+	GroupKey    string
+	// SuppressedBy holds the IDs of the silences of the silenced events and
+	// of the inhibiting alerts of the inhibited events.
+	SuppressedBy []string
+	// Error is the error of the failed events.
+	Error string
+}
+
+// Exporter exports the events.
+type Exporter interface {
+	// Export exports a batch of events. It doesn't retain the slice.
+	Export(ctx context.Context, events []*Event) error
+	// Shutdown releases the resources of the exporter.
+	Shutdown() error
+}
+
+// Options configures a Bus.
+type Options struct {
+	// NewExporter returns the exporter of a configuration. Defaults to
+	// NewOTLPExporter.
+	NewExporter func(*config.LogEventsConfig) (Exporter, error)
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	published *prometheus.CounterVec
+	exported  prometheus.Counter
+	dropped   *prometheus.CounterVec
+}
+
+func newMetrics(r prometheus.Registerer) *metrics {
+	m := &metrics{
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_log_events_published_total",
+			Help: "The total number of published alert events, by type.",
+		}, []string{"type"}),
+		exported: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_log_events_exported_total",
+			Help: "The total number of exported alert events.",
+		}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_log_events_dropped_total",
+			Help: "The total number of alert events dropped, by reason.",
+		}, []string{"reason"}),
+	}
+	for _, t := range []Type{Received, Silenced, Inhibited, Notified, Failed, Resolved} {
+		m.published.WithLabelValues(string(t))
+	}
+	for _, reason := range []string{"buffer_full", "export_failed"} {
+		m.dropped.WithLabelValues(reason)
+	}
+	if r != nil {
+		r.MustRegister(m.published, m.exported, m.dropped)
+	}
+	return m
+}
+
+// alertState is the state of a stored alert known to the bus.
+type alertState struct {
+	labels   model.LabelSet
+	resolved bool
+}
+
+// Bus buffers the published events and exports them in batches. It also
+// tracks the stored alerts to publish their received and resolved events,
+// as a callback of the alert provider.
+type Bus struct {
+	logger      log.Logger
+	metrics     *metrics
+	newExporter func(*config.LogEventsConfig) (Exporter, error)
+
+	mtx    sync.RWMutex
+	config *config.LogEventsConfig
+	// queue is nil while the export is disabled.
+	queue chan *Event
+	done  chan struct{}
+
+	amtx   sync.Mutex
+	alerts map[model.Fingerprint]*alertState
+}
+
+// New returns a new Bus. The events are dropped until a configuration
+// enabling the export is applied.
+func New(o Options) *Bus {
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	if o.NewExporter == nil {
+		o.NewExporter = NewOTLPExporter
+	}
+	return &Bus{
+		logger:      o.Logger,
+		metrics:     newMetrics(o.Metrics),
+		newExporter: o.NewExporter,
+		alerts:      map[model.Fingerprint]*alertState{},
+	}
+}
+
+// ApplyConfig replaces the exporter if the configuration changed. The events
+// buffered for the previous exporter are flushed first. A nil configuration
+// disables the export.
+func (b *Bus) ApplyConfig(c *config.LogEventsConfig) error {
+	u, err := b.Prepare(c)
+	if err != nil {
+		return err
+	}
+	u.Commit()
+	return nil
+}
+
+// Update is an exporter built for a configuration and not started yet.
+type Update struct {
+	b         *Bus
+	config    *config.LogEventsConfig
+	exp       Exporter
+	unchanged bool
+}
+
+// Prepare builds the exporter of the configuration without starting the
+// export.
+func (b *Bus) Prepare(c *config.LogEventsConfig) (*Update, error) {
+	b.mtx.RLock()
+	unchanged := reflect.DeepEqual(b.config, c)
+	b.mtx.RUnlock()
+
+	u := &Update{b: b, config: c, unchanged: unchanged}
+	if unchanged || c == nil {
+		return u, nil
+	}
+	var err error
+	if u.exp, err = b.newExporter(c); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Commit replaces the current exporter by the exporter of the update.
+func (u *Update) Commit() {
+	b := u.b
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if u.unchanged {
+		return
+	}
+	b.stop()
+	b.config = u.config
+	if u.config == nil {
+		level.Info(b.logger).Log("msg", "Export of the log events disabled")
+		return
+	}
+	b.queue = make(chan *Event, u.config.BufferSize)
+	b.done = make(chan struct{})
+	go b.run(u.exp, u.config, b.queue, b.done)
+	u.exp = nil
+	level.Info(b.logger).Log("msg", "Export of the log events enabled", "endpoint", u.config.Endpoint)
+}
+
+// Discard shuts down the exporter if it wasn't started.
+func (u *Update) Discard() {
+	if u.exp == nil {
+		return
+	}
+	if err := u.exp.Shutdown(); err != nil {
+		level.Warn(u.b.logger).Log("msg", "Failed to shut down the discarded log events exporter", "err", err)
+	}
+	u.exp = nil
+}
+
+// Stop flushes the buffered events and stops the export.
+func (b *Bus) Stop() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.stop()
+	b.config = nil
+}
+
+// stop stops the current export, if any. The lock must be held.
+func (b *Bus) stop() {
+	if b.queue == nil {
+		return
+	}
+	close(b.queue)
+	<-b.done
+	b.queue, b.done = nil, nil
+}
+
+// run exports the events of the queue in batches until the queue is closed.
+func (b *Bus) run(exp Exporter, c *config.LogEventsConfig, queue <-chan *Event, done chan<- struct{}) {
+	defer close(done)
+	defer func() {
+		if err := exp.Shutdown(); err != nil {
+			level.Warn(b.logger).Log("msg", "Failed to shut down the log events exporter", "err", err)
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(c.FlushInterval))
+	defer ticker.Stop()
+
+	batch := make([]*Event, 0, c.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout))
+		defer cancel()
+		if err := exp.Export(ctx, batch); err != nil {
+			level.Error(b.logger).Log("msg", "Failed to export the log events", "events", len(batch), "err", err)
+			b.metrics.dropped.WithLabelValues("export_failed").Add(float64(len(batch)))
+		} else {
+			b.metrics.exported.Add(float64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case e, ok := <-queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= c.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// Enabled returns whether the events are exported.
+func (b *Bus) Enabled() bool {
+	if b == nil {
+		return false
+	}
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.queue != nil
+}
+
+// Publish buffers the events for the export. The events are dropped if the
+// export is disabled or the buffer is full. It is safe to call on a nil Bus.
+func (b *Bus) Publish(events ...*Event) {
+	if b == nil {
+		return
+	}
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	if b.queue == nil {
+		return
+	}
+	for _, e := range events {
+		b.metrics.published.WithLabelValues(string(e.Type)).Inc()
+		select {
+		case b.queue <- e:
+		default:
+			b.metrics.dropped.WithLabelValues("buffer_full").Inc()
+		}
+	}
+}
+
+// publishAlert publishes an event of the alert with the labels known to the
+// bus.
+func (b *Bus) publishAlert(fp model.Fingerprint, t Type, suppressedBy []string) {
+	var labels model.LabelSet
+	b.amtx.Lock()
+	if st, ok := b.alerts[fp]; ok {
+		labels = st.labels
+	}
+	b.amtx.Unlock()
+
+	b.Publish(&Event{
+		Time:         time.Now(),
+		Type:         t,
+		Fingerprint:  fp,
+		Labels:       labels,
+		SuppressedBy: suppressedBy,
+	})
+}
+
+// PreStore implements the mem.AlertStoreCallback interface.
+func (b *Bus) PreStore(_ *types.Alert, _ bool) error { return nil }
+
+// PostStore implements the mem.AlertStoreCallback interface. It publishes
+// the received event of a new or firing again alert, and the resolved event
+// of a resolved alert.
+func (b *Bus) PostStore(alert *types.Alert, _ bool) {
+	var (
+		fp       = alert.Fingerprint()
+		resolved = alert.Resolved()
+	)
+	b.amtx.Lock()
+	st, known := b.alerts[fp]
+	if !known {
+		st = &alertState{}
+		b.alerts[fp] = st
+	}
+	wasResolved := st.resolved
+	st.labels, st.resolved = alert.Labels, resolved
+	b.amtx.Unlock()
+
+	var t Type
+	switch {
+	case !resolved && (!known || wasResolved):
+		t = Received
+	case resolved && (!known || !wasResolved):
+		t = Resolved
+	default:
+		return
+	}
+	b.Publish(&Event{
+		Time:        time.Now(),
+		Type:        t,
+		Fingerprint: fp,
+		Labels:      alert.Labels,
+	})
+}
+
+// PostDelete implements the mem.AlertStoreCallback interface. The alerts
+// which timed out are only known to be resolved when they are garbage
+// collected, hence their resolved event is published then.
+func (b *Bus) PostDelete(alert *types.Alert) {
+	fp := alert.Fingerprint()
+	b.amtx.Lock()
+	st, known := b.alerts[fp]
+	delete(b.alerts, fp)
+	b.amtx.Unlock()
+
+	if !known || st.resolved {
+		return
+	}
+	b.Publish(&Event{
+		Time:        alert.EndsAt,
+		Type:        Resolved,
+		Fingerprint: fp,
+		Labels:      alert.Labels,
+	})
+}
+
+// Marker returns a types.Marker publishing the silenced and inhibited events
+// of the alerts marked through m.
+func (b *Bus) Marker(m types.Marker) types.Marker {
+	return &marker{Marker: m, bus: b}
+}
+
+type marker struct {
+	types.Marker
+	bus *Bus
+}
+
+// SetSilenced implements types.Marker.
+func (m *marker) SetSilenced(alert model.Fingerprint, version int, activeIDs, pendingIDs []string) {
+	if !m.bus.Enabled() {
+		m.Marker.SetSilenced(alert, version, activeIDs, pendingIDs)
+		return
+	}
+	prev := m.Marker.Status(alert)
+	m.Marker.SetSilenced(alert, version, activeIDs, pendingIDs)
+	if len(prev.SilencedBy) == 0 && len(activeIDs) > 0 {
+		m.bus.publishAlert(alert, Silenced, activeIDs)
+	}
+}
+
+// SetInhibited implements types.Marker.
+func (m *marker) SetInhibited(alert model.Fingerprint, ids ...string) {
+	if !m.bus.Enabled() {
+		m.Marker.SetInhibited(alert, ids...)
+		return
+	}
+	prev := m.Marker.Status(alert)
+	m.Marker.SetInhibited(alert, ids...)
+	if len(prev.InhibitedBy) == 0 && len(ids) > 0 {
+		m.bus.publishAlert(alert, Inhibited, ids)
+	}
+}
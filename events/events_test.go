@@ -0,0 +1,267 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type recordingExporter struct {
+	mtx     sync.Mutex
+	batches [][]*Event
+	// block, if not nil, blocks the exports until it is closed.
+	block    chan struct{}
+	shutdown bool
+}
+
+func (e *recordingExporter) Export(_ context.Context, events []*Event) error {
+	if e.block != nil {
+		<-e.block
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.batches = append(e.batches, append([]*Event(nil), events...))
+	return nil
+}
+
+func (e *recordingExporter) Shutdown() error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *recordingExporter) types() []Type {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	var res []Type
+	for _, b := range e.batches {
+		for _, ev := range b {
+			res = append(res, ev.Type)
+		}
+	}
+	return res
+}
+
+func newTestBus(t *testing.T, exp *recordingExporter, bufferSize int) *Bus {
+	b := New(Options{
+		NewExporter: func(*config.LogEventsConfig) (Exporter, error) { return exp, nil },
+		Metrics:     prometheus.NewRegistry(),
+	})
+	require.NoError(t, b.ApplyConfig(&config.LogEventsConfig{
+		Endpoint:      "localhost:4317",
+		Timeout:       model.Duration(time.Second),
+		BatchSize:     2,
+		BufferSize:    bufferSize,
+		FlushInterval: model.Duration(time.Hour),
+	}))
+	return b
+}
+
+func TestBusBatches(t *testing.T) {
+	exp := &recordingExporter{}
+	b := newTestBus(t, exp, 100)
+
+	b.Publish(&Event{Type: Received}, &Event{Type: Notified}, &Event{Type: Resolved})
+	require.Eventually(t, func() bool {
+		exp.mtx.Lock()
+		defer exp.mtx.Unlock()
+		return len(exp.batches) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The pending events are flushed when the export stops.
+	b.Stop()
+	require.Len(t, exp.batches, 2)
+	require.Len(t, exp.batches[0], 2)
+	require.Equal(t, []Type{Received, Notified, Resolved}, exp.types())
+	require.Equal(t, 3.0, testutil.ToFloat64(b.metrics.exported))
+
+	// The events are dropped once the export is disabled.
+	b.Publish(&Event{Type: Received})
+	require.False(t, b.Enabled())
+	require.Equal(t, 1.0, testutil.ToFloat64(b.metrics.published.WithLabelValues(string(Received))))
+
+	// Publishing to a nil bus is a no-op.
+	var nilBus *Bus
+	nilBus.Publish(&Event{Type: Received})
+	require.False(t, nilBus.Enabled())
+}
+
+func TestBusPrepareDiscard(t *testing.T) {
+	exp := &recordingExporter{}
+	b := New(Options{
+		NewExporter: func(*config.LogEventsConfig) (Exporter, error) { return exp, nil },
+		Metrics:     prometheus.NewRegistry(),
+	})
+
+	// A discarded update doesn't enable the export.
+	u, err := b.Prepare(&config.LogEventsConfig{Endpoint: "localhost:4317", BufferSize: 1})
+	require.NoError(t, err)
+	u.Discard()
+	require.True(t, exp.shutdown)
+	require.False(t, b.Enabled())
+	require.Nil(t, b.config)
+}
+
+func TestBusBufferFull(t *testing.T) {
+	exp := &recordingExporter{block: make(chan struct{})}
+	b := newTestBus(t, exp, 4)
+
+	// The first batch blocks the export while the next events fill the
+	// buffer.
+	b.Publish(&Event{Type: Received}, &Event{Type: Received})
+	require.Eventually(t, func() bool { return len(b.queue) == 0 }, time.Second, 10*time.Millisecond)
+	for i := 0; i < 6; i++ {
+		b.Publish(&Event{Type: Notified})
+	}
+	require.Equal(t, 2.0, testutil.ToFloat64(b.metrics.dropped.WithLabelValues("buffer_full")))
+
+	close(exp.block)
+	b.Stop()
+	require.Len(t, exp.types(), 6)
+}
+
+func TestBusAlertStore(t *testing.T) {
+	exp := &recordingExporter{}
+	b := newTestBus(t, exp, 100)
+
+	var (
+		now    = time.Now()
+		labels = model.LabelSet{"alertname": "HighLatency"}
+		firing = &types.Alert{Alert: model.Alert{Labels: labels, StartsAt: now, EndsAt: now.Add(time.Hour)}}
+		ended  = &types.Alert{Alert: model.Alert{Labels: labels, StartsAt: now, EndsAt: now.Add(-time.Minute)}}
+	)
+	require.NoError(t, b.PreStore(firing, false))
+	b.PostStore(firing, false)
+	// An update of a firing alert isn't an event.
+	b.PostStore(firing, true)
+	b.PostStore(ended, true)
+	b.PostStore(firing, true)
+	// The alert timed out.
+	b.PostDelete(firing)
+	// A resolved alert is deleted without an event.
+	b.PostStore(ended, false)
+	b.PostDelete(ended)
+
+	b.Stop()
+	require.Equal(t, []Type{Received, Resolved, Received, Resolved, Resolved}, exp.types())
+	require.Equal(t, labels, exp.batches[0][0].Labels)
+	require.Empty(t, b.alerts)
+}
+
+func TestMarker(t *testing.T) {
+	exp := &recordingExporter{}
+	b := newTestBus(t, exp, 100)
+	m := b.Marker(types.NewMarker(prometheus.NewRegistry()))
+
+	labels := model.LabelSet{"alertname": "HighLatency"}
+	alert := &types.Alert{Alert: model.Alert{Labels: labels, EndsAt: time.Now().Add(time.Hour)}}
+	fp := alert.Fingerprint()
+	b.PostStore(alert, false)
+
+	m.SetSilenced(fp, 1, []string{"silence-1"}, nil)
+	// The alert stays silenced.
+	m.SetSilenced(fp, 2, []string{"silence-1", "silence-2"}, nil)
+	m.SetInhibited(fp, "source")
+	m.SetSilenced(fp, 3, nil, nil)
+	m.SetInhibited(fp)
+	m.SetInhibited(fp, "source")
+
+	b.Stop()
+	require.Equal(t, []Type{Received, Silenced, Inhibited, Inhibited}, exp.types())
+	silenced := exp.batches[0][1]
+	require.Equal(t, labels, silenced.Labels)
+	require.Equal(t, []string{"silence-1"}, silenced.SuppressedBy)
+}
+
+func TestOTLPExporterHTTP(t *testing.T) {
+	var (
+		req     collogspb.ExportLogsServiceRequest
+		headers http.Header
+		path    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers, path = r.Header, r.URL.Path
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		b, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(b, &req))
+	}))
+	defer srv.Close()
+
+	exp, err := NewOTLPExporter(&config.LogEventsConfig{
+		ClientType:  config.OTLPClientHTTP,
+		Endpoint:    strings.TrimPrefix(srv.URL, "http://"),
+		Insecure:    true,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		Compression: "gzip",
+	})
+	require.NoError(t, err)
+	defer exp.Shutdown()
+
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, exp.Export(context.Background(), []*Event{
+		{
+			Time:        ts,
+			Type:        Failed,
+			Fingerprint: 1,
+			Labels:      model.LabelSet{"alertname": "HighLatency", "team": "payments"},
+			Receiver:    "team-X",
+			Integration: "webhook[0]",
+			Error:       "unavailable",
+		},
+	}))
+
+	require.Equal(t, "/v1/logs", path)
+	require.Equal(t, "Bearer token", headers.Get("Authorization"))
+	require.Equal(t, "application/x-protobuf", headers.Get("Content-Type"))
+
+	require.Len(t, req.ResourceLogs, 1)
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 1)
+	r := records[0]
+	require.Equal(t, uint64(ts.UnixNano()), r.TimeUnixNano)
+	require.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, r.SeverityNumber)
+	require.Equal(t, "Alert HighLatency failed to notify by team-X/webhook[0]: unavailable", r.Body.GetStringValue())
+
+	attrs := map[string]string{}
+	for _, kv := range r.Attributes {
+		attrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	require.Equal(t, "alertmanager.alert.failed", attrs["event.name"])
+	require.Equal(t, "HighLatency", attrs["alert.name"])
+	require.Equal(t, "team-X", attrs["receiver"])
+	require.Equal(t, "unavailable", attrs["error"])
+}
@@ -0,0 +1,305 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/version"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip" // Registers the gzip compressor.
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const scopeName = "github.com/prometheus/alertmanager/events"
+
+// NewOTLPExporter returns an exporter sending the events as OTLP log records.
+func NewOTLPExporter(c *config.LogEventsConfig) (Exporter, error) {
+	var tlsConfig *commoncfg.TLSConfig
+	if !c.Insecure {
+		tlsConfig = c.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &commoncfg.TLSConfig{}
+		}
+	}
+
+	switch c.ClientType {
+	case config.OTLPClientGRPC:
+		creds := insecure.NewCredentials()
+		if tlsConfig != nil {
+			tc, err := commoncfg.NewTLSConfig(tlsConfig)
+			if err != nil {
+				return nil, err
+			}
+			creds = credentials.NewTLS(tc)
+		}
+		callOpts := []grpc.CallOption{}
+		if c.Compression != "" {
+			callOpts = append(callOpts, grpc.UseCompressor(c.Compression))
+		}
+		conn, err := grpc.Dial(c.Endpoint, grpc.WithTransportCredentials(creds), grpc.WithDefaultCallOptions(callOpts...))
+		if err != nil {
+			return nil, err
+		}
+		return &grpcExporter{
+			conn:    conn,
+			client:  collogspb.NewLogsServiceClient(conn),
+			headers: metadata.New(c.Headers),
+		}, nil
+
+	case config.OTLPClientHTTP:
+		scheme := "http"
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if tlsConfig != nil {
+			scheme = "https"
+			tc, err := commoncfg.NewTLSConfig(tlsConfig)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tc
+		}
+		return &httpExporter{
+			client:      &http.Client{Transport: transport},
+			url:         scheme + "://" + c.Endpoint + "/v1/logs",
+			headers:     c.Headers,
+			compression: c.Compression,
+		}, nil
+	}
+	return nil, errors.Errorf("unknown client type %q", c.ClientType)
+}
+
+type grpcExporter struct {
+	conn    *grpc.ClientConn
+	client  collogspb.LogsServiceClient
+	headers metadata.MD
+}
+
+// Export implements the Exporter interface.
+func (e *grpcExporter) Export(ctx context.Context, events []*Event) error {
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, e.headers)
+	}
+	resp, err := e.client.Export(ctx, newExportRequest(events))
+	if err != nil {
+		return err
+	}
+	return partialSuccessError(resp.GetPartialSuccess())
+}
+
+// Shutdown implements the Exporter interface.
+func (e *grpcExporter) Shutdown() error {
+	return e.conn.Close()
+}
+
+type httpExporter struct {
+	client      *http.Client
+	url         string
+	headers     map[string]string
+	compression string
+}
+
+// Export implements the Exporter interface.
+func (e *httpExporter) Export(ctx context.Context, events []*Event) error {
+	b, err := proto.Marshal(newExportRequest(events))
+	if err != nil {
+		return err
+	}
+	if e.compression == "gzip" {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(b); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		b = buf.Bytes()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	if e.compression == "gzip" {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var res collogspb.ExportLogsServiceResponse
+	if err := proto.Unmarshal(body, &res); err != nil {
+		// The response is optional.
+		return nil
+	}
+	return partialSuccessError(res.GetPartialSuccess())
+}
+
+// Shutdown implements the Exporter interface.
+func (e *httpExporter) Shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// partialSuccessError returns an error if the receiver rejected log records.
+func partialSuccessError(ps *collogspb.ExportLogsPartialSuccess) error {
+	if ps.GetRejectedLogRecords() == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d log records rejected: %s", ps.GetRejectedLogRecords(), ps.GetErrorMessage())
+}
+
+// newExportRequest returns the OTLP request exporting the events.
+func newExportRequest(events []*Event) *collogspb.ExportLogsServiceRequest {
+	records := make([]*logspb.LogRecord, 0, len(events))
+	for _, e := range events {
+		records = append(records, newLogRecord(e))
+	}
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{
+					stringAttr("service.name", "alertmanager"),
+					stringAttr("service.version", version.Version),
+				},
+			},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: scopeName},
+				LogRecords: records,
+			}},
+		}},
+	}
+}
+
+// newLogRecord returns the log record of an event. The failed events are
+// errors and the other ones are informational.
+func newLogRecord(e *Event) *logspb.LogRecord {
+	severity, severityText := logspb.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+	if e.Type == Failed {
+		severity, severityText = logspb.SeverityNumber_SEVERITY_NUMBER_ERROR, "ERROR"
+	}
+
+	attrs := []*commonpb.KeyValue{
+		stringAttr("event.name", "alertmanager.alert."+string(e.Type)),
+		stringAttr("alert.fingerprint", e.Fingerprint.String()),
+	}
+	if name, ok := e.Labels["alertname"]; ok {
+		attrs = append(attrs, stringAttr("alert.name", string(name)))
+	}
+	if len(e.Labels) > 0 {
+		names := make([]string, 0, len(e.Labels))
+		for name := range e.Labels {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		labels := make([]*commonpb.KeyValue, 0, len(names))
+		for _, name := range names {
+			labels = append(labels, stringAttr(name, string(e.Labels[model.LabelName(name)])))
+		}
+		attrs = append(attrs, &commonpb.KeyValue{
+			Key:   "alert.labels",
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: labels}}},
+		})
+	}
+	if e.Receiver != "" {
+		attrs = append(attrs, stringAttr("receiver", e.Receiver))
+	}
+	if e.Integration != "" {
+		attrs = append(attrs, stringAttr("integration", e.Integration))
+	}
+	if e.GroupKey != "" {
+		attrs = append(attrs, stringAttr("group.key", e.GroupKey))
+	}
+	if len(e.SuppressedBy) > 0 {
+		values := make([]*commonpb.AnyValue, 0, len(e.SuppressedBy))
+		for _, id := range e.SuppressedBy {
+			values = append(values, &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: id}})
+		}
+		attrs = append(attrs, &commonpb.KeyValue{
+			Key:   "suppressed_by",
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}},
+		})
+	}
+	if e.Error != "" {
+		attrs = append(attrs, stringAttr("error", e.Error))
+	}
+
+	ts := uint64(e.Time.UnixNano())
+	return &logspb.LogRecord{
+		TimeUnixNano:         ts,
+		ObservedTimeUnixNano: ts,
+		SeverityNumber:       severity,
+		SeverityText:         severityText,
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: body(e)}},
+		Attributes:           attrs,
+	}
+}
+
+// body returns the human-readable message of an event.
+func body(e *Event) string {
+	name := string(e.Labels["alertname"])
+	if name == "" {
+		name = e.Fingerprint.String()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alert %s %s", name, e.Type)
+	switch e.Type {
+	case Notified:
+		fmt.Fprintf(&b, " by %s/%s", e.Receiver, e.Integration)
+	case Failed:
+		fmt.Fprintf(&b, " to notify by %s/%s: %s", e.Receiver, e.Integration, e.Error)
+	case Silenced, Inhibited:
+		fmt.Fprintf(&b, " by %s", strings.Join(e.SuppressedBy, ", "))
+	}
+	return b.String()
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
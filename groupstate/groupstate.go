@@ -0,0 +1,239 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package groupstate keeps the flush schedule of aggregation groups so that
+// the groups recreated after a restart or a reload continue their
+// notification cadence instead of waiting for group_wait again.
+package groupstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/snapshot"
+)
+
+// Entry is the state of an aggregation group.
+type Entry struct {
+	GroupKey string         `json:"groupKey"`
+	RouteKey string         `json:"routeKey"`
+	Labels   model.LabelSet `json:"labels"`
+	// NextFlush is when the group is due to flush.
+	NextFlush time.Time `json:"nextFlush"`
+	// LastFlush is when the group last flushed. It is zero if the group
+	// didn't flush yet.
+	LastFlush time.Time `json:"lastFlush,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Flushed returns whether the group flushed at least once.
+func (e *Entry) Flushed() bool {
+	return !e.LastFlush.IsZero()
+}
+
+// Options configures a store.
+type Options struct {
+	// SnapshotFile is the file the state is persisted to by Maintenance. If
+	// empty, the state is only kept in memory.
+	SnapshotFile string
+	// Retention is how long entries are kept after their last update.
+	Retention time.Duration
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	entries prometheus.GaugeFunc
+}
+
+// Store holds the state of aggregation groups by group key. Unlike the
+// notification log, it isn't shared by the peers of a cluster: each of them
+// flushes its groups on its own schedule.
+type Store struct {
+	opts    Options
+	logger  log.Logger
+	now     func() time.Time
+	metrics *metrics
+
+	mtx sync.RWMutex
+	st  state
+}
+
+type state map[string]*Entry
+
+func (s state) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range s {
+		if err := enc.Encode(e); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeState(r io.Reader) (state, error) {
+	st := state{}
+	dec := json.NewDecoder(r)
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		st[e.GroupKey] = &e
+	}
+	return st, nil
+}
+
+// New returns a new store, loading the state from the snapshot file if it
+// exists.
+func New(o Options) (*Store, error) {
+	if o.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	s := &Store{
+		opts:   o,
+		logger: o.Logger,
+		now:    func() time.Time { return time.Now().UTC() },
+		st:     state{},
+	}
+	s.metrics = &metrics{
+		entries: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_aggregation_group_state_entries",
+			Help: "Number of aggregation groups in the persisted group state.",
+		}, func() float64 {
+			s.mtx.RLock()
+			defer s.mtx.RUnlock()
+			return float64(len(s.st))
+		}),
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(s.metrics.entries)
+	}
+
+	if o.SnapshotFile == "" {
+		return s, nil
+	}
+	f, err := os.Open(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	st, err := decodeState(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load aggregation group snapshot")
+	}
+	now := s.now()
+	for k, e := range st {
+		if e.ExpiresAt.After(now) {
+			s.st[k] = e
+		}
+	}
+	return s, nil
+}
+
+// Get returns the state of the group.
+func (s *Store) Get(groupKey string) (Entry, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	e, ok := s.st[groupKey]
+	if !ok || !e.ExpiresAt.After(s.now()) {
+		return Entry{GroupKey: groupKey}, false
+	}
+	return *e, true
+}
+
+// Set updates the state of the group.
+func (s *Store) Set(e Entry) {
+	now := s.now()
+	e.UpdatedAt = now
+	e.ExpiresAt = now.Add(s.opts.Retention)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.st[e.GroupKey] = &e
+}
+
+// Delete removes the state of the group.
+func (s *Store) Delete(groupKey string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.st, groupKey)
+}
+
+// GC removes the expired entries.
+func (s *Store) GC() int {
+	now := s.now()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var n int
+	for k, e := range s.st {
+		if !e.ExpiresAt.After(now) {
+			delete(s.st, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Snapshot writes the state to w.
+func (s *Store) Snapshot(w io.Writer) (int64, error) {
+	s.mtx.RLock()
+	b, err := s.st.MarshalBinary()
+	s.mtx.RUnlock()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, bytes.NewReader(b))
+}
+
+// Maintenance garbage collects the state and persists it to the snapshot file
+// every interval, and once more when stopc is closed.
+func (s *Store) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	snapshot.Maintain(interval, stopc, func() {
+		s.GC()
+		if s.opts.SnapshotFile == "" {
+			return
+		}
+		if err := snapshot.Write(s.opts.SnapshotFile, func(w io.Writer) error {
+			_, err := s.Snapshot(w)
+			return err
+		}); err != nil {
+			level.Error(s.logger).Log("msg", "Failed to persist the aggregation group state", "err", err)
+		}
+	})
+}
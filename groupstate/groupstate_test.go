@@ -0,0 +1,91 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupstate
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, snapshot string) *Store {
+	s, err := New(Options{
+		SnapshotFile: snapshot,
+		Retention:    time.Hour,
+		Metrics:      prometheus.NewRegistry(),
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestStoreSetAndGet(t *testing.T) {
+	s := newTestStore(t, "")
+
+	_, ok := s.Get("1")
+	require.False(t, ok)
+
+	next := time.Now().UTC().Add(time.Minute)
+	s.Set(Entry{GroupKey: "1", RouteKey: "{}", Labels: model.LabelSet{"alertname": "A"}, NextFlush: next})
+
+	e, ok := s.Get("1")
+	require.True(t, ok)
+	require.False(t, e.Flushed())
+	require.Equal(t, next, e.NextFlush)
+	require.Equal(t, e.UpdatedAt.Add(time.Hour), e.ExpiresAt)
+
+	s.Delete("1")
+	_, ok = s.Get("1")
+	require.False(t, ok)
+
+	// Expired entries are ignored and garbage collected.
+	s.Set(Entry{GroupKey: "2", NextFlush: next})
+	now := time.Now().UTC()
+	s.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, ok = s.Get("2")
+	require.False(t, ok)
+	require.Equal(t, 1, s.GC())
+	require.Equal(t, 0, s.GC())
+}
+
+func TestStoreSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "aggregation_groups")
+	s := newTestStore(t, snapshot)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	want := Entry{
+		GroupKey:  "{}:{alertname=\"A\"}",
+		RouteKey:  "{}",
+		Labels:    model.LabelSet{"alertname": "A"},
+		NextFlush: now.Add(5 * time.Minute),
+		LastFlush: now,
+	}
+	s.Set(want)
+
+	stopc := make(chan struct{})
+	close(stopc)
+	s.Maintenance(time.Hour, stopc)
+
+	loaded := newTestStore(t, snapshot)
+	got, ok := loaded.Get(want.GroupKey)
+	require.True(t, ok)
+	require.True(t, got.Flushed())
+	require.Equal(t, want.Labels, got.Labels)
+	require.Equal(t, want.RouteKey, got.RouteKey)
+	require.True(t, want.NextFlush.Equal(got.NextFlush))
+	require.True(t, want.LastFlush.Equal(got.LastFlush))
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history keeps a queryable record of the delivery of notifications.
+// Unlike the notification log, which only stores what is needed to
+// deduplicate notifications, it records the outcome of each notification.
+package history
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus/alertmanager/snapshot"
+)
+
+// Status is the outcome of a notification.
+type Status string
+
+const (
+	StatusSuccess Status = "success"
+	StatusFailure Status = "failure"
+)
+
+// Entry records the delivery of a notification.
+type Entry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Receiver     string    `json:"receiver"`
+	Integration  string    `json:"integration"`
+	Index        int       `json:"index"`
+	GroupKey     string    `json:"groupKey"`
+	Fingerprints []string  `json:"fingerprints"`
+	Status       Status    `json:"status"`
+	// StatusCode is the HTTP status code of the last attempt, 0 for
+	// integrations not using HTTP.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Reason is the failure reason of the last attempt.
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Latency is the duration of the last attempt.
+	Latency  time.Duration `json:"latency"`
+	Attempts int           `json:"attempts"`
+	// FallbackReceiver is the receiver the alerts of a failed notification
+	// were dispatched to instead.
+	FallbackReceiver string `json:"fallbackReceiver,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The latency is
+// represented in seconds.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	type plain Entry
+	return json.Marshal(struct {
+		plain
+		Latency float64 `json:"latency"`
+	}{
+		plain:   plain(e),
+		Latency: e.Latency.Seconds(),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Entry) UnmarshalJSON(b []byte) error {
+	type plain Entry
+	v := struct {
+		*plain
+		Latency float64 `json:"latency"`
+	}{
+		plain: (*plain)(e),
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	e.Latency = time.Duration(v.Latency * float64(time.Second))
+	return nil
+}
+
+// Query filters the entries of the history. Zero fields don't filter.
+type Query struct {
+	Receiver    string
+	Fingerprint string
+	Status      Status
+	// Limit is the maximum number of entries returned.
+	Limit int
+}
+
+func (q *Query) matches(e *Entry) bool {
+	if q.Receiver != "" && e.Receiver != q.Receiver {
+		return false
+	}
+	if q.Status != "" && e.Status != q.Status {
+		return false
+	}
+	if q.Fingerprint != "" {
+		for _, fp := range e.Fingerprints {
+			if fp == q.Fingerprint {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Options configures a history.
+type Options struct {
+	// SnapshotFile is the file the history is persisted to by Maintenance.
+	// If empty, the history is only kept in memory.
+	SnapshotFile string
+	// Retention is how long entries are kept.
+	Retention time.Duration
+	// MaxEntries is the maximum number of entries kept. If 0, the number of
+	// entries is only bounded by the retention.
+	MaxEntries int
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+type metrics struct {
+	entries          prometheus.GaugeFunc
+	snapshotDuration prometheus.Summary
+}
+
+// History is an in-memory record of notification deliveries.
+type History struct {
+	opts    Options
+	logger  log.Logger
+	now     func() time.Time
+	metrics *metrics
+
+	mtx sync.RWMutex
+	// entries are sorted from the oldest to the most recent.
+	entries []*Entry
+}
+
+// New returns a new history, loading the entries from the snapshot file if
+// it exists.
+func New(o Options) (*History, error) {
+	if o.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if o.MaxEntries < 0 {
+		return nil, errors.New("max entries must not be negative")
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	h := &History{
+		opts:   o,
+		logger: o.Logger,
+		now:    time.Now,
+	}
+	h.metrics = &metrics{
+		entries: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_notification_history_entries",
+			Help: "Number of entries in the notification history.",
+		}, func() float64 {
+			h.mtx.RLock()
+			defer h.mtx.RUnlock()
+			return float64(len(h.current()))
+		}),
+		snapshotDuration: prometheus.NewSummary(prometheus.SummaryOpts{
+			Name:       "alertmanager_notification_history_snapshot_duration_seconds",
+			Help:       "Duration of the last notification history snapshot.",
+			Objectives: map[float64]float64{},
+		}),
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(h.metrics.entries, h.metrics.snapshotDuration)
+	}
+
+	if o.SnapshotFile == "" {
+		return h, nil
+	}
+	f, err := os.Open(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	if err := h.loadSnapshot(f); err != nil {
+		return nil, errors.Wrap(err, "failed to load notification history snapshot")
+	}
+	return h, nil
+}
+
+func (h *History) loadSnapshot(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		h.entries = append(h.entries, &e)
+	}
+	h.entries = h.current()
+	h.gc()
+	return nil
+}
+
+// Add records a notification delivery. The entries past MaxEntries are
+// dropped in batches, so that adding an entry doesn't copy all the others.
+func (h *History) Add(e *Entry) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.entries = append(h.entries, e)
+	if h.opts.MaxEntries > 0 && len(h.entries) >= 2*h.opts.MaxEntries {
+		h.entries = append(h.entries[:0:0], h.current()...)
+	}
+}
+
+// current returns the entries kept, leaving out those past MaxEntries which
+// aren't dropped yet. The lock must be held.
+func (h *History) current() []*Entry {
+	if h.opts.MaxEntries > 0 && len(h.entries) > h.opts.MaxEntries {
+		return h.entries[len(h.entries)-h.opts.MaxEntries:]
+	}
+	return h.entries
+}
+
+// Query returns the entries matching the query, from the most recent to the
+// oldest.
+func (h *History) Query(q Query) []Entry {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	res := []Entry{}
+	entries := h.current()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if q.Limit > 0 && len(res) >= q.Limit {
+			break
+		}
+		if q.matches(entries[i]) {
+			res = append(res, *entries[i])
+		}
+	}
+	return res
+}
+
+// GC removes the entries past their retention.
+func (h *History) GC() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.gc()
+}
+
+func (h *History) gc() {
+	cutoff := h.now().Add(-h.opts.Retention)
+	i := 0
+	for i < len(h.entries) && h.entries[i].Timestamp.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		h.entries = append(h.entries[:0:0], h.entries[i:]...)
+	}
+}
+
+// Snapshot writes the entries to w, one JSON object per line.
+func (h *History) Snapshot(w io.Writer) error {
+	start := time.Now()
+	defer func() { h.metrics.snapshotDuration.Observe(time.Since(start).Seconds()) }()
+
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	enc := json.NewEncoder(w)
+	for _, e := range h.current() {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Maintenance garbage collects the history and persists it to the snapshot
+// file every interval, and once more when stopc is closed.
+func (h *History) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	snapshot.Maintain(interval, stopc, func() {
+		h.GC()
+		if h.opts.SnapshotFile == "" {
+			return
+		}
+		if err := snapshot.Write(h.opts.SnapshotFile, func(w io.Writer) error {
+			return h.Snapshot(w)
+		}); err != nil {
+			level.Error(h.logger).Log("msg", "Failed to persist the notification history", "err", err)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHistory(t *testing.T, o Options) *History {
+	if o.Retention == 0 {
+		o.Retention = time.Hour
+	}
+	o.Metrics = prometheus.NewRegistry()
+	h, err := New(o)
+	require.NoError(t, err)
+	return h
+}
+
+func TestHistoryQuery(t *testing.T) {
+	h := newTestHistory(t, Options{})
+	now := time.Now()
+	h.Add(&Entry{Timestamp: now.Add(-3 * time.Minute), Receiver: "team-X", Fingerprints: []string{"0000000000000001"}, Status: StatusSuccess})
+	h.Add(&Entry{Timestamp: now.Add(-2 * time.Minute), Receiver: "team-Y", Fingerprints: []string{"0000000000000002"}, Status: StatusFailure})
+	h.Add(&Entry{Timestamp: now.Add(-time.Minute), Receiver: "team-X", Fingerprints: []string{"0000000000000001", "0000000000000002"}, Status: StatusFailure})
+
+	for _, tc := range []struct {
+		q   Query
+		exp []time.Time
+	}{
+		{
+			q:   Query{},
+			exp: []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute), now.Add(-3 * time.Minute)},
+		},
+		{
+			q:   Query{Receiver: "team-X"},
+			exp: []time.Time{now.Add(-time.Minute), now.Add(-3 * time.Minute)},
+		},
+		{
+			q:   Query{Fingerprint: "0000000000000002"},
+			exp: []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute)},
+		},
+		{
+			q:   Query{Status: StatusFailure, Receiver: "team-Y"},
+			exp: []time.Time{now.Add(-2 * time.Minute)},
+		},
+		{
+			q:   Query{Limit: 2},
+			exp: []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute)},
+		},
+		{
+			q:   Query{Receiver: "team-Z"},
+			exp: []time.Time{},
+		},
+	} {
+		got := []time.Time{}
+		for _, e := range h.Query(tc.q) {
+			got = append(got, e.Timestamp)
+		}
+		require.Equal(t, tc.exp, got, "query %+v", tc.q)
+	}
+}
+
+func TestHistoryMaxEntries(t *testing.T) {
+	h := newTestHistory(t, Options{MaxEntries: 2})
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		h.Add(&Entry{Timestamp: now, Attempts: i})
+	}
+
+	res := h.Query(Query{})
+	require.Len(t, res, 2)
+	require.Equal(t, 2, res[0].Attempts)
+	require.Equal(t, 1, res[1].Attempts)
+	require.Equal(t, 2.0, testutil.ToFloat64(h.metrics.entries))
+
+	// The dropped entries are removed in batches.
+	require.Len(t, h.entries, 3)
+	h.Add(&Entry{Timestamp: now, Attempts: 3})
+	require.Len(t, h.entries, 2)
+	res = h.Query(Query{})
+	require.Len(t, res, 2)
+	require.Equal(t, 3, res[0].Attempts)
+	require.Equal(t, 2, res[1].Attempts)
+}
+
+func TestHistoryGC(t *testing.T) {
+	h := newTestHistory(t, Options{Retention: time.Hour})
+	now := time.Now()
+	h.now = func() time.Time { return now }
+	h.Add(&Entry{Timestamp: now.Add(-2 * time.Hour), Receiver: "old"})
+	h.Add(&Entry{Timestamp: now.Add(-time.Minute), Receiver: "new"})
+
+	h.GC()
+	res := h.Query(Query{})
+	require.Len(t, res, 1)
+	require.Equal(t, "new", res[0].Receiver)
+}
+
+func TestHistorySnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "history")
+	h := newTestHistory(t, Options{SnapshotFile: snapshot})
+	e := Entry{
+		Timestamp:    time.Now().Truncate(time.Second).UTC(),
+		Receiver:     "team-X",
+		Integration:  "webhook",
+		Index:        1,
+		GroupKey:     "{}:{alertname=\"HighLatency\"}",
+		Fingerprints: []string{"0000000000000001"},
+		Status:       StatusFailure,
+		StatusCode:   503,
+		Reason:       "serverError",
+		Error:        "unexpected status code 503",
+		Latency:      1500 * time.Millisecond,
+		Attempts:     3,
+	}
+	h.Add(&e)
+
+	var buf bytes.Buffer
+	require.NoError(t, h.Snapshot(&buf))
+	require.Contains(t, buf.String(), `"latency":1.5`)
+
+	stopc := make(chan struct{})
+	close(stopc)
+	h.Maintenance(time.Hour, stopc)
+
+	loaded := newTestHistory(t, Options{SnapshotFile: snapshot})
+	require.Equal(t, []Entry{e}, loaded.Query(Query{}))
+}
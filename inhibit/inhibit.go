@@ -145,6 +145,22 @@ func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	return false
 }
 
+// InhibitingAlerts returns the fingerprints of the alerts inhibiting the
+// given label set, one for each inhibition rule muting it. Unlike Mutes, it
+// doesn't mark the label set as inhibited.
+func (ih *Inhibitor) InhibitingAlerts(lset model.LabelSet) []model.Fingerprint {
+	var fps []model.Fingerprint
+	for _, r := range ih.rules {
+		if !r.TargetMatchers.Matches(lset) {
+			continue
+		}
+		if inhibitedByFP, eq := r.hasEqual(lset, r.SourceMatchers.Matches(lset)); eq {
+			fps = append(fps, inhibitedByFP)
+		}
+	}
+	return fps
+}
+
 // An InhibitRule specifies that a class of (source) alerts should inhibit
 // notifications for another class of (target) alerts if all specified matching
 // labels are equal between the two alerts. This may be used to inhibit alerts
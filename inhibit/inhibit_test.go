@@ -473,6 +473,9 @@ func TestInhibit(t *testing.T) {
 				}
 				t.Errorf("tc: %d, expected alert with labels %q to be %s", i, expected.lbls, mute)
 			}
+			if inhibiting := inhibitor.InhibitingAlerts(expected.lbls); (len(inhibiting) > 0) != expected.muted {
+				t.Errorf("tc: %d, unexpected alerts inhibiting the alert with labels %q: %v", i, expected.lbls, inhibiting)
+			}
 		}
 	}
 }
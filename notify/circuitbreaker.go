@@ -0,0 +1,143 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+// CircuitBreakerState is the state of a circuit breaker.
+type CircuitBreakerState int
+
+const (
+	// CircuitClosed lets all notifications through.
+	CircuitClosed CircuitBreakerState = iota
+	// CircuitOpen drops all notifications.
+	CircuitOpen
+	// CircuitHalfOpen lets a single notification attempt through to probe
+	// whether the integration has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerStatus is a snapshot of a circuit breaker.
+type CircuitBreakerStatus struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+}
+
+// CircuitBreaker tracks the consecutive failures of an integration. It is
+// shared by all aggregation groups notifying the integration.
+type CircuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+
+	mtx      sync.Mutex
+	state    CircuitBreakerState
+	failures int
+	openedAt time.Time
+	// probing is true while the attempt let through in the half-open state
+	// is in flight.
+	probing bool
+}
+
+// NewCircuitBreaker returns a new closed circuit breaker.
+func NewCircuitBreaker(c *config.CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:    c.FailureThreshold,
+		openDuration: time.Duration(c.OpenDuration),
+	}
+}
+
+// Allow returns whether a notification attempt may be made. Every allowed
+// attempt must be followed by a call to Record.
+func (b *CircuitBreaker) Allow(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record records the outcome of an attempt.
+func (b *CircuitBreaker) Record(err error, now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.probing = false
+	if err == nil {
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = now
+	}
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker) State() CircuitBreakerState {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.state
+}
+
+// Status returns a snapshot of the circuit breaker.
+func (b *CircuitBreaker) Status() CircuitBreakerStatus {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	s := CircuitBreakerStatus{
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != CircuitClosed {
+		openedAt := b.openedAt
+		s.OpenedAt = &openedAt
+	}
+	return s
+}
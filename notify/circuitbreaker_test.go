@@ -0,0 +1,76 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+func newTestCircuitBreaker(t *testing.T) *CircuitBreaker {
+	var c config.CircuitBreakerConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+failure_threshold: 2
+open_duration: 1m
+`), &c))
+	return NewCircuitBreaker(&c)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		b    = newTestCircuitBreaker(t)
+		now  = time.Now()
+		fail = errors.New("fail")
+	)
+
+	// The breaker opens after 2 consecutive failures.
+	require.True(t, b.Allow(now))
+	b.Record(fail, now)
+	require.True(t, b.Allow(now))
+	b.Record(nil, now)
+	require.True(t, b.Allow(now))
+	b.Record(fail, now)
+	require.Equal(t, CircuitClosed, b.State())
+	require.True(t, b.Allow(now))
+	b.Record(fail, now)
+	require.Equal(t, CircuitOpen, b.State())
+	require.Equal(t, CircuitBreakerStatus{State: "open", ConsecutiveFailures: 2, OpenedAt: &now}, b.Status())
+
+	// Attempts are rejected while open.
+	require.False(t, b.Allow(now.Add(30*time.Second)))
+
+	// A single attempt goes through once half-open, a failure opens the
+	// breaker again.
+	now = now.Add(time.Minute)
+	require.True(t, b.Allow(now))
+	require.Equal(t, CircuitHalfOpen, b.State())
+	require.False(t, b.Allow(now))
+	b.Record(fail, now)
+	require.Equal(t, CircuitOpen, b.State())
+	require.False(t, b.Allow(now.Add(30*time.Second)))
+
+	// A successful probe closes the breaker.
+	now = now.Add(time.Minute)
+	require.True(t, b.Allow(now))
+	b.Record(nil, now)
+	require.Equal(t, CircuitClosed, b.State())
+	require.Equal(t, CircuitBreakerStatus{State: "closed"}, b.Status())
+	require.True(t, b.Allow(now))
+}
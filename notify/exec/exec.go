@@ -0,0 +1,202 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// maxOutputBytes is the amount of stdout and stderr kept for logging.
+const maxOutputBytes = 4096
+
+// inheritedEnv lists the environment variables of the Alertmanager process
+// passed to the command. The rest of the environment may hold secrets and
+// isn't exposed.
+var inheritedEnv = []string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+
+// Notifier implements a Notifier running a local command.
+type Notifier struct {
+	conf   *config.ExecConfig
+	tmpl   *template.Template
+	logger log.Logger
+	// sem limits the number of commands running concurrently.
+	sem chan struct{}
+}
+
+// New returns a new exec notifier.
+func New(c *config.ExecConfig, t *template.Template, l log.Logger) (*Notifier, error) {
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		sem:    make(chan struct{}, c.MaxConcurrency),
+	}, nil
+}
+
+// Notify implements the Notifier interface.
+//
+// The command receives the webhook message as JSON on stdin. A zero exit
+// code means success, the exit codes listed in retry_exit_codes as well as
+// timeouts are retried and any other failure is permanent.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	msg := webhook.NewMessage(ctx, n.tmpl, n.logger, n.conf.MaxAlerts, as...)
+	tmpl := notify.TmplText(n.tmpl, msg.Data, &err)
+
+	args := make([]string, 0, len(n.conf.Args))
+	for _, a := range n.conf.Args {
+		args = append(args, tmpl(a))
+	}
+	env := baseEnv()
+	for k, v := range n.conf.Env {
+		env = append(env, k+"="+tmpl(v))
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "templating error")
+	}
+	env = append(env, metadataEnv(key, msg.Data)...)
+
+	stdin, err := json.Marshal(msg)
+	if err != nil {
+		return false, err
+	}
+
+	select {
+	case n.sem <- struct{}{}:
+		defer func() { <-n.sem }()
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	var stdout, stderr limitedBuffer
+	cmd := osexec.CommandContext(ctx, n.conf.Command, args...)
+	cmd.Dir = n.conf.WorkingDir
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait forever for children which inherited the output pipes.
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err = cmd.Run()
+	logger := log.With(n.logger, "incident", key, "command", n.conf.Command, "duration", time.Since(start))
+	if stdout.Len() > 0 {
+		logger = log.With(logger, "stdout", stdout.String())
+	}
+	if stderr.Len() > 0 {
+		logger = log.With(logger, "stderr", stderr.String())
+	}
+
+	if err == nil {
+		level.Info(logger).Log("msg", "command succeeded")
+		return false, nil
+	}
+	level.Warn(logger).Log("msg", "command failed", "err", err)
+
+	if ctx.Err() != nil {
+		return true, errors.Wrapf(ctx.Err(), "command %s", n.conf.Command)
+	}
+	var exitErr *osexec.ExitError
+	if !errors.As(err, &exitErr) {
+		// The command could not be started.
+		return false, err
+	}
+	code := exitErr.ExitCode()
+	err = fmt.Errorf("command %s exited with code %d: %s", n.conf.Command, code, strings.TrimSpace(stderr.String()))
+	for _, c := range n.conf.RetryExitCodes {
+		if c == code {
+			return true, notify.NewErrorWithReason(notify.ServerErrorReason, err)
+		}
+	}
+	return false, notify.NewErrorWithReason(notify.ClientErrorReason, err)
+}
+
+// metadataEnv returns the environment variables describing the notification.
+// baseEnv returns the variables of inheritedEnv set in the environment.
+func baseEnv() []string {
+	var env []string
+	for _, k := range inheritedEnv {
+		if v, ok := os.LookupEnv(k); ok {
+			env = append(env, k+"="+v)
+		}
+	}
+	return env
+}
+
+func metadataEnv(key notify.Key, data *template.Data) []string {
+	env := []string{
+		"ALERTMANAGER_RECEIVER=" + data.Receiver,
+		"ALERTMANAGER_STATUS=" + data.Status,
+		"ALERTMANAGER_GROUP_KEY=" + key.String(),
+		"ALERTMANAGER_GROUP_KEY_HASH=" + key.Hash(),
+		"ALERTMANAGER_ALERTS_FIRING=" + strconv.Itoa(len(data.Alerts.Firing())),
+		"ALERTMANAGER_ALERTS_RESOLVED=" + strconv.Itoa(len(data.Alerts.Resolved())),
+		"ALERTMANAGER_EXTERNAL_URL=" + data.ExternalURL,
+	}
+	for _, p := range data.GroupLabels.SortedPairs() {
+		env = append(env, "ALERTMANAGER_GROUP_LABEL_"+strings.ToUpper(p.Name)+"="+p.Value)
+	}
+	return env
+}
+
+// limitedBuffer keeps the first maxOutputBytes bytes written to it and
+// discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := maxOutputBytes - b.Buffer.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.Buffer.String() + "..."
+	}
+	return b.Buffer.String()
+}
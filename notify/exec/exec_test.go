@@ -0,0 +1,180 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newConfig(script string, args ...string) *config.ExecConfig {
+	c := config.DefaultExecConfig
+	c.Command = "/bin/sh"
+	c.Args = append([]string{"-c", script, "sh"}, args...)
+	return &c
+}
+
+func testContext() context.Context {
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithReceiverName(ctx, "remediation")
+	return notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "DiskFull"})
+}
+
+func testAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "DiskFull", "instance": "db-1"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestExecStdinAndEnv(t *testing.T) {
+	t.Setenv("ALERTMANAGER_TEST_SECRET", "hunter2")
+	dir := t.TempDir()
+	cfg := newConfig(`cat > stdin.json; env > env.txt; echo "$1" > args.txt`, "{{ .CommonLabels.instance }}")
+	cfg.WorkingDir = dir
+	cfg.Env = map[string]string{"TARGET": "{{ .CommonLabels.instance }}"}
+
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(testContext(), testAlert())
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	b, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	require.NoError(t, err)
+	var msg webhook.Message
+	require.NoError(t, json.Unmarshal(b, &msg))
+	require.Equal(t, "4", msg.Version)
+	require.Equal(t, "remediation", msg.Receiver)
+	require.Len(t, msg.Alerts, 1)
+
+	b, err = os.ReadFile(filepath.Join(dir, "env.txt"))
+	require.NoError(t, err)
+	env := strings.Split(string(b), "\n")
+	for _, kv := range []string{
+		"TARGET=db-1",
+		"ALERTMANAGER_RECEIVER=remediation",
+		"ALERTMANAGER_STATUS=firing",
+		"ALERTMANAGER_GROUP_KEY=1",
+		"ALERTMANAGER_GROUP_KEY_HASH=" + notify.Key("1").Hash(),
+		"ALERTMANAGER_ALERTS_FIRING=1",
+		"ALERTMANAGER_ALERTS_RESOLVED=0",
+		"ALERTMANAGER_GROUP_LABEL_ALERTNAME=DiskFull",
+	} {
+		require.Contains(t, env, kv)
+	}
+	require.NotContains(t, env, "ALERTMANAGER_TEST_SECRET=hunter2")
+
+	b, err = os.ReadFile(filepath.Join(dir, "args.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "db-1\n", string(b))
+}
+
+func TestExecExitCodes(t *testing.T) {
+	for _, tc := range []struct {
+		script string
+		retry  bool
+		err    bool
+	}{
+		{script: "exit 0"},
+		{script: "exit 75", retry: true, err: true},
+		{script: "echo boom >&2; exit 1", err: true},
+	} {
+		t.Run(tc.script, func(t *testing.T) {
+			notifier, err := New(newConfig(tc.script), test.CreateTmpl(t), log.NewNopLogger())
+			require.NoError(t, err)
+
+			retry, err := notifier.Notify(testContext(), testAlert())
+			require.Equal(t, tc.retry, retry)
+			if !tc.err {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tc.script == "echo boom >&2; exit 1" {
+				require.Contains(t, err.Error(), "boom")
+			}
+		})
+	}
+}
+
+func TestExecTimeout(t *testing.T) {
+	cfg := newConfig("sleep 10")
+	cfg.Timeout = config.DefaultExecConfig.Timeout / 300
+
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	start := time.Now()
+	retry, err := notifier.Notify(testContext(), testAlert())
+	require.Error(t, err)
+	require.True(t, retry)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestExecMissingCommand(t *testing.T) {
+	cfg := config.DefaultExecConfig
+	cfg.Command = filepath.Join(t.TempDir(), "missing")
+
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := notifier.Notify(testContext(), testAlert())
+	require.Error(t, err)
+	require.False(t, retry)
+}
+
+func TestExecMaxConcurrency(t *testing.T) {
+	dir := t.TempDir()
+	// The command fails if another instance holds the lock.
+	cfg := newConfig("mkdir lock || exit 3; sleep 0.1; rmdir lock")
+	cfg.WorkingDir = dir
+
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = notifier.Notify(testContext(), testAlert())
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+}
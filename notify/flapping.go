@@ -0,0 +1,239 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// flapState is the state of an alert tracked by a FlapDetector.
+type flapState struct {
+	firing   bool
+	startsAt time.Time
+	// changes are the times of the state changes within the window.
+	changes  []time.Time
+	flapping bool
+	// notified holds the receivers notified that the alert is flapping.
+	notified map[string]struct{}
+	seenAt   time.Time
+}
+
+// FlapDetector counts the state changes of alerts over a sliding window to
+// detect the flapping alerts. It is shared by the pipelines of all receivers.
+type FlapDetector struct {
+	marker     types.Marker
+	suppressed prometheus.Counter
+
+	mtx       sync.Mutex
+	window    time.Duration
+	threshold int
+	alerts    map[model.Fingerprint]*flapState
+	gcAt      time.Time
+}
+
+// NewFlapDetector returns a new FlapDetector, disabled until configured. The
+// flapping alerts are marked in the marker.
+func NewFlapDetector(m types.Marker, r prometheus.Registerer) *FlapDetector {
+	d := &FlapDetector{
+		marker: m,
+		suppressed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notifications_flapping_suppressed_total",
+			Help:      "The total number of flapping alerts dropped from notifications.",
+		}),
+		alerts: map[model.Fingerprint]*flapState{},
+	}
+	if r != nil {
+		r.MustRegister(d.suppressed)
+	}
+	return d
+}
+
+// Configure sets the window and the threshold of the detection. A nil
+// configuration disables it.
+func (d *FlapDetector) Configure(c *config.FlapDetectionConfig) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if c == nil {
+		for fp, s := range d.alerts {
+			if s.flapping {
+				d.marker.SetFlapping(fp, false)
+			}
+		}
+		d.alerts = map[model.Fingerprint]*flapState{}
+		d.window, d.threshold = 0, 0
+		return
+	}
+	d.window, d.threshold = time.Duration(c.Window), c.Threshold
+}
+
+// Filter records the state of the alerts at the given time and returns the
+// alerts to notify to the receiver, along with the flapping ones among them.
+// A flapping alert is notified to each receiver until a notification of it
+// is delivered, see Notified, and then only when it resolves until it stops
+// flapping.
+func (d *FlapDetector) Filter(receiver string, now time.Time, alerts ...*types.Alert) ([]*types.Alert, map[model.Fingerprint]bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.threshold == 0 {
+		return alerts, nil
+	}
+	d.gc(now)
+
+	var (
+		res      = make([]*types.Alert, 0, len(alerts))
+		flapping map[model.Fingerprint]bool
+	)
+	for _, a := range alerts {
+		fp := a.Fingerprint()
+		s := d.observe(fp, a, now)
+		if !s.flapping {
+			res = append(res, a)
+			continue
+		}
+		if _, ok := s.notified[receiver]; ok {
+			// The receiver is still notified of the resolved alerts, so
+			// that their groups resolve.
+			if a.ResolvedAt(now) {
+				res = append(res, a)
+				continue
+			}
+			d.suppressed.Inc()
+			continue
+		}
+		if flapping == nil {
+			flapping = map[model.Fingerprint]bool{}
+		}
+		flapping[fp] = true
+		res = append(res, a)
+	}
+	return res, flapping
+}
+
+// Notified records that the flapping alerts were delivered to the receiver.
+func (d *FlapDetector) Notified(receiver string, flapping map[model.Fingerprint]bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for fp := range flapping {
+		if s, ok := d.alerts[fp]; ok && s.flapping {
+			s.notified[receiver] = struct{}{}
+		}
+	}
+}
+
+// observe records the state of the alert and updates whether it is flapping.
+// The lock must be held.
+func (d *FlapDetector) observe(fp model.Fingerprint, a *types.Alert, now time.Time) *flapState {
+	firing := !a.ResolvedAt(now)
+	s, ok := d.alerts[fp]
+	if !ok {
+		s = &flapState{firing: firing, startsAt: a.StartsAt, notified: map[string]struct{}{}}
+		d.alerts[fp] = s
+	}
+	s.seenAt = now
+
+	// A new start time means that the alert fired again, possibly after
+	// resolving unnoticed in between.
+	refired := a.StartsAt.After(s.startsAt)
+	switch {
+	case refired && s.firing:
+		s.changes = append(s.changes, a.StartsAt, a.StartsAt)
+	case refired:
+		s.changes = append(s.changes, a.StartsAt)
+	case firing && !s.firing:
+		s.changes = append(s.changes, now)
+	}
+	if s.firing && !firing || refired && !firing {
+		s.changes = append(s.changes, a.EndsAt)
+	}
+	s.firing, s.startsAt = firing, a.StartsAt
+
+	cutoff := now.Add(-d.window)
+	changes := s.changes[:0]
+	for _, t := range s.changes {
+		if !t.Before(cutoff) {
+			changes = append(changes, t)
+		}
+	}
+	s.changes = changes
+
+	flapping := len(s.changes) >= d.threshold
+	if flapping || s.flapping {
+		d.marker.SetFlapping(fp, flapping)
+	}
+	if !flapping {
+		s.notified = map[string]struct{}{}
+	}
+	s.flapping = flapping
+	return s
+}
+
+// gc removes the alerts not seen within the window. The lock must be held.
+func (d *FlapDetector) gc(now time.Time) {
+	if now.Sub(d.gcAt) < d.window {
+		return
+	}
+	d.gcAt = now
+	for fp, s := range d.alerts {
+		if now.Sub(s.seenAt) > d.window {
+			if s.flapping {
+				d.marker.SetFlapping(fp, false)
+			}
+			delete(d.alerts, fp)
+		}
+	}
+}
+
+// FlapStage suppresses the notifications of flapping alerts. Once a receiver
+// is notified that an alert is flapping, it is only notified again that the
+// alert resolved until the alert stops flapping.
+type FlapStage struct {
+	detector *FlapDetector
+}
+
+// NewFlapStage returns a new FlapStage.
+func NewFlapStage(d *FlapDetector) *FlapStage {
+	return &FlapStage{detector: d}
+}
+
+// Exec implements the Stage interface.
+func (n *FlapStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	recv, ok := ReceiverName(ctx)
+	if !ok {
+		return ctx, nil, errors.New("receiver missing")
+	}
+	now, ok := Now(ctx)
+	if !ok {
+		return ctx, nil, errors.New("missing now timestamp")
+	}
+
+	alerts, flapping := n.detector.Filter(recv, now, alerts...)
+	if len(flapping) > 0 {
+		ctx = WithFlapping(ctx, flapping)
+	}
+	return ctx, alerts, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func flappingAlert(startsAt, endsAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "Flappy"},
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+		},
+	}
+}
+
+func TestFlapDetector(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	d := NewFlapDetector(marker, prometheus.NewRegistry())
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// The detection is disabled until configured.
+	a := flappingAlert(now, now.Add(-time.Minute))
+	res, flapping := d.Filter("team-X", now, a)
+	require.Equal(t, []*types.Alert{a}, res)
+	require.Nil(t, flapping)
+
+	d.Configure(&config.FlapDetectionConfig{Window: model.Duration(time.Hour), Threshold: 3})
+
+	// Firing, resolved, firing again and resolved: 3 state changes.
+	states := []*types.Alert{
+		flappingAlert(now, now.Add(time.Hour)),
+		flappingAlert(now, now.Add(5*time.Minute)),
+		flappingAlert(now.Add(10*time.Minute), now.Add(time.Hour)),
+		flappingAlert(now.Add(10*time.Minute), now.Add(15*time.Minute)),
+	}
+	for i, a := range states[:3] {
+		res, flapping := d.Filter("team-X", now.Add(time.Duration(i)*6*time.Minute), a)
+		require.Equal(t, []*types.Alert{a}, res)
+		require.Nil(t, flapping)
+	}
+	fp := states[0].Fingerprint()
+	require.False(t, marker.Flapping(fp))
+
+	// The flapping alert is notified to each receiver until it is delivered.
+	now = now.Add(20 * time.Minute)
+	res, flapping = d.Filter("team-X", now, states[3])
+	require.Equal(t, []*types.Alert{states[3]}, res)
+	require.Equal(t, map[model.Fingerprint]bool{fp: true}, flapping)
+	require.True(t, marker.Flapping(fp))
+
+	res, flapping = d.Filter("team-X", now, states[3])
+	require.Equal(t, []*types.Alert{states[3]}, res)
+	require.Equal(t, map[model.Fingerprint]bool{fp: true}, flapping)
+
+	// Once notified, the receiver is still notified that the alert
+	// resolved...
+	d.Notified("team-X", flapping)
+	res, flapping = d.Filter("team-X", now, states[3])
+	require.Equal(t, []*types.Alert{states[3]}, res)
+	require.Nil(t, flapping)
+	require.Equal(t, 0.0, testutil.ToFloat64(d.suppressed))
+
+	// ... but not that it fires again.
+	refiring := flappingAlert(now, now.Add(time.Hour))
+	res, flapping = d.Filter("team-X", now, refiring)
+	require.Empty(t, res)
+	require.Nil(t, flapping)
+	require.Equal(t, 1.0, testutil.ToFloat64(d.suppressed))
+
+	res, flapping = d.Filter("team-Y", now, refiring)
+	require.Equal(t, []*types.Alert{refiring}, res)
+	require.Equal(t, map[model.Fingerprint]bool{fp: true}, flapping)
+
+	// The alert stops flapping once the state changes leave the window.
+	now = now.Add(time.Hour)
+	res, flapping = d.Filter("team-X", now, states[3])
+	require.Equal(t, []*types.Alert{states[3]}, res)
+	require.Nil(t, flapping)
+	require.False(t, marker.Flapping(fp))
+
+	// A resolve and refire between two observations counts for two changes.
+	refired := flappingAlert(now, now.Add(time.Hour))
+	d.Filter("team-X", now, refired)
+	refired = flappingAlert(now.Add(5*time.Minute), now.Add(time.Hour))
+	d.Filter("team-X", now.Add(5*time.Minute), refired)
+	require.True(t, marker.Flapping(fp))
+
+	// Disabling the detection clears the marks.
+	d.Configure(nil)
+	require.False(t, marker.Flapping(fp))
+}
+
+func TestFlapStage(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	d := NewFlapDetector(marker, prometheus.NewRegistry())
+	d.Configure(&config.FlapDetectionConfig{Window: model.Duration(time.Hour), Threshold: 2})
+	stage := NewFlapStage(d)
+
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	a := flappingAlert(now, now.Add(time.Hour))
+
+	_, _, err := stage.Exec(context.Background(), log.NewNopLogger(), a)
+	require.EqualError(t, err, "receiver missing")
+	ctx := WithReceiverName(context.Background(), "team-X")
+	_, _, err = stage.Exec(ctx, log.NewNopLogger(), a)
+	require.EqualError(t, err, "missing now timestamp")
+
+	ctx, res, err := stage.Exec(WithNow(ctx, now), log.NewNopLogger(), a)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a}, res)
+	_, ok := Flapping(ctx)
+	require.False(t, ok)
+
+	// Resolved and fired again.
+	a = flappingAlert(now.Add(10*time.Minute), now.Add(time.Hour))
+	ctx = WithReceiverName(context.Background(), "team-X")
+	ctx, res, err = stage.Exec(WithNow(ctx, now.Add(10*time.Minute)), log.NewNopLogger(), a)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a}, res)
+	flapping, ok := Flapping(ctx)
+	require.True(t, ok)
+	require.Equal(t, map[model.Fingerprint]bool{a.Fingerprint(): true}, flapping)
+
+	// The flapping alerts are passed to the templates.
+	tmpl, err := template.FromGlobs()
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+	data := GetTemplateData(WithGroupLabels(ctx, model.LabelSet{}), tmpl, res, log.NewNopLogger())
+	require.True(t, data.Alerts[0].Flapping)
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notifier implements a Notifier producing records to a Kafka topic.
+type Notifier struct {
+	conf   *config.KafkaConfig
+	tmpl   *template.Template
+	logger log.Logger
+	client *notify.SharedClient[*kgo.Client]
+}
+
+// New returns a new Kafka notifier.
+func New(c *config.KafkaConfig, t *template.Template, l log.Logger) (*Notifier, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(c.Brokers...),
+		kgo.DefaultProduceTopic(c.Topic),
+		kgo.ProducerBatchCompression(compression(c.Compression)),
+	}
+
+	switch c.Acks {
+	case config.KafkaAcksLeader:
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	case config.KafkaAcksNone:
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	default:
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+
+	if c.TLSConfig != nil {
+		tlsConfig, err := commoncfg.NewTLSConfig(c.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	if c.SASL != nil {
+		var m sasl.Mechanism
+		switch c.SASL.Mechanism {
+		case "PLAIN":
+			m = plain.Auth{User: c.SASL.Username, Pass: string(c.SASL.Password)}.AsMechanism()
+		case "SCRAM-SHA-256":
+			m = scram.Auth{User: c.SASL.Username, Pass: string(c.SASL.Password)}.AsSha256Mechanism()
+		case "SCRAM-SHA-512":
+			m = scram.Auth{User: c.SASL.Username, Pass: string(c.SASL.Password)}.AsSha512Mechanism()
+		}
+		opts = append(opts, kgo.SASL(m))
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: notify.NewSharedClient(func(context.Context) (*kgo.Client, error) {
+			return kgo.NewClient(opts...)
+		}, (*kgo.Client).Close),
+	}, nil
+}
+
+// Close closes the Kafka client. It implements the io.Closer interface.
+func (n *Notifier) Close() error {
+	return n.client.Close()
+}
+
+func compression(codec string) kgo.CompressionCodec {
+	switch codec {
+	case "gzip":
+		return kgo.GzipCompression()
+	case "snappy":
+		return kgo.SnappyCompression()
+	case "lz4":
+		return kgo.Lz4Compression()
+	case "zstd":
+		return kgo.ZstdCompression()
+	default:
+		return kgo.NoCompression()
+	}
+}
+
+// Notify implements the Notifier interface.
+//
+// The record is keyed on the hash of the group key so that all notifications
+// of an alert group land in the same partition and stay ordered.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := n.value(ctx, as...)
+	if err != nil {
+		return false, err
+	}
+
+	// The client reconnects to the brokers on its own.
+	client, release, err := n.client.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	record := &kgo.Record{Key: []byte(key.Hash()), Value: value}
+	if err := client.ProduceSync(ctx, record).FirstErr(); err != nil {
+		level.Debug(n.logger).Log("msg", "failed to produce record", "incident", key, "err", err)
+		if ctx.Err() != nil || kerr.IsRetriable(err) {
+			return true, notify.NewErrorWithReason(notify.ServerErrorReason, err)
+		}
+		var kErr *kerr.Error
+		if errors.As(err, &kErr) {
+			return false, notify.NewErrorWithReason(notify.ClientErrorReason, err)
+		}
+		// Errors outside of the Kafka protocol are connection or client
+		// failures which may go away on their own.
+		return true, err
+	}
+	return false, nil
+}
+
+// value returns the record value, either the rendered message template or
+// the webhook message encoded as JSON.
+func (n *Notifier) value(ctx context.Context, as ...*types.Alert) ([]byte, error) {
+	msg := webhook.NewMessage(ctx, n.tmpl, n.logger, n.conf.MaxAlerts, as...)
+	if n.conf.Message == "" {
+		return json.Marshal(msg)
+	}
+
+	var err error
+	value := notify.TmplText(n.tmpl, msg.Data, &err)(n.conf.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "templating error")
+	}
+	return []byte(value), nil
+}
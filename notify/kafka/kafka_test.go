@@ -0,0 +1,172 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newCluster(t *testing.T, opts ...kfake.Opt) *kfake.Cluster {
+	t.Helper()
+	cluster, err := kfake.NewCluster(append([]kfake.Opt{kfake.NumBrokers(1), kfake.SeedTopics(3, "alerts")}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+// consume returns the first n records of the alerts topic.
+func consume(t *testing.T, cluster *kfake.Cluster, n int, opts ...kgo.Opt) []*kgo.Record {
+	t.Helper()
+	client, err := kgo.NewClient(append([]kgo.Opt{
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("alerts"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	}, opts...)...)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < n {
+		fetches := client.PollFetches(ctx)
+		require.NoError(t, ctx.Err())
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
+
+func newConfig(cluster *kfake.Cluster) *config.KafkaConfig {
+	c := config.DefaultKafkaConfig
+	c.Brokers = cluster.ListenAddrs()
+	c.Topic = "alerts"
+	return &c
+}
+
+func testAlerts() []*types.Alert {
+	return []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "instance": "a"},
+				StartsAt: time.Now(),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+		},
+		{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "instance": "b"},
+				StartsAt: time.Now(),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+		},
+	}
+}
+
+func TestKafkaWebhookMessage(t *testing.T) {
+	cluster := newCluster(t)
+
+	cfg := newConfig(cluster)
+	cfg.Compression = "zstd"
+	cfg.MaxAlerts = 1
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	retry, err := notifier.Notify(ctx, testAlerts()...)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	records := consume(t, cluster, 1)
+	require.Equal(t, notify.Key("1").Hash(), string(records[0].Key))
+
+	var msg webhook.Message
+	require.NoError(t, json.Unmarshal(records[0].Value, &msg))
+	require.Equal(t, "4", msg.Version)
+	require.Equal(t, "1", msg.GroupKey)
+	require.Equal(t, uint64(1), msg.TruncatedAlerts)
+	require.Len(t, msg.Alerts, 1)
+	require.Equal(t, "HighLatency", msg.GroupLabels["alertname"])
+}
+
+func TestKafkaMessageTemplate(t *testing.T) {
+	cluster := newCluster(t)
+
+	cfg := newConfig(cluster)
+	cfg.Acks = config.KafkaAcksLeader
+	cfg.Message = `{{ .Status }} {{ len .Alerts }}`
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	for i := 0; i < 2; i++ {
+		_, err = notifier.Notify(ctx, testAlerts()...)
+		require.NoError(t, err)
+	}
+
+	// Notifications for the same group are produced to the same partition.
+	records := consume(t, cluster, 2)
+	require.Equal(t, "firing 2", string(records[0].Value))
+	require.Equal(t, records[0].Partition, records[1].Partition)
+}
+
+func TestKafkaSASL(t *testing.T) {
+	cluster := newCluster(t, kfake.EnableSASL(), kfake.Superuser("SCRAM-SHA-256", "alertmanager", "secret"))
+
+	cfg := newConfig(cluster)
+	cfg.SASL = &config.KafkaSASLConfig{Mechanism: "SCRAM-SHA-256", Username: "alertmanager", Password: "secret"}
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, testAlerts()...)
+	require.NoError(t, err)
+
+	cfg.SASL.Password = "wrong"
+	cfg.Timeout = config.DefaultKafkaConfig.Timeout / 50
+	notifier, err = New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	_, err = notifier.Notify(ctx, testAlerts()...)
+	require.Error(t, err)
+}
+
+func TestKafkaUnreachable(t *testing.T) {
+	cfg := config.DefaultKafkaConfig
+	cfg.Brokers = []string{"127.0.0.1:1"}
+	cfg.Topic = "alerts"
+	cfg.Timeout = config.DefaultKafkaConfig.Timeout / 50
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, testAlerts()...)
+	require.Error(t, err)
+	require.True(t, retry)
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notifier implements a Notifier publishing to an MQTT topic.
+type Notifier struct {
+	conf      *config.MQTTConfig
+	tmpl      *template.Template
+	logger    log.Logger
+	tlsConfig *tls.Config
+	client    *notify.SharedClient[paho.Client]
+}
+
+// New returns a new MQTT notifier.
+func New(c *config.MQTTConfig, t *template.Template, l log.Logger) (*Notifier, error) {
+	n := &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+	}
+	if c.TLSConfig != nil {
+		tlsConfig, err := commoncfg.NewTLSConfig(c.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		n.tlsConfig = tlsConfig
+	}
+	n.client = notify.NewSharedClient(n.connect, func(c paho.Client) { c.Disconnect(250) })
+	return n, nil
+}
+
+// connect returns a client connected to the broker.
+func (n *Notifier) connect(ctx context.Context) (paho.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	opts := paho.NewClientOptions().
+		AddBroker(n.conf.Broker).
+		SetClientID(n.conf.ClientID).
+		SetUsername(n.conf.Username).
+		SetPassword(string(n.conf.Password)).
+		SetConnectTimeout(time.Duration(n.conf.Timeout)).
+		SetAutoReconnect(false)
+	if n.tlsConfig != nil {
+		opts.SetTLSConfig(n.tlsConfig)
+	}
+
+	client := paho.NewClient(opts)
+	if err := wait(ctx, client.Connect()); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// Close disconnects from the broker. It implements the io.Closer interface.
+func (n *Notifier) Close() error {
+	return n.client.Close()
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	msg := webhook.NewMessage(ctx, n.tmpl, n.logger, n.conf.MaxAlerts, as...)
+	tmpl := notify.TmplText(n.tmpl, msg.Data, &err)
+
+	topic := strings.TrimSpace(tmpl(n.conf.Topic))
+	var payload []byte
+	if n.conf.Message != "" {
+		payload = []byte(tmpl(n.conf.Message))
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "templating error")
+	}
+	// Wildcards are only valid in subscriptions.
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return false, errors.Errorf("invalid topic %q after templating", topic)
+	}
+	if payload == nil {
+		if payload, err = json.Marshal(msg); err != nil {
+			return false, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	client, release, err := n.client.Get(ctx)
+	if err != nil {
+		return n.checkErr(key, err)
+	}
+	defer release()
+
+	// With QoS 1 and 2 the token completes once the broker acknowledged
+	// the message.
+	if err := wait(ctx, client.Publish(topic, n.conf.QoS, n.conf.Retain, payload)); err != nil {
+		n.client.Reset(client)
+		return n.checkErr(key, err)
+	}
+	return false, nil
+}
+
+func wait(ctx context.Context, t paho.Token) error {
+	select {
+	case <-t.Done():
+		return t.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) checkErr(key notify.Key, err error) (bool, error) {
+	level.Debug(n.logger).Log("msg", "failed to publish message", "incident", key, "err", err)
+	if errors.Is(err, packets.ErrorRefusedBadUsernameOrPassword) || errors.Is(err, packets.ErrorRefusedNotAuthorised) {
+		return false, notify.NewErrorWithReason(notify.ClientErrorReason, err)
+	}
+	return true, err
+}
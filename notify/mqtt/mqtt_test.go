@@ -0,0 +1,138 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// runBroker starts an in-process broker and returns it with its tcp:// URL.
+func runBroker(t *testing.T, authHook mochi.Hook, authConfig any) (*mochi.Server, string) {
+	t.Helper()
+	s := mochi.New(&mochi.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, s.AddHook(authHook, authConfig))
+
+	l := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	require.NoError(t, s.AddListener(l))
+	require.NoError(t, s.Serve())
+	t.Cleanup(func() { s.Close() })
+	return s, "tcp://" + l.Address()
+}
+
+func testAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "site": "edge-1"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestMQTTPublish(t *testing.T) {
+	s, broker := runBroker(t, new(auth.AllowHook), nil)
+
+	cfg := config.DefaultMQTTConfig
+	cfg.Broker = broker
+	cfg.Topic = "alerts/{{ .CommonLabels.site }}"
+	cfg.Retain = true
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	// The message is retained and delivered to later subscribers.
+	received := make(chan packets.Packet, 1)
+	require.NoError(t, s.Subscribe("alerts/#", 1, func(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+		received <- pk
+	}))
+
+	select {
+	case pk := <-received:
+		require.Equal(t, "alerts/edge-1", pk.TopicName)
+		require.True(t, pk.FixedHeader.Retain)
+
+		var msg webhook.Message
+		require.NoError(t, json.Unmarshal(pk.Payload, &msg))
+		require.Equal(t, "4", msg.Version)
+		require.Equal(t, "1", msg.GroupKey)
+		require.Len(t, msg.Alerts, 1)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestMQTTErrors(t *testing.T) {
+	_, broker := runBroker(t, new(auth.Hook), &auth.Options{
+		Ledger: &auth.Ledger{
+			Users: auth.Users{"alertmanager": {Username: "alertmanager", Password: "secret"}},
+		},
+	})
+
+	cfg := config.DefaultMQTTConfig
+	cfg.Broker = broker
+	cfg.Topic = "alerts/{{ .CommonLabels.site }}/#"
+	cfg.Username = "alertmanager"
+	cfg.Password = "secret"
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.False(t, retry)
+
+	// Rejected credentials are not retried.
+	cfg.Topic = "alerts"
+	cfg.Password = "wrong"
+	notifier, err = New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	retry, err = notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.False(t, retry)
+
+	// Connection failures are retried.
+	cfg.Broker = "tcp://127.0.0.1:1"
+	cfg.Password = "secret"
+	notifier, err = New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	retry, err = notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.True(t, retry)
+}
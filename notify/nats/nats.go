@@ -0,0 +1,140 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notifier implements a Notifier publishing to a NATS subject.
+type Notifier struct {
+	conf   *config.NATSConfig
+	tmpl   *template.Template
+	logger log.Logger
+	conn   *notify.SharedClient[*nats.Conn]
+}
+
+// New returns a new NATS notifier.
+func New(c *config.NATSConfig, t *template.Template, l log.Logger) (*Notifier, error) {
+	opts := []nats.Option{
+		nats.Name("alertmanager"),
+		nats.Timeout(time.Duration(c.Timeout)),
+		nats.NoReconnect(),
+	}
+	switch {
+	case c.Username != "":
+		opts = append(opts, nats.UserInfo(c.Username, string(c.Password)))
+	case c.Token != "":
+		opts = append(opts, nats.Token(string(c.Token)))
+	case c.CredentialsFile != "":
+		opts = append(opts, nats.UserCredentials(c.CredentialsFile))
+	}
+	if c.TLSConfig != nil {
+		tlsConfig, err := commoncfg.NewTLSConfig(c.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		conn: notify.NewSharedClient(func(context.Context) (*nats.Conn, error) {
+			return nats.Connect(strings.Join(c.Servers, ","), opts...)
+		}, (*nats.Conn).Close),
+	}, nil
+}
+
+// Close closes the NATS connection. It implements the io.Closer interface.
+func (n *Notifier) Close() error {
+	return n.conn.Close()
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	msg := webhook.NewMessage(ctx, n.tmpl, n.logger, n.conf.MaxAlerts, as...)
+	tmpl := notify.TmplText(n.tmpl, msg.Data, &err)
+
+	subject := strings.TrimSpace(tmpl(n.conf.Subject))
+	var payload []byte
+	if n.conf.Message != "" {
+		payload = []byte(tmpl(n.conf.Message))
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "templating error")
+	}
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return false, errors.Errorf("invalid subject %q after templating", subject)
+	}
+	if payload == nil {
+		if payload, err = json.Marshal(msg); err != nil {
+			return false, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(n.conf.Timeout))
+	defer cancel()
+
+	nc, release, err := n.conn.Get(ctx)
+	if err != nil {
+		return n.checkErr(key, err)
+	}
+	defer release()
+
+	if err := nc.PublishMsg(&nats.Msg{Subject: subject, Data: payload}); err != nil {
+		n.conn.Reset(nc)
+		return n.checkErr(key, err)
+	}
+	// Flushing waits for the server to process the message, which surfaces
+	// permission violations and connection failures.
+	if err := nc.FlushWithContext(ctx); err != nil {
+		n.conn.Reset(nc)
+		return n.checkErr(key, err)
+	}
+	if err := nc.LastError(); err != nil {
+		n.conn.Reset(nc)
+		return n.checkErr(key, err)
+	}
+	return false, nil
+}
+
+func (n *Notifier) checkErr(key notify.Key, err error) (bool, error) {
+	level.Debug(n.logger).Log("msg", "failed to publish message", "incident", key, "err", err)
+	if errors.Is(err, nats.ErrAuthorization) || strings.Contains(err.Error(), nats.PERMISSIONS_ERR) {
+		return false, notify.NewErrorWithReason(notify.ClientErrorReason, err)
+	}
+	return true, err
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func runServer(t *testing.T, configure func(*server.Options)) *server.Server {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	if configure != nil {
+		configure(&opts)
+	}
+	s := natsserver.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+func subscribe(t *testing.T, s *server.Server, subject string, opts ...nats.Option) *nats.Subscription {
+	t.Helper()
+	nc, err := nats.Connect(s.ClientURL(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	sub, err := nc.SubscribeSync(subject)
+	require.NoError(t, err)
+	require.NoError(t, nc.Flush())
+	return sub
+}
+
+func testAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "site": "edge-1"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestNATSWebhookMessage(t *testing.T) {
+	s := runServer(t, nil)
+	sub := subscribe(t, s, "alerts.>")
+
+	cfg := config.DefaultNATSConfig
+	cfg.Servers = []string{s.ClientURL()}
+	cfg.Subject = "alerts.{{ .Receiver }}.{{ .CommonLabels.site }}"
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithReceiverName(ctx, "edge")
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	m, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "alerts.edge.edge-1", m.Subject)
+
+	var msg webhook.Message
+	require.NoError(t, json.Unmarshal(m.Data, &msg))
+	require.Equal(t, "4", msg.Version)
+	require.Equal(t, "1", msg.GroupKey)
+	require.Len(t, msg.Alerts, 1)
+}
+
+func TestNATSMessageTemplate(t *testing.T) {
+	s := runServer(t, func(o *server.Options) { o.Authorization = "s3cr3t" })
+	sub := subscribe(t, s, "alerts", nats.Token("s3cr3t"))
+
+	cfg := config.DefaultNATSConfig
+	cfg.Servers = []string{s.ClientURL()}
+	cfg.Subject = "alerts"
+	cfg.Token = "s3cr3t"
+	cfg.Message = "{{ .Status }}: {{ .CommonLabels.alertname }}"
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	m, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "firing: HighLatency", string(m.Data))
+}
+
+func TestNATSErrors(t *testing.T) {
+	s := runServer(t, func(o *server.Options) {
+		o.Username = "alertmanager"
+		o.Password = "secret"
+	})
+
+	cfg := config.DefaultNATSConfig
+	cfg.Servers = []string{s.ClientURL()}
+	cfg.Subject = "{{ .CommonLabels.missing }}"
+	cfg.Username = "alertmanager"
+	cfg.Password = "secret"
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.False(t, retry)
+
+	// Authorization failures are not retried.
+	cfg.Subject = "alerts"
+	cfg.Password = "wrong"
+	notifier, err = New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	retry, err = notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.False(t, retry)
+
+	// Connection failures are retried.
+	s.Shutdown()
+	cfg.Password = "secret"
+	notifier, err = New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	retry, err = notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.True(t, retry)
+}
@@ -16,8 +16,12 @@ package notify
 import (
 	"context"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -27,15 +31,26 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
-
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/digest"
+	"github.com/prometheus/alertmanager/events"
+	"github.com/prometheus/alertmanager/history"
 	"github.com/prometheus/alertmanager/inhibit"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/tracing"
 	"github.com/prometheus/alertmanager/types"
 )
 
+var tracer = otel.Tracer("github.com/prometheus/alertmanager/notify")
+
 // ResolvedSender returns true if resolved notifications should be sent.
 type ResolvedSender interface {
 	SendResolved() bool
@@ -63,18 +78,41 @@ type Notifier interface {
 type Integration struct {
 	notifier Notifier
 	rs       ResolvedSender
+	retry    *config.RetryConfig
+	breaker  *CircuitBreaker
 	name     string
 	idx      int
 }
 
-// NewIntegration returns a new integration.
+// retryConfigurer is implemented by notifier configurations carrying a retry
+// policy.
+type retryConfigurer interface {
+	RetryConfig() *config.RetryConfig
+}
+
+// circuitBreakerConfigurer is implemented by notifier configurations carrying
+// a circuit breaker configuration.
+type circuitBreakerConfigurer interface {
+	CircuitBreakerConfig() *config.CircuitBreakerConfig
+}
+
+// NewIntegration returns a new integration. If rs carries a retry policy, it
+// is honored when retrying failed notifications, and if it carries a circuit
+// breaker configuration, the integration gets a circuit breaker.
 func NewIntegration(notifier Notifier, rs ResolvedSender, name string, idx int) Integration {
-	return Integration{
+	i := Integration{
 		notifier: notifier,
 		rs:       rs,
 		name:     name,
 		idx:      idx,
 	}
+	if rc, ok := rs.(retryConfigurer); ok {
+		i.retry = rc.RetryConfig()
+	}
+	if cc, ok := rs.(circuitBreakerConfigurer); ok && cc.CircuitBreakerConfig() != nil {
+		i.breaker = NewCircuitBreaker(cc.CircuitBreakerConfig())
+	}
+	return i
 }
 
 // Notify implements the Notifier interface.
@@ -82,6 +120,16 @@ func (i *Integration) Notify(ctx context.Context, alerts ...*types.Alert) (bool,
 	return i.notifier.Notify(ctx, alerts...)
 }
 
+// Close releases the resources held by the notifier, such as its
+// connections. It is called once the integration is replaced by a
+// configuration reload.
+func (i *Integration) Close() error {
+	if c, ok := i.notifier.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // SendResolved implements the ResolvedSender interface.
 func (i *Integration) SendResolved() bool {
 	return i.rs.SendResolved()
@@ -97,6 +145,12 @@ func (i *Integration) Index() int {
 	return i.idx
 }
 
+// CircuitBreaker returns the circuit breaker of the integration, nil if it
+// has none.
+func (i *Integration) CircuitBreaker() *CircuitBreaker {
+	return i.breaker
+}
+
 // String implements the Stringer interface.
 func (i *Integration) String() string {
 	return fmt.Sprintf("%s[%d]", i.name, i.idx)
@@ -115,6 +169,13 @@ const (
 	keyResolvedAlerts
 	keyNow
 	keyMuteTimeIntervals
+	keyStatusCode
+	keyAcknowledgements
+	keyDigest
+	keyFlapping
+	keyRenotify
+	keyOutboxReplay
+	keyFallbackClaim
 )
 
 // WithReceiverName populates a context with a receiver name.
@@ -157,6 +218,70 @@ func WithMuteTimeIntervals(ctx context.Context, mt []string) context.Context {
 	return context.WithValue(ctx, keyMuteTimeIntervals, mt)
 }
 
+// WithAcknowledgements populates a context with the acknowledgements of the
+// alerts.
+func WithAcknowledgements(ctx context.Context, acks map[model.Fingerprint]*types.Acknowledgement) context.Context {
+	return context.WithValue(ctx, keyAcknowledgements, acks)
+}
+
+// WithDigest populates a context with the window of the digest being notified.
+func WithDigest(ctx context.Context, w *digest.Window) context.Context {
+	return context.WithValue(ctx, keyDigest, w)
+}
+
+// WithFlapping populates a context with the flapping alerts.
+func WithFlapping(ctx context.Context, flapping map[model.Fingerprint]bool) context.Context {
+	return context.WithValue(ctx, keyFlapping, flapping)
+}
+
+// WithRenotify populates a context with whether the alerts are notified
+// again even if they were already.
+func WithRenotify(ctx context.Context, renotify bool) context.Context {
+	return context.WithValue(ctx, keyRenotify, renotify)
+}
+
+// withOutboxReplay marks a context as the replay of an outbox entry.
+func withOutboxReplay(ctx context.Context, e *outbox.Entry) context.Context {
+	return context.WithValue(ctx, keyOutboxReplay, e)
+}
+
+// outboxReplay extracts the replayed outbox entry from the context. Iff none
+// exists, the second argument is false.
+func outboxReplay(ctx context.Context) (*outbox.Entry, bool) {
+	v, ok := ctx.Value(keyOutboxReplay).(*outbox.Entry)
+	return v, ok
+}
+
+// isOutboxReplay returns whether the context is the replay of an outbox
+// entry.
+func isOutboxReplay(ctx context.Context) bool {
+	_, ok := outboxReplay(ctx)
+	return ok
+}
+
+// statusCodeRecorder holds the HTTP status code of the last request sent by a
+// notifier.
+type statusCodeRecorder struct {
+	code atomic.Int64
+}
+
+// withStatusCodeRecorder populates a context with a recorder of HTTP status
+// codes.
+func withStatusCodeRecorder(ctx context.Context) (context.Context, *statusCodeRecorder) {
+	r := &statusCodeRecorder{}
+	return context.WithValue(ctx, keyStatusCode, r), r
+}
+
+// RecordStatusCode records the HTTP status code of the response to a
+// notification request, for the notification history. The request helpers of
+// this package call it, notifiers sending requests otherwise should call it
+// themselves.
+func RecordStatusCode(ctx context.Context, code int) {
+	if r, ok := ctx.Value(keyStatusCode).(*statusCodeRecorder); ok {
+		r.code.Store(int64(code))
+	}
+}
+
 // RepeatInterval extracts a repeat interval from the context. Iff none exists, the
 // second argument is false.
 func RepeatInterval(ctx context.Context) (time.Duration, bool) {
@@ -206,6 +331,34 @@ func ResolvedAlerts(ctx context.Context) ([]uint64, bool) {
 	return v, ok
 }
 
+// Acknowledgements extracts the acknowledgements of the alerts from the
+// context. Iff none exists, the second argument is false.
+func Acknowledgements(ctx context.Context) (map[model.Fingerprint]*types.Acknowledgement, bool) {
+	v, ok := ctx.Value(keyAcknowledgements).(map[model.Fingerprint]*types.Acknowledgement)
+	return v, ok
+}
+
+// Digest extracts the window of the digest being notified from the context.
+// Iff none exists, the second argument is false.
+func Digest(ctx context.Context) (*digest.Window, bool) {
+	v, ok := ctx.Value(keyDigest).(*digest.Window)
+	return v, ok
+}
+
+// Flapping extracts the flapping alerts from the context. Iff none exists, the
+// second argument is false.
+func Flapping(ctx context.Context) (map[model.Fingerprint]bool, bool) {
+	v, ok := ctx.Value(keyFlapping).(map[model.Fingerprint]bool)
+	return v, ok
+}
+
+// Renotify extracts from the context whether the alerts are notified again
+// even if they were already. Iff none exists, the second argument is false.
+func Renotify(ctx context.Context) (bool, bool) {
+	v, ok := ctx.Value(keyRenotify).(bool)
+	return v, ok
+}
+
 // MuteTimeIntervalNames extracts a slice of mute time names from the context. Iff none exists, the
 // second argument is false.
 func MuteTimeIntervalNames(ctx context.Context) ([]string, bool) {
@@ -237,6 +390,12 @@ type Metrics struct {
 	numNotificationRequestsTotal       *prometheus.CounterVec
 	numNotificationRequestsFailedTotal *prometheus.CounterVec
 	notificationLatencySeconds         *prometheus.HistogramVec
+	numNotificationRetriesTotal        *prometheus.CounterVec
+	numNotificationRetriesExhausted    *prometheus.CounterVec
+	circuitBreakerState                *prometheus.GaugeVec
+	numCircuitBreakerShortCircuits     *prometheus.CounterVec
+	numFallbacks                       *prometheus.CounterVec
+	numFallbacksFailed                 *prometheus.CounterVec
 }
 
 func NewMetrics(r prometheus.Registerer) *Metrics {
@@ -267,6 +426,36 @@ func NewMetrics(r prometheus.Registerer) *Metrics {
 			Help:      "The latency of notifications in seconds.",
 			Buckets:   []float64{1, 5, 10, 15, 20},
 		}, []string{"integration"}),
+		numNotificationRetriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_retries_total",
+			Help:      "The total number of notification requests retried after a failure.",
+		}, []string{"integration", "reason"}),
+		numNotificationRetriesExhausted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_retries_exhausted_total",
+			Help:      "The total number of notifications that failed after reaching the maximum number of attempts.",
+		}, []string{"integration"}),
+		circuitBreakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_circuit_breaker_state",
+			Help:      "The state of the circuit breaker of integrations (0 = closed, 1 = open, 2 = half-open).",
+		}, []string{"receiver", "integration", "index"}),
+		numCircuitBreakerShortCircuits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_circuit_breaker_short_circuits_total",
+			Help:      "The total number of notifications dropped because the circuit breaker was open.",
+		}, []string{"integration"}),
+		numFallbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_fallbacks_total",
+			Help:      "The total number of failed notifications dispatched to the fallback receiver.",
+		}, []string{"receiver", "fallback_receiver"}),
+		numFallbacksFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_fallbacks_failed_total",
+			Help:      "The total number of failed notifications the fallback receiver failed to notify too.",
+		}, []string{"receiver", "fallback_receiver"}),
 	}
 	for _, integration := range []string{
 		"email",
@@ -279,17 +468,32 @@ func NewMetrics(r prometheus.Registerer) *Metrics {
 		"webhook",
 		"victorops",
 		"sns",
+		"servicenow",
+		"sms",
+		"kafka",
+		"nats",
+		"mqtt",
+		"exec",
+		"plugin",
 	} {
 		m.numNotifications.WithLabelValues(integration)
 		m.numTotalFailedNotifications.WithLabelValues(integration)
 		m.numNotificationRequestsTotal.WithLabelValues(integration)
 		m.numNotificationRequestsFailedTotal.WithLabelValues(integration)
 		m.notificationLatencySeconds.WithLabelValues(integration)
+		m.numNotificationRetriesExhausted.WithLabelValues(integration)
+		m.numCircuitBreakerShortCircuits.WithLabelValues(integration)
+		for _, reason := range possibleFailureReasonCategory {
+			m.numNotificationRetriesTotal.WithLabelValues(integration, reason)
+		}
 	}
 	r.MustRegister(
 		m.numNotifications, m.numTotalFailedNotifications,
 		m.numNotificationRequestsTotal, m.numNotificationRequestsFailedTotal,
 		m.notificationLatencySeconds,
+		m.numNotificationRetriesTotal, m.numNotificationRetriesExhausted,
+		m.circuitBreakerState, m.numCircuitBreakerShortCircuits,
+		m.numFallbacks, m.numFallbacksFailed,
 	)
 	return m
 }
@@ -304,14 +508,22 @@ func NewPipelineBuilder(r prometheus.Registerer) *PipelineBuilder {
 	}
 }
 
-// New returns a map of receivers to Stages.
+// New returns a map of receivers to Stages. The fallbacks map the receivers to
+// the receiver their failed notifications are dispatched to.
 func (pb *PipelineBuilder) New(
 	receivers map[string][]Integration,
+	fallbacks map[string]string,
 	wait func() time.Duration,
 	inhibitor *inhibit.Inhibitor,
 	silencer *silence.Silencer,
 	muteTimes map[string][]timeinterval.TimeInterval,
 	notificationLog NotificationLog,
+	ob *outbox.Outbox,
+	hist *history.History,
+	acker types.Acknowledger,
+	flaps *FlapDetector,
+	digests *digest.Digests,
+	bus *events.Bus,
 	peer Peer,
 ) RoutingStage {
 	rs := make(RoutingStage, len(receivers))
@@ -321,19 +533,39 @@ func (pb *PipelineBuilder) New(
 	ss := NewMuteStage(silencer)
 	tms := NewTimeMuteStage(muteTimes)
 
+	// The circuit breakers of the previous integrations are gone.
+	pb.metrics.circuitBreakerState.Reset()
 	for name := range receivers {
-		st := createReceiverStage(name, receivers[name], wait, notificationLog, pb.metrics)
-		rs[name] = MultiStage{ms, is, tms, ss, st}
+		st := createReceiverStage(name, receivers[name], fallbacks[name], rs, wait, notificationLog, ob, hist, flaps, bus, pb.metrics)
+		stages := MultiStage{ms, is, tms, ss}
+		if acker != nil {
+			stages = append(stages, NewAckStage(acker))
+		}
+		if flaps != nil {
+			stages = append(stages, NewFlapStage(flaps))
+		}
+		if digests != nil && digests.Scheduled(name) {
+			stages = append(stages, NewDigestStage(digests))
+		}
+		rs[name] = append(stages, st)
 	}
 	return rs
 }
 
-// createReceiverStage creates a pipeline of stages for a receiver.
+// createReceiverStage creates a pipeline of stages for a receiver. The failed
+// notifications are dispatched to the fallback receiver, if any, through the
+// routing stage.
 func createReceiverStage(
 	name string,
 	integrations []Integration,
+	fallback string,
+	rs RoutingStage,
 	wait func() time.Duration,
 	notificationLog NotificationLog,
+	ob *outbox.Outbox,
+	hist *history.History,
+	flaps *FlapDetector,
+	bus *events.Bus,
 	metrics *Metrics,
 ) Stage {
 	var fs FanoutStage
@@ -343,17 +575,57 @@ func createReceiverStage(
 			Integration: integrations[i].Name(),
 			Idx:         uint32(integrations[i].Index()),
 		}
+		if integrations[i].breaker != nil {
+			metrics.setCircuitBreakerState(name, &integrations[i])
+		}
+
 		var s MultiStage
+		s = append(s, replayFilterStage{integration: integrations[i].Name(), idx: integrations[i].Index()})
 		s = append(s, NewWaitStage(wait))
 		s = append(s, NewDedupStage(&integrations[i], notificationLog, recv))
-		s = append(s, NewRetryStage(integrations[i], name, metrics))
-		s = append(s, NewSetNotifiesStage(notificationLog, recv))
+		s = append(s, NewRetryStage(integrations[i], name, ob, hist, bus, fallback, rs, metrics))
+		s = append(s, NewSetNotifiesStage(notificationLog, recv, flaps))
 
 		fs = append(fs, s)
 	}
+	if fallback != "" {
+		return fallbackClaimStage{fs}
+	}
 	return fs
 }
 
+// fallbackClaim dispatches the alerts of a receiver's notification to its
+// fallback receiver at most once, however many of its integrations fail.
+type fallbackClaim struct {
+	once     sync.Once
+	notified bool
+}
+
+// fallbackClaimStage executes the integrations of a receiver with a shared
+// fallbackClaim.
+type fallbackClaimStage struct {
+	Stage
+}
+
+func (s fallbackClaimStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	return s.Stage.Exec(context.WithValue(ctx, keyFallbackClaim, &fallbackClaim{}), l, alerts...)
+}
+
+// replayFilterStage drops the replays of the outbox entries of other
+// integrations, so that a replay only notifies the integration which failed.
+type replayFilterStage struct {
+	integration string
+	idx         int
+}
+
+// Exec implements the Stage interface.
+func (s replayFilterStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if e, ok := outboxReplay(ctx); ok && (e.Integration != s.integration || e.Index != s.idx) {
+		return ctx, nil, nil
+	}
+	return ctx, alerts, nil
+}
+
 // RoutingStage executes the inner stages based on the receiver specified in
 // the context.
 type RoutingStage map[string]Stage
@@ -378,20 +650,37 @@ type MultiStage []Stage
 
 // Exec implements the Stage interface.
 func (ms MultiStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
-	var err error
+	var (
+		err    error
+		parent = trace.SpanFromContext(ctx)
+	)
 	for _, s := range ms {
 		if len(alerts) == 0 {
 			return ctx, nil, nil
 		}
 
-		ctx, alerts, err = s.Exec(ctx, l, alerts...)
+		sctx, span := startStageSpan(ctx, s, alerts)
+		ctx, alerts, err = s.Exec(sctx, l, alerts...)
+		tracing.RecordError(span, err)
+		span.End()
 		if err != nil {
 			return ctx, nil, err
 		}
+		// The span of the next stage is a sibling of this one.
+		ctx = trace.ContextWithSpan(ctx, parent)
 	}
 	return ctx, alerts, nil
 }
 
+// startStageSpan starts the span of the execution of a stage.
+func startStageSpan(ctx context.Context, s Stage, alerts []*types.Alert) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attribute.Int("alerts", len(alerts))}
+	if ms, ok := s.(*MuteStage); ok {
+		attrs = append(attrs, attribute.String("muter", fmt.Sprintf("%T", ms.muter)))
+	}
+	return tracer.Start(ctx, strings.TrimPrefix(fmt.Sprintf("%T", s), "*"), trace.WithAttributes(attrs...))
+}
+
 // FanoutStage executes its stages concurrently
 type FanoutStage []Stage
 
@@ -406,7 +695,11 @@ func (fs FanoutStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.A
 
 	for _, s := range fs {
 		go func(s Stage) {
-			if _, _, err := s.Exec(ctx, l, alerts...); err != nil {
+			sctx, span := startStageSpan(ctx, s, alerts)
+			_, _, err := s.Exec(sctx, l, alerts...)
+			tracing.RecordError(span, err)
+			span.End()
+			if err != nil {
 				me.Add(err)
 			}
 			wg.Done()
@@ -463,6 +756,77 @@ func (n *MuteStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Ale
 	return ctx, filtered, nil
 }
 
+// AckStage looks up the acknowledgements of the alerts and passes them along
+// the pipeline.
+type AckStage struct {
+	acker types.Acknowledger
+}
+
+// NewAckStage returns a new AckStage.
+func NewAckStage(a types.Acknowledger) *AckStage {
+	return &AckStage{acker: a}
+}
+
+// Exec implements the Stage interface.
+func (n *AckStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	gkey, ok := GroupKey(ctx)
+	if !ok {
+		return ctx, nil, errors.New("group key missing")
+	}
+
+	acks := map[model.Fingerprint]*types.Acknowledgement{}
+	for _, a := range alerts {
+		fp := a.Fingerprint()
+		if ack, ok := n.acker.Acknowledged(gkey, fp); ok {
+			acks[fp] = ack
+		}
+	}
+	return WithAcknowledgements(ctx, acks), alerts, nil
+}
+
+// DigestStage records the alerts of digest receivers for their next digest
+// instead of notifying them. The notifications of the digests pass through.
+type DigestStage struct {
+	digests *digest.Digests
+}
+
+// NewDigestStage returns a new DigestStage.
+func NewDigestStage(d *digest.Digests) *DigestStage {
+	return &DigestStage{digests: d}
+}
+
+// Exec implements the Stage interface.
+func (n *DigestStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if _, ok := Digest(ctx); ok {
+		return ctx, alerts, nil
+	}
+	recv, ok := ReceiverName(ctx)
+	if !ok {
+		return ctx, nil, errors.New("receiver missing")
+	}
+	if err := n.digests.Add(recv, alerts...); err != nil {
+		return ctx, nil, err
+	}
+	return ctx, nil, nil
+}
+
+// DigestNotifyFunc returns the function notifying the digests through the
+// pipeline returned by stage. The digests of a receiver share a group key so
+// that the peers of a cluster notify each digest once.
+func DigestNotifyFunc(stage func() Stage, l log.Logger) digest.NotifyFunc {
+	return func(ctx context.Context, w *digest.Window) error {
+		ctx = WithReceiverName(ctx, w.Receiver)
+		ctx = WithGroupKey(ctx, "digest:"+w.Receiver)
+		ctx = WithGroupLabels(ctx, model.LabelSet{})
+		ctx = WithNow(ctx, time.Now())
+		// The digest of the previous window must not prevent this one.
+		ctx = WithRepeatInterval(ctx, w.End.Sub(w.Start)/2)
+		ctx = WithDigest(ctx, w)
+		_, _, err := stage().Exec(ctx, l, w.Alerts()...)
+		return err
+	}
+}
+
 // WaitStage waits for a certain amount of time before continuing or until the
 // context is done.
 type WaitStage struct {
@@ -547,7 +911,7 @@ func hashAlert(a *types.Alert) uint64 {
 	return hash
 }
 
-func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint64]struct{}, repeat time.Duration) bool {
+func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint64]struct{}, repeat time.Duration, acknowledged bool) bool {
 	// If we haven't notified about the alert group before, notify right away
 	// unless we only have resolved alerts.
 	if entry == nil {
@@ -573,7 +937,11 @@ func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint
 		return true
 	}
 
-	// Nothing changed, only notify if the repeat interval has passed.
+	// Nothing changed, only notify if the repeat interval has passed and
+	// someone isn't already working on the firing alerts.
+	if acknowledged {
+		return false
+	}
 	return entry.Timestamp.Before(n.now().Add(-repeat))
 }
 
@@ -594,6 +962,11 @@ func (n *DedupStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Al
 	firing := []uint64{}
 	resolved := []uint64{}
 
+	// Repeat notifications are suppressed if all the firing alerts are
+	// acknowledged.
+	acks, _ := Acknowledgements(ctx)
+	acknowledged := true
+
 	var hash uint64
 	for _, a := range alerts {
 		hash = n.hash(a)
@@ -603,6 +976,9 @@ func (n *DedupStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Al
 		} else {
 			firing = append(firing, hash)
 			firingSet[hash] = struct{}{}
+			if _, ok := acks[a.Fingerprint()]; !ok {
+				acknowledged = false
+			}
 		}
 	}
 
@@ -623,25 +999,54 @@ func (n *DedupStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Al
 		return ctx, nil, errors.Errorf("unexpected entry result size %d", len(entries))
 	}
 
-	if n.needsUpdate(entry, firingSet, resolvedSet, repeatInterval) {
+	// A digest summarizes its window, resolved alerts included, and is
+	// notified once per window unless a peer already notified it.
+	if _, ok := Digest(ctx); ok {
+		if entry == nil || entry.Timestamp.Before(n.now().Add(-repeatInterval)) {
+			return ctx, alerts, nil
+		}
+		return ctx, nil, nil
+	}
+	// A renotification of the group sends the firing alerts regardless of
+	// the previous notification.
+	if renotify, _ := Renotify(ctx); renotify && len(firing) > 0 {
+		return ctx, alerts, nil
+	}
+	if n.needsUpdate(entry, firingSet, resolvedSet, repeatInterval, acknowledged) {
 		return ctx, alerts, nil
 	}
 	return ctx, nil, nil
 }
 
 // RetryStage notifies via passed integration with exponential backoff until it
-// succeeds. It aborts if the context is canceled or timed out.
+// succeeds. It aborts if the context is canceled or timed out, or when the
+// retry policy of the integration gives up. The notifications it gives up on
+// are dispatched to the fallback receiver, if any, or else handed over to the
+// outbox, if any. The outcome of notifications is recorded in the history, if
+// any, and published to the event bus, if any.
 type RetryStage struct {
 	integration Integration
 	groupName   string
+	outbox      *outbox.Outbox
+	history     *history.History
+	events      *events.Bus
+	fallback    string
+	routes      Stage
 	metrics     *Metrics
 }
 
-// NewRetryStage returns a new instance of a RetryStage.
-func NewRetryStage(i Integration, groupName string, metrics *Metrics) *RetryStage {
+// NewRetryStage returns a new instance of a RetryStage. The outbox, the
+// history and the event bus may be nil. If fallback isn't empty, the failed
+// notifications are dispatched to the fallback receiver through routes.
+func NewRetryStage(i Integration, groupName string, ob *outbox.Outbox, hist *history.History, bus *events.Bus, fallback string, routes Stage, metrics *Metrics) *RetryStage {
 	return &RetryStage{
 		integration: i,
 		groupName:   groupName,
+		outbox:      ob,
+		history:     hist,
+		events:      bus,
+		fallback:    fallback,
+		routes:      routes,
 		metrics:     metrics,
 	}
 }
@@ -655,13 +1060,72 @@ func (r RetryStage) Exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 	return ctx, alerts, err
 }
 
-func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+// canFallBack returns whether the alerts of the failed notification are
+// dispatched to the fallback receiver. They aren't if the notification was
+// canceled rather than given up on, or replays an outbox entry.
+func (r RetryStage) canFallBack(ctx context.Context) bool {
+	return r.fallback != "" && r.routes != nil && !errors.Is(ctx.Err(), context.Canceled) && !isOutboxReplay(ctx)
+}
+
+// fallbackContext returns the context of the notification of the fallback
+// receiver.
+func (r RetryStage) fallbackContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = WithReceiverName(ctx, r.fallback)
+	if ctx.Err() == nil {
+		return context.WithCancel(ctx)
+	}
+	// The retries gave up when the notification timed out. The fallback
+	// receiver gets as much time as the notification had.
+	timeout := time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		if now, ok := Now(ctx); ok && deadline.After(now) {
+			timeout = deadline.Sub(now)
+		}
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
+// fallBack dispatches the alerts of a failed notification to the fallback
+// receiver, if any. It returns whether the fallback receiver was notified.
+// When several integrations of the receiver fail, only the first one
+// dispatches; the others get its outcome.
+func (r RetryStage) fallBack(ctx context.Context, l log.Logger, alerts []*types.Alert) bool {
+	if !r.canFallBack(ctx) {
+		return false
+	}
+	c, ok := ctx.Value(keyFallbackClaim).(*fallbackClaim)
+	if !ok {
+		return r.dispatchFallback(ctx, l, alerts)
+	}
+	c.once.Do(func() {
+		c.notified = r.dispatchFallback(ctx, l, alerts)
+	})
+	return c.notified
+}
+
+func (r RetryStage) dispatchFallback(ctx context.Context, l log.Logger, alerts []*types.Alert) bool {
+	ctx, cancel := r.fallbackContext(ctx)
+	defer cancel()
+
+	l = log.With(l, "fallback_receiver", r.fallback)
+	level.Warn(l).Log("msg", "Notify failed, dispatching to the fallback receiver")
+	r.metrics.numFallbacks.WithLabelValues(r.groupName, r.fallback).Inc()
+	if _, _, err := r.routes.Exec(ctx, l, alerts...); err != nil {
+		r.metrics.numFallbacksFailed.WithLabelValues(r.groupName, r.fallback).Inc()
+		level.Error(l).Log("msg", "Fallback receiver failed to notify", "err", err)
+		return false
+	}
+	return true
+}
+
+func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Alert) (_ context.Context, _ []*types.Alert, err error) {
 	var sent []*types.Alert
 
 	// If we shouldn't send notifications for resolved alerts, but there are only
 	// resolved alerts, report them all as successfully notified (we still want the
-	// notification log to log them for the next run of DedupStage).
-	if !r.integration.SendResolved() {
+	// notification log to log them for the next run of DedupStage). Digests
+	// are sent as they are since they summarize their window.
+	if _, digest := Digest(ctx); !digest && !r.integration.SendResolved() {
 		firing, ok := FiringAlerts(ctx)
 		if !ok {
 			return ctx, nil, errors.New("firing alerts missing")
@@ -678,8 +1142,20 @@ func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 		sent = alerts
 	}
 
+	policy := r.integration.retry
+	if policy == nil {
+		policy = &config.RetryConfig{}
+	}
+
 	b := backoff.NewExponentialBackOff()
 	b.MaxElapsedTime = 0 // Always retry.
+	if policy.InitialBackoff > 0 {
+		b.InitialInterval = time.Duration(policy.InitialBackoff)
+	}
+	if policy.MaxBackoff > 0 {
+		b.MaxInterval = time.Duration(policy.MaxBackoff)
+	}
+	b.Reset()
 
 	tick := backoff.NewTicker(b)
 	defer tick.Stop()
@@ -687,8 +1163,44 @@ func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 	var (
 		i    = 0
 		iErr error
+		// unrecoverable is set when the retry policy gave up, exhausted when
+		// it ran out of attempts.
+		unrecoverable bool
+		exhausted     bool
+		// The outcome of the last attempt, for the history.
+		entry    *history.Entry
+		attempts int
 	)
 	l = log.With(l, "receiver", r.groupName, "integration", r.integration.String())
+	defer func() {
+		// The notifications the pipeline gave up on are handed over to the
+		// fallback receiver, or else to the outbox. Those given up because
+		// the policy ran out of attempts are retried in the background. The
+		// canceled notifications, for instance on reload, aren't failures.
+		if err != nil && !r.fallBack(ctx, l, alerts) {
+			switch {
+			case exhausted:
+				r.hold(ctx, l, sent, err, true)
+			case unrecoverable:
+				r.hold(ctx, l, sent, err, false)
+			}
+		}
+		if entry != nil && r.history != nil {
+			entry.Attempts = attempts
+			entry.Status = history.StatusSuccess
+			if err != nil {
+				entry.Status = history.StatusFailure
+				entry.Error = err.Error()
+				if r.canFallBack(ctx) {
+					entry.FallbackReceiver = r.fallback
+				}
+			}
+			r.history.Add(entry)
+		}
+		if attempts > 0 || err != nil {
+			r.publish(ctx, sent, err)
+		}
+	}()
 
 	for {
 		i++
@@ -705,15 +1217,61 @@ func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 
 		select {
 		case <-tick.C:
+			if entry == nil {
+				entry = r.newHistoryEntry(ctx, sent)
+			}
+			if b := r.integration.breaker; b != nil {
+				allowed := b.Allow(time.Now())
+				r.metrics.setCircuitBreakerState(r.groupName, &r.integration)
+				if !allowed {
+					r.metrics.numCircuitBreakerShortCircuits.WithLabelValues(r.integration.Name()).Inc()
+					if iErr == nil {
+						iErr = errors.New("circuit breaker is open")
+					}
+					return ctx, nil, errors.Wrapf(iErr, "%s/%s: notify canceled by the circuit breaker after %d attempts", r.groupName, r.integration.String(), i-1)
+				}
+			}
+
 			now := time.Now()
-			retry, err := r.integration.Notify(ctx, sent...)
-			r.metrics.notificationLatencySeconds.WithLabelValues(r.integration.Name()).Observe(time.Since(now).Seconds())
+			actx, statusCode := withStatusCodeRecorder(ctx)
+			actx, span := tracer.Start(actx, "notify.attempt", trace.WithAttributes(
+				attribute.String("integration", r.integration.Name()),
+				attribute.Int("integration.index", r.integration.Index()),
+				attribute.Int("attempt", i),
+			))
+			retry, err := r.notify(actx, time.Duration(policy.AttemptTimeout), sent...)
+			tracing.RecordError(span, err)
+			span.End()
+			attempts++
+			if b := r.integration.breaker; b != nil {
+				b.Record(err, time.Now())
+				r.metrics.setCircuitBreakerState(r.groupName, &r.integration)
+			}
+			entry.Latency = time.Since(now)
+			entry.StatusCode = int(statusCode.code.Load())
+			entry.Reason = ""
+			if err != nil {
+				entry.Reason = failureReason(err).String()
+			}
+			r.metrics.notificationLatencySeconds.WithLabelValues(r.integration.Name()).Observe(entry.Latency.Seconds())
 			r.metrics.numNotificationRequestsTotal.WithLabelValues(r.integration.Name()).Inc()
 			if err != nil {
 				r.metrics.numNotificationRequestsFailedTotal.WithLabelValues(r.integration.Name()).Inc()
 				if !retry {
+					unrecoverable = true
 					return ctx, alerts, errors.Wrapf(err, "%s/%s: notify retry canceled due to unrecoverable error after %d attempts", r.groupName, r.integration.String(), i)
 				}
+				reason := failureReason(err)
+				if !retryOn(policy, reason) {
+					unrecoverable = true
+					return ctx, alerts, errors.Wrapf(err, "%s/%s: notify retry canceled due to non-retryable %s error after %d attempts", r.groupName, r.integration.String(), reason, i)
+				}
+				if policy.MaxAttempts > 0 && i >= policy.MaxAttempts {
+					r.metrics.numNotificationRetriesExhausted.WithLabelValues(r.integration.Name()).Inc()
+					unrecoverable, exhausted = true, true
+					return ctx, alerts, errors.Wrapf(err, "%s/%s: notify retry canceled after reaching the maximum of %d attempts", r.groupName, r.integration.String(), i)
+				}
+				r.metrics.numNotificationRetriesTotal.WithLabelValues(r.integration.Name(), reason.String()).Inc()
 				if ctx.Err() == nil && (iErr == nil || err.Error() != iErr.Error()) {
 					// Log the error if the context isn't done and the error isn't the same as before.
 					level.Warn(l).Log("msg", "Notify attempt failed, will retry later", "attempts", i, "err", err)
@@ -740,18 +1298,162 @@ func (r RetryStage) exec(ctx context.Context, l log.Logger, alerts ...*types.Ale
 	}
 }
 
+// publish publishes the notified or failed events of the alerts.
+func (r RetryStage) publish(ctx context.Context, alerts []*types.Alert, err error) {
+	if !r.events.Enabled() {
+		return
+	}
+	var (
+		groupKey, _ = GroupKey(ctx)
+		now         = time.Now()
+		evs         = make([]*events.Event, 0, len(alerts))
+	)
+	for _, a := range alerts {
+		e := &events.Event{
+			Time:        now,
+			Type:        events.Notified,
+			Fingerprint: a.Fingerprint(),
+			Labels:      a.Labels,
+			Receiver:    r.groupName,
+			Integration: r.integration.String(),
+			GroupKey:    groupKey,
+		}
+		if err != nil {
+			e.Type, e.Error = events.Failed, err.Error()
+		}
+		evs = append(evs, e)
+	}
+	r.events.Publish(evs...)
+}
+
+// newHistoryEntry returns the history entry of the notification of the
+// alerts.
+func (r RetryStage) newHistoryEntry(ctx context.Context, alerts []*types.Alert) *history.Entry {
+	groupKey, _ := GroupKey(ctx)
+	fps := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		fps = append(fps, a.Fingerprint().String())
+	}
+	return &history.Entry{
+		Timestamp:    time.Now(),
+		Receiver:     r.groupName,
+		Integration:  r.integration.Name(),
+		Index:        r.integration.Index(),
+		GroupKey:     groupKey,
+		Fingerprints: fps,
+	}
+}
+
+// hold records the notification in the outbox, if any. The notifications of
+// digests and of outbox entries aren't recorded, they are retried by the
+// digests and the outbox.
+func (r RetryStage) hold(ctx context.Context, l log.Logger, alerts []*types.Alert, err error, retry bool) {
+	if r.outbox == nil || isOutboxReplay(ctx) {
+		return
+	}
+	if _, ok := Digest(ctx); ok {
+		return
+	}
+	groupKey, _ := GroupKey(ctx)
+	groupLabels, _ := GroupLabels(ctx)
+	if err := r.outbox.Add(&outbox.Entry{
+		Receiver:    r.groupName,
+		Integration: r.integration.Name(),
+		Index:       r.integration.Index(),
+		GroupKey:    groupKey,
+		GroupLabels: groupLabels,
+		Alerts:      alerts,
+	}, err, retry); err != nil {
+		level.Error(l).Log("msg", "Failed to record the notification in the outbox", "err", err)
+	}
+}
+
+// OutboxNotifyFunc returns the function delivering the notifications of the
+// outbox entries through the pipeline returned by stage, so that they are
+// deduplicated against the notification log. Only the integration of an entry
+// is notified. The alerts are refreshed with
+// get, those no longer known are dropped.
+func OutboxNotifyFunc(stage func() Stage, get func(model.Fingerprint) (*types.Alert, error), l log.Logger) outbox.NotifyFunc {
+	return func(ctx context.Context, e *outbox.Entry) error {
+		alerts := make([]*types.Alert, 0, len(e.Alerts))
+		for _, a := range e.Alerts {
+			if cur, err := get(a.Fingerprint()); err == nil {
+				alerts = append(alerts, cur)
+			}
+		}
+		if len(alerts) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		ctx = WithReceiverName(ctx, e.Receiver)
+		ctx = WithGroupKey(ctx, e.GroupKey)
+		ctx = WithGroupLabels(ctx, e.GroupLabels)
+		ctx = WithNow(ctx, now)
+		// The integrations notified since the entry was created are up to
+		// date.
+		ctx = WithRepeatInterval(ctx, now.Sub(e.CreatedAt))
+		ctx = withOutboxReplay(ctx, e)
+		_, _, err := stage().Exec(ctx, l, alerts...)
+		return err
+	}
+}
+
+// setCircuitBreakerState exports the state of the circuit breaker of the
+// integration.
+func (m *Metrics) setCircuitBreakerState(receiver string, i *Integration) {
+	m.circuitBreakerState.WithLabelValues(receiver, i.Name(), strconv.Itoa(i.Index())).Set(float64(i.breaker.State()))
+}
+
+// notify sends the alerts via the integration, bounding the attempt by the
+// given timeout if it isn't 0.
+func (r RetryStage) notify(ctx context.Context, timeout time.Duration, alerts ...*types.Alert) (bool, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return r.integration.Notify(ctx, alerts...)
+}
+
+// failureReason returns the reason of a failed notification.
+func failureReason(err error) Reason {
+	var e *ErrorWithReason
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return DefaultReason
+}
+
+// retryOn returns whether the retry policy retries failures with the given
+// reason.
+func retryOn(policy *config.RetryConfig, reason Reason) bool {
+	if len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, r := range policy.RetryOn {
+		if r == reason.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // SetNotifiesStage sets the notification information about passed alerts. The
 // passed alerts should have already been sent to the receivers.
 type SetNotifiesStage struct {
 	nflog NotificationLog
 	recv  *nflogpb.Receiver
+	flaps *FlapDetector
 }
 
-// NewSetNotifiesStage returns a new instance of a SetNotifiesStage.
-func NewSetNotifiesStage(l NotificationLog, recv *nflogpb.Receiver) *SetNotifiesStage {
+// NewSetNotifiesStage returns a new instance of a SetNotifiesStage. The
+// delivered flapping alerts are recorded in the flap detector, if any.
+func NewSetNotifiesStage(l NotificationLog, recv *nflogpb.Receiver, flaps *FlapDetector) *SetNotifiesStage {
 	return &SetNotifiesStage{
 		nflog: l,
 		recv:  recv,
+		flaps: flaps,
 	}
 }
 
@@ -772,7 +1474,15 @@ func (n SetNotifiesStage) Exec(ctx context.Context, l log.Logger, alerts ...*typ
 		return ctx, nil, errors.New("resolved alerts missing")
 	}
 
-	return ctx, alerts, n.nflog.Log(n.recv, gkey, firing, resolved)
+	if err := n.nflog.Log(n.recv, gkey, firing, resolved); err != nil {
+		return ctx, alerts, err
+	}
+	if flapping, ok := Flapping(ctx); ok && n.flaps != nil {
+		if receiver, ok := ReceiverName(ctx); ok {
+			n.flaps.Notified(receiver, flapping)
+		}
+	}
+	return ctx, alerts, nil
 }
 
 type TimeMuteStage struct {
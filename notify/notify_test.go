@@ -18,20 +18,37 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v2"
 
+	"github.com/prometheus/alertmanager/ack"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/digest"
+	"github.com/prometheus/alertmanager/events"
+	"github.com/prometheus/alertmanager/history"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/outbox"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -96,6 +113,7 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 		resolvedAlerts map[uint64]struct{}
 		repeat         time.Duration
 		resolve        bool
+		acknowledged   bool
 
 		res bool
 	}{
@@ -140,6 +158,38 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 			repeat:       10 * time.Minute,
 			firingAlerts: alertHashSet(1, 2, 3),
 			res:          true,
+		}, {
+			// Acknowledged alerts shouldn't update after repeat_interval.
+			entry: &nflogpb.Entry{
+				FiringAlerts: []uint64{1, 2, 3},
+				Timestamp:    now.Add(-11 * time.Minute),
+			},
+			repeat:       10 * time.Minute,
+			firingAlerts: alertHashSet(1, 2, 3),
+			acknowledged: true,
+			res:          false,
+		}, {
+			// New alerts should update even if the others are acknowledged.
+			entry: &nflogpb.Entry{
+				FiringAlerts: []uint64{1, 2, 3},
+				Timestamp:    now.Add(-9 * time.Minute),
+			},
+			repeat:       10 * time.Minute,
+			firingAlerts: alertHashSet(1, 2, 3, 4),
+			acknowledged: true,
+			res:          true,
+		}, {
+			// Resolved acknowledged alerts should update.
+			entry: &nflogpb.Entry{
+				FiringAlerts: []uint64{1, 2, 3},
+				Timestamp:    now.Add(-9 * time.Minute),
+			},
+			repeat:         10 * time.Minute,
+			firingAlerts:   alertHashSet(1, 2),
+			resolvedAlerts: alertHashSet(3),
+			resolve:        true,
+			acknowledged:   true,
+			res:            true,
 		}, {
 			// Different sets of resolved alerts without firing alerts shouldn't update after repeat_interval.
 			entry: &nflogpb.Entry{
@@ -207,7 +257,7 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 			now: func() time.Time { return now },
 			rs:  sendResolved(c.resolve),
 		}
-		res := s.needsUpdate(c.entry, c.firingAlerts, c.resolvedAlerts, c.repeat)
+		res := s.needsUpdate(c.entry, c.firingAlerts, c.resolvedAlerts, c.repeat, c.acknowledged)
 		require.Equal(t, c.res, res)
 	}
 }
@@ -281,6 +331,12 @@ func TestDedupStage(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, res, "unexpected alerts returned")
 
+	// Must return all input alerts on renotification.
+	i = 0
+	_, res, err = s.Exec(WithRenotify(ctx, true), log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res, "unexpected alerts returned")
+
 	// Must return no error and all input alerts on changes.
 	i = 0
 	s.nflog = &testNflog{
@@ -295,6 +351,26 @@ func TestDedupStage(t *testing.T) {
 	_, res, err = s.Exec(ctx, log.NewNopLogger(), alerts...)
 	require.NoError(t, err)
 	require.Equal(t, alerts, res, "unexpected alerts returned")
+
+	// Must return digests of resolved alerts only once per window.
+	resolved := []*types.Alert{{Alert: model.Alert{EndsAt: now.Add(-time.Minute)}}}
+	dctx := WithDigest(ctx, &digest.Window{Receiver: "digest"})
+	s.nflog = &testNflog{qerr: nflog.ErrNotFound}
+	_, res, err = s.Exec(dctx, log.NewNopLogger(), resolved...)
+	require.NoError(t, err)
+	require.Equal(t, resolved, res, "unexpected alerts returned")
+
+	s.nflog = &testNflog{
+		qres: []*nflogpb.Entry{
+			{
+				ResolvedAlerts: []uint64{0},
+				Timestamp:      now,
+			},
+		},
+	}
+	_, res, err = s.Exec(dctx, log.NewNopLogger(), resolved...)
+	require.NoError(t, err)
+	require.Nil(t, res, "unexpected alerts returned")
 }
 
 func TestMultiStage(t *testing.T) {
@@ -335,6 +411,35 @@ func TestMultiStage(t *testing.T) {
 	}
 }
 
+func TestMultiStageSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+	stage := MultiStage{
+		StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			return ctx, alerts, nil
+		}),
+		failStage{},
+	}
+	_, _, err := stage.Exec(ctx, log.NewNopLogger(), &types.Alert{})
+	require.EqualError(t, err, "some error")
+	root.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "notify.StageFunc", spans[0].Name())
+	require.Equal(t, "notify.failStage", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	// The spans of the stages are siblings.
+	for _, s := range spans[:2] {
+		require.Equal(t, root.SpanContext().SpanID(), s.Parent().SpanID())
+		require.Contains(t, s.Attributes(), attribute.Int("alerts", 1))
+	}
+}
+
 func TestMultiStageFailure(t *testing.T) {
 	var (
 		ctx   = context.Background()
@@ -422,6 +527,643 @@ func TestRetryStageWithError(t *testing.T) {
 	require.NotNil(t, resctx)
 }
 
+func TestRetryStageWithRetryPolicy(t *testing.T) {
+	var policy config.RetryConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+max_attempts: 3
+initial_backoff: 1ms
+max_backoff: 2ms
+attempt_timeout: 10ms
+retry_on: [serverError, other]
+`), &policy))
+
+	var (
+		attempts int
+		err      error
+	)
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			attempts++
+			if _, ok := ctx.Deadline(); !ok {
+				return false, errors.New("missing attempt timeout")
+			}
+			return true, err
+		}),
+		rs:    sendResolved(false),
+		retry: &policy,
+	}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	r := RetryStage{
+		integration: i,
+		metrics:     metrics,
+	}
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	ctx := WithFiringAlerts(context.Background(), []uint64{0})
+
+	// Retryable failures are retried up to the maximum number of attempts.
+	err = NewErrorWithReason(ServerErrorReason, errors.New("unavailable"))
+	_, res, execErr := r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.ErrorContains(t, execErr, "notify retry canceled after reaching the maximum of 3 attempts")
+	require.Equal(t, alerts, res)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.numNotificationRetriesTotal.WithLabelValues("", "serverError")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numNotificationRetriesExhausted.WithLabelValues("")))
+
+	// Failures with a reason the policy doesn't retry on aren't retried.
+	attempts = 0
+	err = NewErrorWithReason(ClientErrorReason, errors.New("too many requests"))
+	_, _, execErr = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.ErrorContains(t, execErr, "notify retry canceled due to non-retryable clientError error after 1 attempts")
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryStageWithCircuitBreaker(t *testing.T) {
+	var attempts int
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			attempts++
+			return true, errors.New("fail to deliver notification")
+		}),
+		rs:      sendResolved(false),
+		name:    "slack",
+		breaker: newTestCircuitBreaker(t),
+	}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	r := RetryStage{
+		integration: i,
+		groupName:   "team-X",
+		metrics:     metrics,
+	}
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	ctx := WithFiringAlerts(context.Background(), []uint64{0})
+
+	// The breaker opens after 2 failed attempts and stops the retries.
+	_, _, err := r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.EqualError(t, err, "team-X/slack[0]: notify canceled by the circuit breaker after 2 attempts: fail to deliver notification")
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.circuitBreakerState.WithLabelValues("team-X", "slack", "0")))
+
+	// Further notifications aren't attempted while the breaker is open.
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.EqualError(t, err, "team-X/slack[0]: notify canceled by the circuit breaker after 0 attempts: circuit breaker is open")
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.numCircuitBreakerShortCircuits.WithLabelValues("slack")))
+}
+
+func TestRetryStageWithOutbox(t *testing.T) {
+	ob, err := outbox.New(outbox.Options{
+		MaxAttempts:    1,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+		Retention:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	var policy config.RetryConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+max_attempts: 2
+initial_backoff: 1ms
+max_backoff: 1ms
+`), &policy))
+	var fail, retry bool
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			if fail {
+				return retry, errors.New("fail to deliver notification")
+			}
+			return false, nil
+		}),
+		rs:    sendResolved(false),
+		name:  "webhook",
+		idx:   1,
+		retry: &policy,
+	}
+	r := NewRetryStage(i, "team-X", ob, nil, nil, "", nil, NewMetrics(prometheus.NewRegistry()))
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	ctx := WithFiringAlerts(context.Background(), []uint64{0})
+	ctx = WithGroupKey(ctx, "1")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+
+	// Delivered notifications aren't held.
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Empty(t, ob.List())
+
+	// Notifications running out of attempts are retried in the background.
+	fail, retry = true, true
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	entries := ob.List()
+	require.Len(t, entries, 1)
+	require.Equal(t, outbox.StatusPending, entries[0].Status)
+	require.Equal(t, "team-X", entries[0].Receiver)
+	require.Equal(t, "webhook", entries[0].Integration)
+	require.Equal(t, 1, entries[0].Index)
+	require.Equal(t, "1", entries[0].GroupKey)
+	require.Equal(t, model.LabelSet{"alertname": "HighLatency"}, entries[0].GroupLabels)
+	require.Equal(t, alerts, entries[0].Alerts)
+	require.NoError(t, ob.Discard(entries[0].ID))
+
+	// Notifications failing with unrecoverable errors become dead letters.
+	retry = false
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	entries = ob.List()
+	require.Len(t, entries, 1)
+	require.Equal(t, outbox.StatusDead, entries[0].Status)
+	require.Contains(t, entries[0].Error, "fail to deliver notification")
+	require.NoError(t, ob.Discard(entries[0].ID))
+
+	// Canceled notifications, for instance on reload, aren't held.
+	retry = true
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = r.Exec(cctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Empty(t, ob.List())
+
+	// Replays of outbox entries aren't held again.
+	_, _, err = r.Exec(withOutboxReplay(ctx, &outbox.Entry{}), log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Empty(t, ob.List())
+}
+
+func TestRetryStageWithHistory(t *testing.T) {
+	hist, err := history.New(history.Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	fail := true
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			if fail {
+				fail = false
+				RecordStatusCode(ctx, http.StatusServiceUnavailable)
+				return true, NewErrorWithReason(ServerErrorReason, errors.New("unavailable"))
+			}
+			RecordStatusCode(ctx, http.StatusOK)
+			return false, nil
+		}),
+		rs:   sendResolved(false),
+		name: "webhook",
+		idx:  1,
+	}
+	r := NewRetryStage(i, "team-X", nil, hist, nil, "", nil, NewMetrics(prometheus.NewRegistry()))
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency"},
+			EndsAt: time.Now().Add(time.Hour),
+		},
+	}
+	ctx := WithFiringAlerts(context.Background(), []uint64{0})
+	ctx = WithGroupKey(ctx, "1")
+
+	// A notification delivered after a retry is recorded once, with the
+	// outcome of the last attempt.
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alert)
+	require.NoError(t, err)
+	entries := hist.Query(history.Query{})
+	require.Len(t, entries, 1)
+	require.Equal(t, "team-X", entries[0].Receiver)
+	require.Equal(t, "webhook", entries[0].Integration)
+	require.Equal(t, 1, entries[0].Index)
+	require.Equal(t, "1", entries[0].GroupKey)
+	require.Equal(t, []string{alert.Fingerprint().String()}, entries[0].Fingerprints)
+	require.Equal(t, history.StatusSuccess, entries[0].Status)
+	require.Equal(t, http.StatusOK, entries[0].StatusCode)
+	require.Empty(t, entries[0].Reason)
+	require.Equal(t, 2, entries[0].Attempts)
+
+	// Failed notifications record the failure reason and the error.
+	i.notifier = notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+		RecordStatusCode(ctx, http.StatusBadRequest)
+		return false, NewErrorWithReason(ClientErrorReason, errors.New("bad request"))
+	})
+	r = NewRetryStage(i, "team-X", nil, hist, nil, "", nil, NewMetrics(prometheus.NewRegistry()))
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alert)
+	require.Error(t, err)
+	entries = hist.Query(history.Query{Status: history.StatusFailure})
+	require.Len(t, entries, 1)
+	require.Equal(t, http.StatusBadRequest, entries[0].StatusCode)
+	require.Equal(t, "clientError", entries[0].Reason)
+	require.Equal(t, err.Error(), entries[0].Error)
+	require.Equal(t, 1, entries[0].Attempts)
+}
+
+type eventsExporter struct {
+	mtx    sync.Mutex
+	events []*events.Event
+}
+
+func (e *eventsExporter) Export(_ context.Context, evs []*events.Event) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.events = append(e.events, evs...)
+	return nil
+}
+
+func (e *eventsExporter) Shutdown() error { return nil }
+
+func TestRetryStageEvents(t *testing.T) {
+	exp := &eventsExporter{}
+	bus := events.New(events.Options{
+		NewExporter: func(*config.LogEventsConfig) (events.Exporter, error) { return exp, nil },
+	})
+	require.NoError(t, bus.ApplyConfig(&config.LogEventsConfig{
+		Endpoint:      "localhost:4317",
+		Timeout:       model.Duration(time.Second),
+		BatchSize:     10,
+		BufferSize:    10,
+		FlushInterval: model.Duration(time.Hour),
+	}))
+
+	fail := false
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			if fail {
+				return false, errors.New("bad request")
+			}
+			return false, nil
+		}),
+		rs:   sendResolved(true),
+		name: "webhook",
+	}
+	r := NewRetryStage(i, "team-X", nil, nil, bus, "", nil, NewMetrics(prometheus.NewRegistry()))
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{"alertname": "HighLatency"},
+			EndsAt: time.Now().Add(time.Hour),
+		},
+	}
+	ctx := WithGroupKey(context.Background(), "1")
+	_, _, err := r.Exec(ctx, log.NewNopLogger(), alert)
+	require.NoError(t, err)
+	fail = true
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alert)
+	require.Error(t, err)
+
+	bus.Stop()
+	require.Len(t, exp.events, 2)
+	for _, e := range exp.events {
+		require.Equal(t, alert.Fingerprint(), e.Fingerprint)
+		require.Equal(t, "team-X", e.Receiver)
+		require.Equal(t, i.String(), e.Integration)
+		require.Equal(t, "1", e.GroupKey)
+	}
+	require.Equal(t, events.Notified, exp.events[0].Type)
+	require.Equal(t, events.Failed, exp.events[1].Type)
+	require.Equal(t, err.Error(), exp.events[1].Error)
+}
+
+func TestRetryStageFallback(t *testing.T) {
+	hist, err := history.New(history.Options{Retention: time.Hour})
+	require.NoError(t, err)
+	ob, err := outbox.New(outbox.Options{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+		Retention:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			return false, errors.New("fail to deliver notification")
+		}),
+		rs:   sendResolved(false),
+		name: "pagerduty",
+	}
+	var (
+		fallbackReceiver string
+		fallbackAlerts   []*types.Alert
+	)
+	routes := RoutingStage{
+		"slack": StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			fallbackReceiver, _ = ReceiverName(ctx)
+			fallbackAlerts = alerts
+			return ctx, alerts, nil
+		}),
+	}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	r := NewRetryStage(i, "team-X", ob, hist, nil, "slack", routes, metrics)
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	ctx := WithFiringAlerts(context.Background(), []uint64{0})
+	ctx = WithGroupKey(ctx, "1")
+	ctx = WithReceiverName(ctx, "team-X")
+
+	// The alerts are dispatched to the fallback receiver once the retries
+	// gave up.
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Equal(t, "slack", fallbackReceiver)
+	require.Equal(t, alerts, fallbackAlerts)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numFallbacks.WithLabelValues("team-X", "slack")))
+	require.Equal(t, 0.0, testutil.ToFloat64(metrics.numFallbacksFailed.WithLabelValues("team-X", "slack")))
+	entries := hist.Query(history.Query{})
+	require.Len(t, entries, 1)
+	require.Equal(t, history.StatusFailure, entries[0].Status)
+	require.Equal(t, "slack", entries[0].FallbackReceiver)
+	// The notifications handled by the fallback receiver aren't held.
+	require.Empty(t, ob.List())
+
+	// Canceled notifications aren't dispatched to the fallback receiver.
+	fallbackReceiver = ""
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = r.Exec(cctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Empty(t, fallbackReceiver)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numFallbacks.WithLabelValues("team-X", "slack")))
+	require.Empty(t, ob.List())
+
+	// Failures of the fallback receiver are counted.
+	routes["slack"] = StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, nil, errors.New("fallback failed")
+	})
+	_, _, err = r.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.numFallbacks.WithLabelValues("team-X", "slack")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numFallbacksFailed.WithLabelValues("team-X", "slack")))
+	require.Len(t, ob.List(outbox.StatusDead), 1)
+}
+
+func TestRetryStageFallbackOnce(t *testing.T) {
+	ob, err := outbox.New(outbox.Options{
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+		Retention:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	integration := func(name string) Integration {
+		return Integration{
+			notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+				return false, errors.New("fail to deliver notification")
+			}),
+			rs:   sendResolved(true),
+			name: name,
+		}
+	}
+	var (
+		mtx       sync.Mutex
+		fallbacks int
+	)
+	rs := RoutingStage{
+		"slack": StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			fallbacks++
+			return ctx, alerts, nil
+		}),
+	}
+	tnflog := &testNflog{
+		qerr:    nflog.ErrNotFound,
+		logFunc: func(*nflogpb.Receiver, string, []uint64, []uint64) error { return nil },
+	}
+	metrics := NewMetrics(prometheus.NewRegistry())
+	rs["team-X"] = createReceiverStage(
+		"team-X",
+		[]Integration{integration("pagerduty"), integration("webhook")},
+		"slack", rs, func() time.Duration { return 0 }, tnflog, ob, nil, nil, nil, metrics,
+	)
+
+	alert := &types.Alert{Alert: model.Alert{
+		Labels: model.LabelSet{"alertname": "HighLatency"},
+		EndsAt: time.Now().Add(time.Hour),
+	}}
+	ctx := WithReceiverName(context.Background(), "team-X")
+	ctx = WithGroupKey(ctx, "1")
+	ctx = WithRepeatInterval(ctx, time.Hour)
+	ctx = WithNow(ctx, time.Now())
+
+	// The fallback receiver is notified once however many integrations
+	// failed.
+	_, _, err = rs.Exec(ctx, log.NewNopLogger(), alert)
+	require.Error(t, err)
+	require.Equal(t, 1, fallbacks)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numFallbacks.WithLabelValues("team-X", "slack")))
+	require.Empty(t, ob.List())
+
+	// Every notification of the receiver falls back on its own.
+	_, _, err = rs.Exec(ctx, log.NewNopLogger(), alert)
+	require.Error(t, err)
+	require.Equal(t, 2, fallbacks)
+}
+
+func TestDigestStage(t *testing.T) {
+	digests, err := digest.New(digest.Options{Timeout: time.Second})
+	require.NoError(t, err)
+	schedule := &config.DigestConfig{Interval: model.Duration(time.Hour)}
+	require.NoError(t, schedule.Validate())
+	digests.SetSchedules(map[string]*config.DigestConfig{"digest": schedule})
+
+	now := time.Now()
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: now,
+			EndsAt:   now.Add(time.Hour),
+		},
+	}
+	var (
+		got    []*types.Alert
+		gotCtx context.Context
+	)
+	stage := MultiStage{
+		NewDigestStage(digests),
+		StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			got, gotCtx = alerts, ctx
+			return ctx, alerts, nil
+		}),
+	}
+
+	// The alerts are recorded for the next digest instead of being notified.
+	ctx := WithReceiverName(context.Background(), "digest")
+	_, res, err := stage.Exec(ctx, log.NewNopLogger(), alert)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	require.Empty(t, got)
+
+	// The digests pass through.
+	w := &digest.Window{Receiver: "digest", Start: now.Add(-time.Hour), End: now, Open: []*types.Alert{alert}}
+	err = DigestNotifyFunc(func() Stage { return RoutingStage{"digest": stage} }, log.NewNopLogger())(context.Background(), w)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{alert}, got)
+	gkey, _ := GroupKey(gotCtx)
+	require.Equal(t, "digest:digest", gkey)
+	repeat, _ := RepeatInterval(gotCtx)
+	require.Equal(t, 30*time.Minute, repeat)
+	gotWindow, ok := Digest(gotCtx)
+	require.True(t, ok)
+	require.Equal(t, w, gotWindow)
+
+	tmpl, err := template.FromGlobs()
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+	data := GetTemplateData(gotCtx, tmpl, got, log.NewNopLogger())
+	require.NotNil(t, data.Digest)
+	require.Len(t, data.Digest.Open, 1)
+	require.Equal(t, "HighLatency", data.Digest.Open[0].Labels["alertname"])
+
+	// Receivers without a schedule fail.
+	_, _, err = stage.Exec(WithReceiverName(context.Background(), "team-X"), log.NewNopLogger(), alert)
+	require.Error(t, err)
+}
+
+func TestOutboxNotifyFunc(t *testing.T) {
+	var (
+		got      context.Context
+		replayed []*types.Alert
+	)
+	stage := StageFunc(func(ctx context.Context, l log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		got, replayed = ctx, alerts
+		return ctx, alerts, nil
+	})
+	resolved := &types.Alert{Alert: model.Alert{
+		Labels: model.LabelSet{"alertname": "HighLatency"},
+		EndsAt: time.Now().Add(-time.Minute),
+	}}
+	get := func(fp model.Fingerprint) (*types.Alert, error) {
+		if fp == resolved.Fingerprint() {
+			return resolved, nil
+		}
+		return nil, errors.New("alert not found")
+	}
+	notifyFn := OutboxNotifyFunc(func() Stage { return stage }, get, log.NewNopLogger())
+
+	e := &outbox.Entry{
+		Receiver:    "team-X",
+		Integration: "webhook",
+		GroupKey:    "1",
+		GroupLabels: model.LabelSet{"alertname": "HighLatency"},
+		Alerts: []*types.Alert{
+			{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency"}, EndsAt: time.Now().Add(time.Hour)}},
+			{Alert: model.Alert{Labels: model.LabelSet{"alertname": "Gone"}, EndsAt: time.Now().Add(time.Hour)}},
+		},
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+
+	// The entry is replayed through the pipeline with the current state of
+	// its alerts.
+	require.NoError(t, notifyFn(context.Background(), e))
+	require.Equal(t, []*types.Alert{resolved}, replayed)
+	receiver, _ := ReceiverName(got)
+	require.Equal(t, "team-X", receiver)
+	groupKey, _ := GroupKey(got)
+	require.Equal(t, "1", groupKey)
+	groupLabels, _ := GroupLabels(got)
+	require.Equal(t, e.GroupLabels, groupLabels)
+	repeat, _ := RepeatInterval(got)
+	require.InDelta(t, time.Hour, repeat, float64(time.Minute))
+	require.True(t, isOutboxReplay(got))
+
+	// Entries whose alerts are gone are done.
+	got = nil
+	e.Alerts = e.Alerts[1:]
+	require.NoError(t, notifyFn(context.Background(), e))
+	require.Nil(t, got)
+}
+
+func TestOutboxReplayFailedIntegration(t *testing.T) {
+	ob, err := outbox.New(outbox.Options{
+		MaxAttempts:    1,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Minute,
+		Timeout:        time.Second,
+		Retention:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	var policy config.RetryConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+max_attempts: 1
+`), &policy))
+	var (
+		mtx      sync.Mutex
+		notified = map[string]int{}
+		fail     = true
+	)
+	integration := func(name string, failing bool) Integration {
+		return Integration{
+			notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				if failing && fail {
+					return true, errors.New("fail to deliver notification")
+				}
+				notified[name]++
+				return false, nil
+			}),
+			rs:    sendResolved(true),
+			name:  name,
+			retry: &policy,
+		}
+	}
+	tnflog := &testNflog{
+		qerr:    nflog.ErrNotFound,
+		logFunc: func(*nflogpb.Receiver, string, []uint64, []uint64) error { return nil },
+	}
+	rs := RoutingStage{}
+	rs["team-X"] = createReceiverStage(
+		"team-X",
+		[]Integration{integration("webhook", false), integration("slack", true)},
+		"", rs, func() time.Duration { return 0 }, tnflog, ob, nil, nil, nil, NewMetrics(prometheus.NewRegistry()),
+	)
+
+	alert := &types.Alert{Alert: model.Alert{
+		Labels: model.LabelSet{"alertname": "HighLatency"},
+		EndsAt: time.Now().Add(time.Hour),
+	}}
+	ctx := WithReceiverName(context.Background(), "team-X")
+	ctx = WithGroupKey(ctx, "1")
+	ctx = WithRepeatInterval(ctx, time.Hour)
+	ctx = WithNow(ctx, time.Now())
+	_, _, err = rs.Exec(ctx, log.NewNopLogger(), alert)
+	require.Error(t, err)
+	require.Equal(t, map[string]int{"webhook": 1}, notified)
+	entries := ob.List()
+	require.Len(t, entries, 1)
+	require.Equal(t, "slack", entries[0].Integration)
+
+	// Only the failed integration is notified by the replay.
+	fail = false
+	get := func(model.Fingerprint) (*types.Alert, error) { return alert, nil }
+	notifyFn := OutboxNotifyFunc(func() Stage { return rs }, get, log.NewNopLogger())
+	require.NoError(t, notifyFn(context.Background(), &entries[0]))
+	require.Equal(t, map[string]int{"webhook": 1, "slack": 1}, notified)
+}
+
 func TestRetryStageNoResolved(t *testing.T) {
 	sent := []*types.Alert{}
 	i := Integration{
@@ -474,6 +1216,13 @@ func TestRetryStageNoResolved(t *testing.T) {
 	require.Equal(t, alerts, res)
 	require.Equal(t, []*types.Alert{}, sent)
 	require.NotNil(t, resctx)
+
+	// Digests are sent with their resolved alerts.
+	resctx, res, err = r.Exec(WithDigest(ctx, &digest.Window{Receiver: "digest"}), log.NewNopLogger(), alerts...)
+	require.Nil(t, err)
+	require.Equal(t, alerts, res)
+	require.Equal(t, alerts, sent)
+	require.NotNil(t, resctx)
 }
 
 func TestRetryStageSendResolved(t *testing.T) {
@@ -580,6 +1329,69 @@ func TestSetNotifiesStage(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, alerts, res)
 	require.NotNil(t, resctx)
+
+	// The delivered flapping alerts are recorded in the flap detector.
+	d := NewFlapDetector(types.NewMarker(prometheus.NewRegistry()), prometheus.NewRegistry())
+	d.Configure(&config.FlapDetectionConfig{Window: model.Duration(time.Hour), Threshold: 2})
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	d.Filter("test", now, flappingAlert(now, now.Add(time.Hour)))
+	a := flappingAlert(now.Add(10*time.Minute), now.Add(time.Hour))
+	_, flapping := d.Filter("test", now.Add(10*time.Minute), a)
+	require.Equal(t, map[model.Fingerprint]bool{a.Fingerprint(): true}, flapping)
+
+	s.flaps = d
+	ctx = WithFlapping(WithReceiverName(ctx, "test"), flapping)
+	_, _, err = s.Exec(ctx, log.NewNopLogger(), a)
+	require.NoError(t, err)
+	res, _ = d.Filter("test", now.Add(10*time.Minute), a)
+	require.Empty(t, res)
+}
+
+func TestAckStage(t *testing.T) {
+	acks, err := ack.New(ack.Options{Retention: time.Hour})
+	require.NoError(t, err)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	stage := NewAckStage(ack.NewAcker(acks, marker))
+
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "1"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "2"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "3"}}},
+	}
+	_, err = acks.Create(ack.Ack{Fingerprint: alerts[0].Fingerprint().String(), By: "alice", Comment: "looking into it"})
+	require.NoError(t, err)
+	_, err = acks.Create(ack.Ack{GroupKey: "1", By: "bob"})
+	require.NoError(t, err)
+	_, err = acks.Create(ack.Ack{GroupKey: "2", By: "carol"})
+	require.NoError(t, err)
+
+	ctx := WithGroupKey(context.Background(), "1")
+	ctx = WithReceiverName(ctx, "team-X")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	ctx, res, err := stage.Exec(ctx, log.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res)
+
+	// Acknowledgements of the alert take precedence over the ones of the
+	// group, and acknowledgements of other groups are ignored.
+	got, ok := Acknowledgements(ctx)
+	require.True(t, ok)
+	require.Len(t, got, 3)
+	require.Equal(t, "alice", got[alerts[0].Fingerprint()].By)
+	require.Equal(t, "bob", got[alerts[1].Fingerprint()].By)
+	require.Equal(t, "bob", got[alerts[2].Fingerprint()].By)
+
+	status, ok := marker.Acknowledged(alerts[0].Fingerprint())
+	require.True(t, ok)
+	require.Equal(t, "looking into it", status.Comment)
+
+	// The acknowledgements are passed to the templates.
+	tmpl, err := template.FromGlobs()
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+	data := GetTemplateData(ctx, tmpl, alerts, log.NewNopLogger())
+	require.Equal(t, "alice", data.Alerts[0].Acknowledgement.By)
+	require.Equal(t, "bob", data.Alerts[1].Acknowledgement.By)
 }
 
 func TestMuteStage(t *testing.T) {
@@ -0,0 +1,297 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const (
+	// startTimeout is the time a started plugin has to answer Describe.
+	startTimeout = 10 * time.Second
+	// stopTimeout is the time a plugin has to exit after being interrupted.
+	stopTimeout = 5 * time.Second
+)
+
+// Info describes a running plugin.
+type Info struct {
+	Name string `json:"name"`
+	*DescribeResponse
+}
+
+// Manager starts, supervises and connects to the configured plugins.
+type Manager struct {
+	dir    string
+	logger log.Logger
+
+	mtx     sync.Mutex
+	current *Set
+	// seq numbers the sockets of the plugins started by Alertmanager, so
+	// that a changed plugin starts before its previous instance stops.
+	seq int
+}
+
+// Set holds the plugins of a configuration. It is prepared while the
+// configuration is loaded and replaces the current set once committed.
+type Set struct {
+	m         *Manager
+	instances map[string]*instance
+	// started holds the instances started for the set, which are stopped
+	// if the set is discarded.
+	started []*instance
+}
+
+type instance struct {
+	conf    *config.Plugin
+	socket  string
+	client  *Client
+	info    *DescribeResponse
+	stopped bool
+
+	// Set for plugins started by Alertmanager.
+	cmd    *exec.Cmd
+	exited chan struct{}
+}
+
+// NewManager returns a new plugin manager. The sockets of the plugins it
+// starts are created in a temporary directory.
+func NewManager(l log.Logger) (*Manager, error) {
+	dir, err := os.MkdirTemp("", "alertmanager-plugins")
+	if err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		dir:    dir,
+		logger: l,
+	}
+	m.current = &Set{m: m, instances: map[string]*instance{}}
+	return m, nil
+}
+
+// Prepare returns the set of the given plugins. The unchanged plugins of
+// the current set are reused and the others are started, the current set
+// is left untouched.
+func (m *Manager) Prepare(plugins []*config.Plugin) (*Set, error) {
+	m.mtx.Lock()
+	current := m.current
+	m.mtx.Unlock()
+
+	s := &Set{m: m, instances: make(map[string]*instance, len(plugins))}
+	for _, p := range plugins {
+		if inst, ok := current.instances[p.Name]; ok && reflect.DeepEqual(inst.conf, p) {
+			s.instances[p.Name] = inst
+			continue
+		}
+		inst, err := m.start(p)
+		if err != nil {
+			s.Discard()
+			return nil, errors.Wrapf(err, "failed to start plugin %q", p.Name)
+		}
+		s.instances[p.Name] = inst
+		s.started = append(s.started, inst)
+	}
+	return s, nil
+}
+
+// Sync prepares the set of the given plugins and commits it.
+func (m *Manager) Sync(plugins []*config.Plugin) error {
+	s, err := m.Prepare(plugins)
+	if err != nil {
+		return err
+	}
+	s.Commit()
+	return nil
+}
+
+// Commit makes s the current set and stops the plugins of the previous set
+// which s doesn't use.
+func (s *Set) Commit() {
+	s.m.mtx.Lock()
+	defer s.m.mtx.Unlock()
+
+	prev := s.m.current
+	s.m.current, s.started = s, nil
+	for name, inst := range prev.instances {
+		if s.instances[name] != inst {
+			s.m.stop(inst)
+		}
+	}
+}
+
+// Discard stops the plugins started for s. It is a no-op once s is
+// committed.
+func (s *Set) Discard() {
+	s.m.mtx.Lock()
+	defer s.m.mtx.Unlock()
+
+	for _, inst := range s.started {
+		s.m.stop(inst)
+	}
+	s.started = nil
+}
+
+func (m *Manager) start(p *config.Plugin) (*instance, error) {
+	inst := &instance{conf: p, socket: p.Socket}
+	if p.Command != "" {
+		m.mtx.Lock()
+		m.seq++
+		inst.socket = filepath.Join(m.dir, fmt.Sprintf("%s-%d.sock", p.Name, m.seq))
+		err := m.exec(inst)
+		m.mtx.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := Dial(inst.socket)
+	if err != nil {
+		m.mtx.Lock()
+		m.stop(inst)
+		m.mtx.Unlock()
+		return nil, err
+	}
+	inst.client = client
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+	if inst.info, err = client.Describe(ctx); err != nil {
+		m.mtx.Lock()
+		m.stop(inst)
+		m.mtx.Unlock()
+		return nil, err
+	}
+	level.Info(m.logger).Log("msg", "plugin started", "plugin", p.Name, "name", inst.info.Name, "version", inst.info.Version)
+	return inst, nil
+}
+
+// exec starts the plugin process.
+func (m *Manager) exec(inst *instance) error {
+	cmd := exec.Command(inst.conf.Command, inst.conf.Args...)
+	cmd.Env = append(os.Environ(), SocketEnv+"="+inst.socket)
+	for k, v := range inst.conf.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	// Plugins log to the same destination as Alertmanager.
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		level.Warn(m.logger).Log("msg", "plugin exited", "plugin", inst.conf.Name, "err", err)
+		close(exited)
+	}()
+	inst.cmd, inst.exited = cmd, exited
+	return nil
+}
+
+// stop stops the plugin. The lock must be held.
+func (m *Manager) stop(inst *instance) {
+	inst.stopped = true
+	if inst.client != nil {
+		inst.client.Close()
+	}
+	if inst.cmd == nil {
+		return
+	}
+	select {
+	case <-inst.exited:
+		return
+	default:
+	}
+	if err := inst.cmd.Process.Signal(os.Interrupt); err != nil {
+		inst.cmd.Process.Kill()
+	}
+	select {
+	case <-inst.exited:
+	case <-time.After(stopTimeout):
+		inst.cmd.Process.Kill()
+		<-inst.exited
+	}
+}
+
+// Client returns the client of the named plugin. Plugins started by
+// Alertmanager are restarted if they exited.
+func (s *Set) Client(name string) (*Client, error) {
+	s.m.mtx.Lock()
+	defer s.m.mtx.Unlock()
+
+	inst, ok := s.instances[name]
+	if !ok {
+		return nil, errors.Errorf("unknown plugin %q", name)
+	}
+	if inst.stopped {
+		return nil, errors.Errorf("plugin %q was stopped by a configuration reload", name)
+	}
+	if inst.cmd != nil {
+		select {
+		case <-inst.exited:
+			level.Info(s.m.logger).Log("msg", "restarting plugin", "plugin", name)
+			if err := s.m.exec(inst); err != nil {
+				return nil, errors.Wrapf(err, "failed to restart plugin %q", name)
+			}
+		default:
+		}
+	}
+	return inst.client, nil
+}
+
+// Current returns the current set of plugins.
+func (m *Manager) Current() *Set {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.current
+}
+
+// Plugins returns the description of the running plugins sorted by name.
+func (m *Manager) Plugins() []Info {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	infos := make([]Info, 0, len(m.current.instances))
+	for name, inst := range m.current.instances {
+		infos = append(infos, Info{Name: name, DescribeResponse: inst.info})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Close stops all plugins.
+func (m *Manager) Close() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, inst := range m.current.instances {
+		m.stop(inst)
+	}
+	m.current = &Set{m: m, instances: map[string]*instance{}}
+	os.RemoveAll(m.dir)
+}
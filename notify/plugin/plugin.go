@@ -0,0 +1,110 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// validateTimeout bounds the validation of the configuration by the plugin.
+const validateTimeout = 10 * time.Second
+
+// Notifier implements a Notifier delegating to a plugin.
+type Notifier struct {
+	conf    *config.PluginConfig
+	tmpl    *template.Template
+	logger  log.Logger
+	plugins *Set
+	config  json.RawMessage
+}
+
+// New returns a new plugin notifier using the plugins of the given set. The
+// configuration is validated by the plugin.
+func New(c *config.PluginConfig, s *Set, t *template.Template, l log.Logger) (*Notifier, error) {
+	if s == nil {
+		return nil, errors.New("plugins are not enabled")
+	}
+	client, err := s.Client(c.Plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := json.Marshal(c.Config)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := client.Validate(ctx, cfg); err != nil {
+		return nil, errors.Wrapf(err, "invalid configuration for plugin %q", c.Plugin)
+	}
+
+	return &Notifier{
+		conf:    c,
+		tmpl:    t,
+		logger:  l,
+		plugins: s,
+		config:  cfg,
+	}, nil
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// The client is looked up on each notification so that crashed plugins
+	// get restarted.
+	client, err := n.plugins.Client(n.conf.Plugin)
+	if err != nil {
+		return true, err
+	}
+
+	resp, err := client.Notify(ctx, &NotifyRequest{
+		Config:  n.config,
+		Message: webhook.NewMessage(ctx, n.tmpl, n.logger, 0, as...),
+	})
+	if err != nil {
+		level.Debug(n.logger).Log("msg", "plugin call failed", "plugin", n.conf.Plugin, "incident", key, "err", err)
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted:
+			return true, err
+		}
+		return false, err
+	}
+	if resp.Error != "" {
+		reason := notify.ClientErrorReason
+		if resp.Retry {
+			reason = notify.ServerErrorReason
+		}
+		return resp.Retry, notify.NewErrorWithReason(reason, errors.New(resp.Error))
+	}
+	return false, nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/notify/webhook"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// The test binary doubles as a plugin executable when this variable is set.
+const runPluginEnv = "ALERTMANAGER_TEST_RUN_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runPluginEnv) != "" {
+		if err := Serve(&fakePlugin{}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type fakePluginConfig struct {
+	Channel string `json:"channel"`
+	Fail    string `json:"fail"`
+}
+
+type fakePlugin struct {
+	mtx      sync.Mutex
+	messages []*webhook.Message
+}
+
+func (p *fakePlugin) Describe(context.Context) (*DescribeResponse, error) {
+	return &DescribeResponse{
+		Name:         "fake",
+		Version:      "1.0.0",
+		ConfigSchema: json.RawMessage(`{"type":"object","required":["channel"]}`),
+	}, nil
+}
+
+func (p *fakePlugin) Validate(_ context.Context, config json.RawMessage) error {
+	var c fakePluginConfig
+	if err := json.Unmarshal(config, &c); err != nil {
+		return err
+	}
+	if c.Channel == "" {
+		return errors.New("missing channel")
+	}
+	return nil
+}
+
+func (p *fakePlugin) Notify(_ context.Context, config json.RawMessage, msg *webhook.Message) (bool, error) {
+	var c fakePluginConfig
+	if err := json.Unmarshal(config, &c); err != nil {
+		return false, err
+	}
+	switch c.Fail {
+	case "retry":
+		return true, errors.New("channel unavailable")
+	case "permanent":
+		return false, errors.New("channel archived")
+	}
+	p.mtx.Lock()
+	p.messages = append(p.messages, msg)
+	p.mtx.Unlock()
+	return false, nil
+}
+
+// serve serves the plugin on a Unix socket and returns the socket path and
+// a function stopping the server.
+func serve(t *testing.T, p Plugin) (string, func()) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugin.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	s := NewServer(p)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	return path, s.Stop
+}
+
+func newManager(t *testing.T, plugins ...*config.Plugin) *Manager {
+	t.Helper()
+	m, err := NewManager(log.NewNopLogger())
+	require.NoError(t, err)
+	t.Cleanup(m.Close)
+	require.NoError(t, m.Sync(plugins))
+	return m
+}
+
+func testAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestPluginNotify(t *testing.T) {
+	fake := &fakePlugin{}
+	path, _ := serve(t, fake)
+	m := newManager(t, &config.Plugin{Name: "chat", Socket: path})
+
+	require.Equal(t, []Info{{
+		Name: "chat",
+		DescribeResponse: &DescribeResponse{
+			Name:         "fake",
+			Version:      "1.0.0",
+			ConfigSchema: json.RawMessage(`{"type":"object","required":["channel"]}`),
+		},
+	}}, m.Plugins())
+
+	_, err := New(&config.PluginConfig{Plugin: "chat", Config: map[string]interface{}{}}, m.current, test.CreateTmpl(t), log.NewNopLogger())
+	require.EqualError(t, err, `invalid configuration for plugin "chat": missing channel`)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	for _, tc := range []struct {
+		fail  string
+		retry bool
+		err   string
+	}{
+		{},
+		{fail: "retry", retry: true, err: "channel unavailable"},
+		{fail: "permanent", err: "channel archived"},
+	} {
+		t.Run(tc.fail, func(t *testing.T) {
+			n, err := New(&config.PluginConfig{
+				Plugin: "chat",
+				Config: map[string]interface{}{"channel": "#ops", "fail": tc.fail},
+			}, m.current, test.CreateTmpl(t), log.NewNopLogger())
+			require.NoError(t, err)
+
+			retry, err := n.Notify(ctx, testAlert())
+			require.Equal(t, tc.retry, retry)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+
+	require.Len(t, fake.messages, 1)
+	require.Equal(t, "4", fake.messages[0].Version)
+	require.Equal(t, "1", fake.messages[0].GroupKey)
+	require.Len(t, fake.messages[0].Alerts, 1)
+}
+
+func TestPluginUnavailable(t *testing.T) {
+	path, stop := serve(t, &fakePlugin{})
+	m := newManager(t, &config.Plugin{Name: "chat", Socket: path})
+
+	n, err := New(&config.PluginConfig{Plugin: "chat", Config: map[string]interface{}{"channel": "#ops"}}, m.current, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	stop()
+
+	ctx, cancel := context.WithTimeout(notify.WithGroupKey(context.Background(), "1"), 200*time.Millisecond)
+	defer cancel()
+	retry, err := n.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.True(t, retry)
+}
+
+func TestPluginCommand(t *testing.T) {
+	p := &config.Plugin{
+		Name:    "chat",
+		Command: os.Args[0],
+		Env:     map[string]string{runPluginEnv: "1"},
+	}
+	m := newManager(t, p)
+
+	n, err := New(&config.PluginConfig{Plugin: "chat", Config: map[string]interface{}{"channel": "#ops"}}, m.current, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(notify.WithGroupKey(context.Background(), "1"), 10*time.Second)
+	defer cancel()
+	_, err = n.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	// A crashed plugin is restarted on the next notification.
+	inst := m.current.instances["chat"]
+	require.NoError(t, inst.cmd.Process.Kill())
+	<-inst.exited
+	_, err = n.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	// Plugins removed from the configuration are stopped.
+	inst = m.current.instances["chat"]
+	require.NoError(t, m.Sync(nil))
+	select {
+	case <-inst.exited:
+	default:
+		t.Fatal("plugin still running")
+	}
+	require.Empty(t, m.Plugins())
+	_, err = n.Notify(ctx, testAlert())
+	require.EqualError(t, err, `plugin "chat" was stopped by a configuration reload`)
+}
+
+func TestPluginPrepareFailure(t *testing.T) {
+	p := &config.Plugin{
+		Name:    "chat",
+		Command: os.Args[0],
+		Env:     map[string]string{runPluginEnv: "1"},
+	}
+	m := newManager(t, p)
+	inst := m.current.instances["chat"]
+
+	// A failed configuration stops the plugins started for it and keeps the
+	// current ones running.
+	changed := *p
+	changed.Env = map[string]string{runPluginEnv: "1", "CHANGED": "1"}
+	_, err := m.Prepare([]*config.Plugin{&changed, {Name: "broken", Command: "/nonexistent"}})
+	require.Error(t, err)
+	require.Equal(t, inst, m.current.instances["chat"])
+	require.False(t, inst.stopped)
+
+	n, err := New(&config.PluginConfig{Plugin: "chat", Config: map[string]interface{}{"channel": "#ops"}}, m.current, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(notify.WithGroupKey(context.Background(), "1"), 10*time.Second)
+	defer cancel()
+	_, err = n.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	// A committed set replaces the changed plugins.
+	s, err := m.Prepare([]*config.Plugin{&changed})
+	require.NoError(t, err)
+	require.False(t, inst.stopped)
+	s.Commit()
+	require.True(t, inst.stopped)
+	require.NotEqual(t, inst, m.current.instances["chat"])
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/alertmanager/notify/webhook"
+)
+
+// SocketEnv is the environment variable holding the path of the Unix socket
+// a plugin started by Alertmanager has to serve on.
+const SocketEnv = "ALERTMANAGER_PLUGIN_SOCKET"
+
+// The plugin protocol is a gRPC service whose messages are encoded as JSON,
+// so that plugins can be written without generated code.
+const serviceName = "alertmanager.plugin.v1.Notifier"
+
+// DescribeResponse describes a plugin.
+type DescribeResponse struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// ConfigSchema is the JSON schema of the plugin configuration.
+	ConfigSchema json.RawMessage `json:"configSchema,omitempty"`
+}
+
+// ValidateRequest asks the plugin to validate an integration configuration.
+type ValidateRequest struct {
+	Config json.RawMessage `json:"config"`
+}
+
+// NotifyRequest asks the plugin to deliver a notification.
+type NotifyRequest struct {
+	Config  json.RawMessage  `json:"config"`
+	Message *webhook.Message `json:"message"`
+}
+
+// NotifyResponse is the outcome of a notification.
+type NotifyResponse struct {
+	// Error describes why the notification failed. It is empty on success.
+	Error string `json:"error,omitempty"`
+	// Retry tells whether a failed notification should be retried.
+	Retry bool `json:"retry,omitempty"`
+}
+
+type empty struct{}
+
+// Plugin is implemented by notifier plugins.
+type Plugin interface {
+	// Describe returns the plugin name, version and configuration schema.
+	Describe(ctx context.Context) (*DescribeResponse, error)
+	// Validate returns an error if the configuration is invalid.
+	Validate(ctx context.Context, config json.RawMessage) error
+	// Notify delivers a notification. It follows the notify.Notifier
+	// contract: the returned boolean tells whether a failure is retryable.
+	Notify(ctx context.Context, config json.RawMessage, msg *webhook.Message) (bool, error)
+}
+
+// jsonCodec is set on the plugin clients and servers only, the gRPC "json"
+// codec isn't registered globally.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Plugin)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				if err := dec(&empty{}); err != nil {
+					return nil, err
+				}
+				return srv.(Plugin).Describe(ctx)
+			},
+		},
+		{
+			MethodName: "Validate",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				var req ValidateRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				if err := srv.(Plugin).Validate(ctx, req.Config); err != nil {
+					return nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				return &empty{}, nil
+			},
+		},
+		{
+			MethodName: "Notify",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				var req NotifyRequest
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				var resp NotifyResponse
+				retry, err := srv.(Plugin).Notify(ctx, req.Config, req.Message)
+				if err != nil {
+					resp.Error, resp.Retry = err.Error(), retry
+				}
+				return &resp, nil
+			},
+		},
+	},
+}
+
+// NewServer returns a gRPC server exposing the plugin.
+func NewServer(p Plugin) *grpc.Server {
+	s := grpc.NewServer(grpc.ForceServerCodec(jsonCodec{}))
+	s.RegisterService(&serviceDesc, p)
+	return s
+}
+
+// Serve serves the plugin on the Unix socket given by Alertmanager in the
+// ALERTMANAGER_PLUGIN_SOCKET environment variable. It is meant to be called
+// from the main function of plugin executables.
+func Serve(p Plugin) error {
+	path := os.Getenv(SocketEnv)
+	if path == "" {
+		return errors.Errorf("%s is not set", SocketEnv)
+	}
+	// Remove the socket left over by a previous instance.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	return NewServer(p).Serve(l)
+}
+
+// Client calls a plugin.
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// Dial returns a client for the plugin serving on the given Unix socket.
+// The connection is established lazily and re-established after failures.
+func Dial(path string) (*Client, error) {
+	conn, err := grpc.Dial(
+		"unix://"+path,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// Calls wait for the plugin to be reachable, bounded by the deadline
+		// of their context, so that (re)started plugins have time to come up.
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{}), grpc.WaitForReady(true)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Describe calls the Describe method of the plugin.
+func (c *Client) Describe(ctx context.Context) (*DescribeResponse, error) {
+	var resp DescribeResponse
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/Describe", &empty{}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Validate calls the Validate method of the plugin.
+func (c *Client) Validate(ctx context.Context, config json.RawMessage) error {
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/Validate", &ValidateRequest{Config: config}, &empty{})
+	if s, ok := status.FromError(err); ok && s.Code() == codes.InvalidArgument {
+		return errors.New(s.Message())
+	}
+	return err
+}
+
+// Notify calls the Notify method of the plugin.
+func (c *Client) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	var resp NotifyResponse
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/Notify", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Close closes the connection to the plugin.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
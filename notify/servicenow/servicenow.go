@@ -0,0 +1,299 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Maximum length of the short_description field of the incident table.
+const maxShortDescriptionRunes = 160
+
+// Notifier implements a Notifier for ServiceNow incidents.
+type Notifier struct {
+	conf    *config.ServiceNowConfig
+	tmpl    *template.Template
+	logger  log.Logger
+	client  *http.Client
+	retrier *notify.Retrier
+}
+
+// New returns a new ServiceNow notifier.
+func New(c *config.ServiceNowConfig, t *template.Template, l log.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := notify.NewClientWithTracing(*c.HTTPConfig, "servicenow", append(httpOpts, commoncfg.WithHTTP2Disabled())...)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// The Table API answers 429 when the instance rate limit is exceeded.
+		retrier: &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+	}, nil
+}
+
+// tableResponse is the envelope returned by the ServiceNow Table API.
+type tableResponse struct {
+	Result []struct {
+		SysID string `json:"sys_id"`
+	} `json:"result"`
+}
+
+// Notify implements the Notifier interface.
+//
+// The incident is keyed on a correlation ID derived from the group key: a
+// firing group creates the incident or updates the open one, a resolved group
+// moves the open incident to the resolved state.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	correlationID := key.Hash()
+
+	level.Debug(n.logger).Log("incident", key, "correlation_id", correlationID)
+
+	sysID, retry, err := n.findIncident(ctx, correlationID)
+	if err != nil {
+		return retry, err
+	}
+
+	var (
+		alerts = types.Alerts(as...)
+		data   = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+		fields map[string]string
+	)
+	switch alerts.Status() {
+	case model.AlertResolved:
+		if sysID == "" {
+			level.Debug(n.logger).Log("msg", "no open incident to resolve", "correlation_id", correlationID)
+			return false, nil
+		}
+		fields, err = n.resolveFields(data)
+	default:
+		fields, err = n.incidentFields(data, correlationID, as...)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	u := n.tableURL()
+	method := http.MethodPost
+	if sysID != "" {
+		u.Path += "/" + sysID
+		method = http.MethodPatch
+	}
+	resp, err := n.do(ctx, method, u.String(), fields)
+	if err != nil {
+		return true, err
+	}
+	defer notify.Drain(resp)
+
+	return n.check(resp)
+}
+
+// findIncident returns the sys_id of the active incident with the given
+// correlation ID or an empty string if there is none.
+func (n *Notifier) findIncident(ctx context.Context, correlationID string) (string, bool, error) {
+	u := n.tableURL()
+	q := u.Query()
+	q.Set("sysparm_query", fmt.Sprintf("correlation_id=%s^active=true", correlationID))
+	q.Set("sysparm_fields", "sys_id")
+	q.Set("sysparm_limit", "1")
+	u.RawQuery = q.Encode()
+
+	resp, err := n.do(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", true, err
+	}
+	defer notify.Drain(resp)
+
+	if resp.StatusCode/100 != 2 {
+		retry, err := n.check(resp)
+		return "", retry, err
+	}
+
+	var tr tableResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", true, errors.Wrap(err, "failed to decode ServiceNow response")
+	}
+	if len(tr.Result) == 0 {
+		return "", false, nil
+	}
+	return tr.Result[0].SysID, false, nil
+}
+
+// incidentFields returns the incident record for a firing alert group.
+func (n *Notifier) incidentFields(data *template.Data, correlationID string, as ...*types.Alert) (map[string]string, error) {
+	var err error
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	shortDescription, truncated := notify.TruncateInRunes(tmpl(n.conf.ShortDescription), maxShortDescriptionRunes)
+	if truncated {
+		level.Debug(n.logger).Log("msg", "truncated short_description", "correlation_id", correlationID)
+	}
+
+	impact, urgency := n.impactUrgency(as...)
+	fields := map[string]string{
+		"correlation_id":    correlationID,
+		"short_description": shortDescription,
+		"description":       tmpl(n.conf.Description),
+		"impact":            impact,
+		"urgency":           urgency,
+	}
+	for name, v := range map[string]string{
+		"caller_id":        n.conf.CallerID,
+		"assignment_group": n.conf.AssignmentGroup,
+		"category":         n.conf.Category,
+		"subcategory":      n.conf.Subcategory,
+		"cmdb_ci":          n.conf.CMDBCI,
+	} {
+		if v == "" {
+			continue
+		}
+		fields[name] = tmpl(v)
+	}
+	for k, v := range n.conf.CustomFields {
+		fields[k] = tmpl(v)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "templating error")
+	}
+	return fields, nil
+}
+
+// resolveFields returns the update applied to an incident once its alert
+// group has resolved.
+func (n *Notifier) resolveFields(data *template.Data) (map[string]string, error) {
+	var err error
+	tmpl := notify.TmplText(n.tmpl, data, &err)
+
+	fields := map[string]string{
+		"state":       n.conf.ResolvedState,
+		"close_code":  tmpl(n.conf.CloseCode),
+		"close_notes": tmpl(n.conf.CloseNotes),
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "templating error")
+	}
+	return fields, nil
+}
+
+// impactUrgency returns the impact and urgency of the most severe firing
+// alert according to the configured severity mapping.
+func (n *Notifier) impactUrgency(as ...*types.Alert) (string, string) {
+	impact, urgency := n.conf.DefaultImpact, n.conf.DefaultUrgency
+	var best *config.ServiceNowImpactUrgency
+	for _, a := range as {
+		if a.Resolved() {
+			continue
+		}
+		m, ok := n.conf.SeverityMapping[string(a.Labels[model.LabelName(n.conf.SeverityLabel)])]
+		if !ok {
+			continue
+		}
+		if best == nil || moreSevere(m, *best) {
+			m := m
+			best = &m
+		}
+	}
+	if best != nil {
+		impact, urgency = best.Impact, best.Urgency
+	}
+	return impact, urgency
+}
+
+// moreSevere returns true if a yields a higher incident priority than b.
+// Values are ranked from 1 (high) downwards and the priority grows with the
+// sum of the impact and urgency, as in the default priority lookup of
+// ServiceNow. Ties go to the higher impact. Non-numeric values rank last.
+func moreSevere(a, b config.ServiceNowImpactUrgency) bool {
+	ai, aerr := strconv.Atoi(a.Impact)
+	au, uerr := strconv.Atoi(a.Urgency)
+	if aerr != nil || uerr != nil {
+		return false
+	}
+	bi, berr := strconv.Atoi(b.Impact)
+	bu, uerr := strconv.Atoi(b.Urgency)
+	if berr != nil || uerr != nil {
+		return true
+	}
+	if ai+au != bi+bu {
+		return ai+au < bi+bu
+	}
+	return ai < bi
+}
+
+func (n *Notifier) tableURL() *url.URL {
+	u := n.conf.APIURL.Copy()
+	u.Path += "api/now/table/" + n.conf.Table
+	return u.URL
+}
+
+func (n *Notifier) do(ctx context.Context, method, u string, fields map[string]string) (*http.Response, error) {
+	var body io.Reader
+	if fields != nil {
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(fields); err != nil {
+			return nil, err
+		}
+		body = &buf
+	}
+
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(n.conf.Username, string(n.conf.Password))
+
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err == nil {
+		notify.RecordStatusCode(ctx, resp.StatusCode)
+	}
+	return resp, err
+}
+
+func (n *Notifier) check(resp *http.Response) (bool, error) {
+	retry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return retry, notify.NewErrorWithReason(notify.GetFailureReason(resp.StatusCode, err.Error()), err)
+	}
+	return retry, nil
+}
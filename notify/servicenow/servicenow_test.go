@@ -0,0 +1,261 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestConfig(u *url.URL) *config.ServiceNowConfig {
+	c := config.DefaultServiceNowConfig
+	c.APIURL = &config.URL{URL: u}
+	c.Username = "admin"
+	c.Password = "secret"
+	c.HTTPConfig = &commoncfg.HTTPClientConfig{}
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+	return &c
+}
+
+func TestServiceNowRetry(t *testing.T) {
+	u, _ := url.Parse("http://example.service-now.com/")
+	notifier, err := New(newTestConfig(u), test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	retryCodes := append(test.DefaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range test.RetryTests(retryCodes) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestServiceNowIncidentLifecycle(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   map[string]string
+	}
+	var (
+		requests []request
+		sysID    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "admin", user)
+		require.Equal(t, "secret", pass)
+
+		req := request{method: r.Method, path: r.URL.Path}
+		if r.Method != http.MethodGet {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req.body))
+		}
+		requests = append(requests, req)
+
+		switch r.Method {
+		case http.MethodGet:
+			require.Contains(t, r.URL.Query().Get("sysparm_query"), "correlation_id=")
+			if sysID == "" {
+				fmt.Fprint(w, `{"result":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"result":[{"sys_id":%q}]}`, sysID)
+		case http.MethodPost:
+			sysID = "abc123"
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"result":{"sys_id":%q}}`, sysID)
+		default:
+			fmt.Fprint(w, `{"result":{}}`)
+		}
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	notifier, err := New(newTestConfig(u), test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	firing := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+
+	// The first notification creates the incident.
+	retry, err := notifier.Notify(ctx, firing)
+	require.NoError(t, err)
+	require.False(t, retry)
+	require.Len(t, requests, 2)
+	require.Equal(t, http.MethodPost, requests[1].method)
+	require.Equal(t, "/api/now/table/incident", requests[1].path)
+	require.Equal(t, notify.Key("1").Hash(), requests[1].body["correlation_id"])
+	require.Equal(t, "1", requests[1].body["impact"])
+	require.Equal(t, "1", requests[1].body["urgency"])
+
+	// A repeated notification updates the open incident.
+	_, err = notifier.Notify(ctx, firing)
+	require.NoError(t, err)
+	require.Len(t, requests, 4)
+	require.Equal(t, http.MethodPatch, requests[3].method)
+	require.Equal(t, "/api/now/table/incident/abc123", requests[3].path)
+
+	// The resolved notification resolves the incident.
+	resolved := &types.Alert{
+		Alert: model.Alert{
+			Labels:   firing.Labels,
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	}
+	_, err = notifier.Notify(ctx, resolved)
+	require.NoError(t, err)
+	require.Len(t, requests, 6)
+	require.Equal(t, http.MethodPatch, requests[5].method)
+	require.Equal(t, "6", requests[5].body["state"])
+	require.Equal(t, "Solved (Permanently)", requests[5].body["close_code"])
+	require.NotContains(t, requests[5].body, "short_description")
+}
+
+func TestServiceNowResolvedWithoutIncident(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		fmt.Fprint(w, `{"result":[]}`)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	notifier, err := New(newTestConfig(u), test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{http.MethodGet}, methods)
+}
+
+func TestServiceNowImpactUrgency(t *testing.T) {
+	u, _ := url.Parse("http://example.service-now.com/")
+	conf := newTestConfig(u)
+	conf.SeverityMapping["impact-1"] = config.ServiceNowImpactUrgency{Impact: "1", Urgency: "3"}
+	conf.SeverityMapping["urgency-1"] = config.ServiceNowImpactUrgency{Impact: "3", Urgency: "1"}
+	notifier, err := New(conf, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	alert := func(severity string, resolved bool) *types.Alert {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"severity": model.LabelValue(severity)},
+				StartsAt: time.Now().Add(-time.Hour),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+		}
+		if resolved {
+			a.EndsAt = time.Now().Add(-time.Minute)
+		}
+		return a
+	}
+
+	for _, tc := range []struct {
+		name            string
+		alerts          []*types.Alert
+		impact, urgency string
+	}{
+		{
+			name:    "unknown severity uses the defaults",
+			alerts:  []*types.Alert{alert("unknown", false)},
+			impact:  "3",
+			urgency: "3",
+		},
+		{
+			name:    "most severe firing alert wins",
+			alerts:  []*types.Alert{alert("info", false), alert("warning", false)},
+			impact:  "2",
+			urgency: "2",
+		},
+		{
+			name:    "priority is computed from impact and urgency",
+			alerts:  []*types.Alert{alert("impact-1", false), alert("error", false)},
+			impact:  "2",
+			urgency: "1",
+		},
+		{
+			name:    "higher impact wins ties",
+			alerts:  []*types.Alert{alert("urgency-1", false), alert("impact-1", false)},
+			impact:  "1",
+			urgency: "3",
+		},
+		{
+			name:    "resolved alerts are ignored",
+			alerts:  []*types.Alert{alert("critical", true), alert("info", false)},
+			impact:  "3",
+			urgency: "3",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			impact, urgency := notifier.impactUrgency(tc.alerts...)
+			require.Equal(t, tc.impact, impact)
+			require.Equal(t, tc.urgency, urgency)
+		})
+	}
+}
+
+func TestServiceNowTemplating(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result":[]}`)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	cfg := newTestConfig(u)
+	cfg.ShortDescription = "{{ .CommonLabels.alertname }"
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "templating error")
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Notifier implements a Notifier for SMS notifications.
+type Notifier struct {
+	conf    *config.SMSConfig
+	tmpl    *template.Template
+	logger  log.Logger
+	client  *http.Client
+	retrier *notify.Retrier
+
+	// mtx serializes sends so that consecutive messages are at least
+	// SendInterval apart.
+	mtx      sync.Mutex
+	lastSend time.Time
+}
+
+// New returns a new SMS notifier.
+func New(c *config.SMSConfig, t *template.Template, l log.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := notify.NewClientWithTracing(*c.HTTPConfig, "sms", append(httpOpts, commoncfg.WithHTTP2Disabled())...)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		client: client,
+		// Gateways answer 429 when the sending rate is exceeded.
+		retrier: &notify.Retrier{RetryCodes: []int{http.StatusTooManyRequests}},
+	}, nil
+}
+
+// Notify implements the Notifier interface.
+//
+// A message is sent to every recipient. Recipients are attempted even if an
+// earlier one failed; the returned error covers all failed recipients. The
+// notification is only retried if no recipient was delivered, so that no
+// recipient receives the message twice.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		data = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+		tmpl = notify.TmplText(n.tmpl, data, &err)
+	)
+
+	message, truncated := notify.TruncateInRunes(strings.TrimSpace(tmpl(n.conf.Message)), n.conf.MaxMessageLength)
+	if truncated {
+		level.Debug(n.logger).Log("msg", "truncated message", "truncated_message", message, "incident", key)
+	}
+
+	from := tmpl(n.conf.From)
+	recipients := make([]string, 0, len(n.conf.To))
+	for _, to := range n.conf.To {
+		if to = strings.TrimSpace(tmpl(to)); to != "" {
+			recipients = append(recipients, to)
+		}
+	}
+
+	params := url.Values{}
+	if n.conf.Provider == config.SMSProviderGeneric {
+		for k, v := range n.conf.FormParams {
+			params.Set(k, tmpl(v))
+		}
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "templating error")
+	}
+	if len(recipients) == 0 {
+		return false, errors.New("no recipients after templating")
+	}
+
+	var (
+		retry     bool
+		delivered int
+		errs      types.MultiError
+	)
+	for _, to := range recipients {
+		if err := n.wait(ctx); err != nil {
+			// Retrying would text the delivered recipients again.
+			return delivered == 0, err
+		}
+		r, err := n.send(ctx, from, to, message, params)
+		if err != nil {
+			level.Debug(n.logger).Log("msg", "failed to send message", "incident", key, "err", err)
+			retry = retry || r
+			errs.Add(errors.Wrapf(err, "recipient %s", to))
+			continue
+		}
+		delivered++
+	}
+	if errs.Len() > 0 {
+		// Retrying would text the delivered recipients again.
+		return retry && delivered == 0, &errs
+	}
+	return false, nil
+}
+
+// wait blocks until the send interval since the previous message has elapsed
+// and reserves the next slot.
+func (n *Notifier) wait(ctx context.Context) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if d := time.Until(n.lastSend.Add(time.Duration(n.conf.SendInterval))); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	n.lastSend = time.Now()
+	return nil
+}
+
+func (n *Notifier) send(ctx context.Context, from, to, message string, extra url.Values) (bool, error) {
+	var (
+		u      = n.conf.APIURL.Copy().URL
+		params = url.Values{}
+	)
+	switch n.conf.Provider {
+	case config.SMSProviderTwilio:
+		u.Path += fmt.Sprintf("Accounts/%s/Messages.json", url.PathEscape(n.conf.AccountSID))
+		params.Set("From", from)
+		params.Set("To", to)
+		params.Set("Body", message)
+	default:
+		for k, v := range extra {
+			params[k] = v
+		}
+		if n.conf.FromParam != "" && from != "" {
+			params.Set(n.conf.FromParam, from)
+		}
+		params.Set(n.conf.ToParam, to)
+		params.Set(n.conf.MessageParam, message)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if n.conf.Provider == config.SMSProviderTwilio {
+		req.SetBasicAuth(n.conf.AccountSID, string(n.conf.AuthToken))
+	}
+
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	notify.RecordStatusCode(ctx, resp.StatusCode)
+	defer notify.Drain(resp)
+
+	retry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return retry, notify.NewErrorWithReason(notify.GetFailureReason(resp.StatusCode, err.Error()), err)
+	}
+	return false, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-kit/log"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func testAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			Annotations: model.LabelSet{"summary": "p99 latency above 2s"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestSMSRetry(t *testing.T) {
+	u, _ := url.Parse("http://example.com/")
+	notifier, err := New(
+		&config.SMSConfig{
+			Provider:   config.SMSProviderTwilio,
+			APIURL:     &config.URL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		log.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	retryCodes := append(test.DefaultRetryCodes(), http.StatusTooManyRequests)
+	for statusCode, expected := range test.RetryTests(retryCodes) {
+		actual, _ := notifier.retrier.Check(statusCode, nil)
+		require.Equal(t, expected, actual, fmt.Sprintf("error on status %d", statusCode))
+	}
+}
+
+func TestSMSTwilio(t *testing.T) {
+	var (
+		mtx   sync.Mutex
+		forms []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "AC123", user)
+		require.Equal(t, "token", pass)
+		require.NoError(t, r.ParseForm())
+
+		mtx.Lock()
+		forms = append(forms, r.PostForm)
+		mtx.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL + "/2010-04-01/")
+
+	cfg := config.DefaultSMSConfig
+	cfg.APIURL = &config.URL{URL: u}
+	cfg.AccountSID = "AC123"
+	cfg.AuthToken = "token"
+	cfg.From = "+15550000000"
+	cfg.To = []string{"+15551111111", "{{ .CommonLabels.severity | reReplaceAll \"critical\" \"+15552222222\" }}"}
+	cfg.MaxMessageLength = 20
+	cfg.SendInterval = 0
+	cfg.HTTPConfig = &commoncfg.HTTPClientConfig{}
+
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency"})
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	require.Len(t, forms, 2)
+	require.Equal(t, "+15551111111", forms[0].Get("To"))
+	require.Equal(t, "+15552222222", forms[1].Get("To"))
+	for _, f := range forms {
+		require.Equal(t, "+15550000000", f.Get("From"))
+		require.Equal(t, 20, utf8.RuneCountInString(f.Get("Body")))
+	}
+}
+
+func TestSMSGeneric(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL + "/send")
+
+	cfg := config.DefaultSMSConfig
+	cfg.Provider = config.SMSProviderGeneric
+	cfg.APIURL = &config.URL{URL: u}
+	cfg.To = []string{"0612345678"}
+	cfg.ToParam = "msisdn"
+	cfg.MessageParam = "text"
+	cfg.Message = "{{ .CommonAnnotations.summary }}"
+	cfg.FormParams = map[string]string{"priority": "{{ .CommonLabels.severity }}"}
+	cfg.HTTPConfig = &commoncfg.HTTPClientConfig{}
+
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	require.Equal(t, "0612345678", form.Get("msisdn"))
+	require.Equal(t, "p99 latency above 2s", form.Get("text"))
+	require.Equal(t, "critical", form.Get("priority"))
+	require.Empty(t, form.Get("from"))
+}
+
+func TestSMSPartialFailure(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("to") == "bad" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	cfg := config.DefaultSMSConfig
+	cfg.Provider = config.SMSProviderGeneric
+	cfg.APIURL = &config.URL{URL: u}
+	cfg.To = []string{"bad", "good"}
+	cfg.SendInterval = 0
+	cfg.HTTPConfig = &commoncfg.HTTPClientConfig{}
+
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, testAlert())
+	require.ErrorContains(t, err, "recipient bad")
+	// Retrying would text the delivered recipient again.
+	require.False(t, retry)
+	// The failing recipient does not prevent the others from being notified.
+	require.Equal(t, 2, calls)
+
+	// The notification is retried if no recipient was delivered.
+	notifier.conf.To = []string{"bad"}
+	retry, err = notifier.Notify(ctx, testAlert())
+	require.Error(t, err)
+	require.True(t, retry)
+}
+
+func TestSMSSendInterval(t *testing.T) {
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	cfg := config.DefaultSMSConfig
+	cfg.Provider = config.SMSProviderGeneric
+	cfg.APIURL = &config.URL{URL: u}
+	cfg.To = []string{"a", "b", "c"}
+	cfg.SendInterval = config.DefaultSMSConfig.SendInterval / 20
+	cfg.HTTPConfig = &commoncfg.HTTPClientConfig{}
+
+	notifier, err := New(&cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, testAlert())
+	require.NoError(t, err)
+
+	require.Len(t, times, 3)
+	for i := 1; i < len(times); i++ {
+		require.GreaterOrEqual(t, times[i].Sub(times[i-1]), 40*time.Millisecond)
+	}
+}
@@ -22,12 +22,15 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
 
 	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/tracing"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/common/version"
 )
@@ -40,6 +43,17 @@ var UserAgentHeader = fmt.Sprintf("Alertmanager/%s", version.Version)
 // Retry messages
 var RetryMsgs = []string{"Microsoft Teams endpoint returned HTTP error 429"}
 
+// NewClientWithTracing returns the HTTP client of the configuration, whose
+// requests are traced.
+func NewClientWithTracing(cfg commoncfg.HTTPClientConfig, name string, httpOpts ...commoncfg.HTTPClientOption) (*http.Client, error) {
+	client, err := commoncfg.NewClientFromConfig(cfg, name, httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = tracing.Transport(client.Transport, name)
+	return client, nil
+}
+
 // RedactURL removes the URL part from an error of *url.Error type.
 func RedactURL(err error) error {
 	e, ok := err.(*url.Error)
@@ -78,7 +92,11 @@ func request(ctx context.Context, client *http.Client, method string, url string
 	if bodyType != "" {
 		req.Header.Set("Content-Type", bodyType)
 	}
-	return client.Do(req.WithContext(ctx))
+	resp, err := client.Do(req.WithContext(ctx))
+	if err == nil {
+		RecordStatusCode(ctx, resp.StatusCode)
+	}
+	return resp, err
 }
 
 // Drain consumes and closes the response's body to make sure that the
@@ -174,7 +192,21 @@ func GetTemplateData(ctx context.Context, tmpl *template.Template, alerts []*typ
 	if !ok {
 		level.Error(l).Log("msg", "Missing group labels")
 	}
-	return tmpl.Data(recv, groupLabels, alerts...)
+	data := tmpl.Data(recv, groupLabels, alerts...)
+	if acks, ok := Acknowledgements(ctx); ok && len(acks) > 0 {
+		for i, a := range alerts {
+			data.Alerts[i].Acknowledgement = acks[a.Fingerprint()]
+		}
+	}
+	if flapping, ok := Flapping(ctx); ok && len(flapping) > 0 {
+		for i, a := range alerts {
+			data.Alerts[i].Flapping = flapping[a.Fingerprint()]
+		}
+	}
+	if w, ok := Digest(ctx); ok {
+		data.Digest = template.NewDigest(w.Start, w.End, w.Fired, w.Resolved, w.Open)
+	}
+	return data
 }
 
 func readAll(r io.Reader) string {
@@ -304,3 +336,105 @@ func GetFailureReason(statusCode int, responseContent string) Reason {
 	}
 	return DefaultReason
 }
+
+// SharedClient holds the connection of an integration to a broker, which is
+// dialed on the first notification and reused by the following ones. The
+// integrations are rebuilt when the configuration is reloaded, and the
+// previous ones are closed then. A connection is only closed once the
+// notifications using it released it, and a notification starting on a
+// closed integration gets a connection of its own.
+type SharedClient[T comparable] struct {
+	dial  func(context.Context) (T, error)
+	close func(T)
+
+	mtx    sync.Mutex
+	client T
+	// refs counts the notifications using each connection.
+	refs   map[T]int
+	closed bool
+}
+
+// NewSharedClient returns a shared client dialing and closing connections
+// with the given functions.
+func NewSharedClient[T comparable](dial func(context.Context) (T, error), close func(T)) *SharedClient[T] {
+	return &SharedClient[T]{dial: dial, close: close, refs: map[T]int{}}
+}
+
+// Get returns the connection, dialing it if needed, and a function to call
+// once the connection isn't used anymore.
+func (c *SharedClient[T]) Get(ctx context.Context) (T, func(), error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var zero T
+	if c.closed {
+		client, err := c.dial(ctx)
+		if err != nil {
+			return zero, nil, err
+		}
+		return client, func() { c.close(client) }, nil
+	}
+	if c.client == zero {
+		client, err := c.dial(ctx)
+		if err != nil {
+			return zero, nil, err
+		}
+		c.client = client
+	}
+	client := c.client
+	c.refs[client]++
+	var once sync.Once
+	return client, func() { once.Do(func() { c.release(client) }) }, nil
+}
+
+// release records that a notification doesn't use the connection anymore,
+// and closes it if it is the last one and the connection isn't shared
+// anymore.
+func (c *SharedClient[T]) release(client T) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.refs[client]--
+	if c.refs[client] > 0 {
+		return
+	}
+	delete(c.refs, client)
+	if client != c.client {
+		c.close(client)
+	}
+}
+
+// drop stops sharing the connection, closing it unless notifications still
+// use it. The mutex must be held.
+func (c *SharedClient[T]) drop() {
+	var zero T
+	client := c.client
+	c.client = zero
+	if client != zero && c.refs[client] == 0 {
+		c.close(client)
+	}
+}
+
+// Reset stops sharing the connection if it is still the shared one, so that
+// the next notification dials a new one. It is called when the connection is
+// broken.
+func (c *SharedClient[T]) Reset(client T) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var zero T
+	if c.client == client && client != zero {
+		c.drop()
+	}
+}
+
+// Close closes the connection once the notifications using it released it.
+// It implements the io.Closer interface.
+func (c *SharedClient[T]) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.drop()
+	c.closed = true
+	return nil
+}
@@ -15,6 +15,7 @@ package notify
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -177,3 +178,41 @@ func TestRetrierCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestSharedClient(t *testing.T) {
+	var dialed, closed int
+	c := NewSharedClient(func(context.Context) (int, error) {
+		dialed++
+		return dialed, nil
+	}, func(int) { closed++ })
+
+	// The client is dialed once and reused.
+	for i := 0; i < 2; i++ {
+		client, release, err := c.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, client)
+		release()
+	}
+	require.Equal(t, 0, closed)
+
+	// A reset client is closed and dialed again.
+	c.Reset(1)
+	require.Equal(t, 1, closed)
+	client, release, err := c.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, client)
+
+	// A client in use is only closed once released.
+	require.NoError(t, c.Close())
+	require.Equal(t, 1, closed)
+	release()
+	release()
+	require.Equal(t, 2, closed)
+
+	// Once closed, each call gets its own client.
+	client, release, err = c.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, client)
+	release()
+	require.Equal(t, 3, closed)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+
+	// The event types are suffixed with the status of the alert group or
+	// the alert.
+	alertGroupEventType = "io.prometheus.alertmanager.alertgroup."
+	alertEventType      = "io.prometheus.alertmanager.alert."
+)
+
+// Event is a CloudEvents 1.0 event in the JSON event format.
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            *Message  `json:"data"`
+}
+
+func newEvent(source, typ string, msg *Message, now time.Time) (*Event, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id.String(),
+		Source:          source,
+		Type:            typ,
+		Subject:         msg.GroupKey,
+		Time:            now,
+		DataContentType: "application/json",
+		Data:            msg,
+	}, nil
+}
+
+// encodeCloudEvents returns the request body, its content type and the
+// headers to set for the message in the configured CloudEvents mode.
+func encodeCloudEvents(conf *config.WebhookCloudEventsConfig, msg *Message, now time.Time) ([]byte, string, map[string]string, error) {
+	source := conf.Source
+	if source == "" {
+		source = msg.ExternalURL
+	}
+
+	if conf.Mode == config.CloudEventsBatch {
+		// Each alert is sent as its own event, with the group's data
+		// narrowed down to that alert.
+		events := make([]*Event, 0, len(msg.Alerts))
+		for _, a := range msg.Alerts {
+			data := *msg.Data
+			data.Status = a.Status
+			data.Alerts = template.Alerts{a}
+			m := *msg
+			m.Data = &data
+
+			ev, err := newEvent(source, alertEventType+a.Status, &m, now)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			events = append(events, ev)
+		}
+		b, err := json.Marshal(events)
+		return b, "application/cloudevents-batch+json", nil, err
+	}
+
+	ev, err := newEvent(source, alertGroupEventType+msg.Status, msg, now)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if conf.Mode == config.CloudEventsBinary {
+		b, err := json.Marshal(ev.Data)
+		return b, ev.DataContentType, map[string]string{
+			"ce-specversion": ev.SpecVersion,
+			"ce-id":          ev.ID,
+			"ce-source":      ev.Source,
+			"ce-type":        ev.Type,
+			"ce-subject":     ev.Subject,
+			"ce-time":        ev.Time.Format(time.RFC3339Nano),
+		}, err
+	}
+
+	b, err := json.Marshal(ev)
+	return b, "application/cloudevents+json", nil, err
+}
@@ -16,12 +16,19 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
 	commoncfg "github.com/prometheus/common/config"
 
 	"github.com/prometheus/alertmanager/config"
@@ -41,7 +48,7 @@ type Notifier struct {
 
 // New returns a new Webhook.
 func New(conf *config.WebhookConfig, t *template.Template, l log.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
-	client, err := commoncfg.NewClientFromConfig(*conf.HTTPConfig, "webhook", append(httpOpts, commoncfg.WithHTTP2Disabled())...)
+	client, err := notify.NewClientWithTracing(*conf.HTTPConfig, "webhook", append(httpOpts, commoncfg.WithHTTP2Disabled())...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,33 +85,102 @@ func truncateAlerts(maxAlerts uint64, alerts []*types.Alert) ([]*types.Alert, ui
 	return alerts, 0
 }
 
-// Notify implements the Notifier interface.
-func (n *Notifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
-	alerts, numTruncated := truncateAlerts(n.conf.MaxAlerts, alerts)
-	data := notify.GetTemplateData(ctx, n.tmpl, alerts, n.logger)
+// NewMessage returns the webhook message for the given alerts. If maxAlerts
+// is not 0, alerts above this threshold are truncated.
+func NewMessage(ctx context.Context, t *template.Template, l log.Logger, maxAlerts uint64, alerts ...*types.Alert) *Message {
+	alerts, numTruncated := truncateAlerts(maxAlerts, alerts)
+	data := notify.GetTemplateData(ctx, t, alerts, l)
 
 	groupKey, err := notify.ExtractGroupKey(ctx)
 	if err != nil {
-		level.Error(n.logger).Log("err", err)
+		level.Error(l).Log("err", err)
 	}
 
-	msg := &Message{
+	return &Message{
 		Version:         "4",
 		Data:            data,
 		GroupKey:        groupKey.String(),
 		TruncatedAlerts: numTruncated,
 	}
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	msg := NewMessage(ctx, n.tmpl, n.logger, n.conf.MaxAlerts, alerts...)
+
+	var (
+		err         error
+		tmpl        = notify.TmplText(n.tmpl, msg.Data, &err)
+		headers     = make(map[string]string, len(n.conf.Headers))
+		body        []byte
+		contentType = "application/json"
+		ceHeaders   map[string]string
+	)
+	for k, v := range n.conf.Headers {
+		headers[k] = tmpl(v)
+	}
+	if n.conf.Body != "" {
+		body = []byte(tmpl(n.conf.Body))
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "templating error")
+	}
+
+	switch {
+	case n.conf.Body != "":
+	case n.conf.CloudEvents != nil:
+		body, contentType, ceHeaders, err = encodeCloudEvents(n.conf.CloudEvents, msg, time.Now())
+	default:
+		var buf bytes.Buffer
+		err = json.NewEncoder(&buf).Encode(msg)
+		body = buf.Bytes()
+	}
+	if err != nil {
+		return false, err
+	}
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+	req, err := http.NewRequest(http.MethodPost, n.conf.URL.String(), bytes.NewReader(body))
+	if err != nil {
 		return false, err
 	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range ceHeaders {
+		req.Header.Set(k, v)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if sig := n.conf.Signature; sig != nil {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(sig.TimestampHeader, ts)
+		req.Header.Set(sig.Header, Sign(sig, ts, body))
+	}
 
-	resp, err := notify.PostJSON(ctx, n.client, n.conf.URL.String(), &buf)
+	resp, err := n.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return true, err
 	}
+	notify.RecordStatusCode(ctx, resp.StatusCode)
 	notify.Drain(resp)
 
 	return n.retrier.Check(resp.StatusCode, nil)
 }
+
+// Sign returns the value of the signature header for the given timestamp
+// and body. It holds one "sha256=<hex digest>" entry per configured secret,
+// separated by commas, the current secret first.
+func Sign(sig *config.WebhookSignatureConfig, timestamp string, body []byte) string {
+	signatures := make([]string, 0, 2)
+	for _, secret := range []config.Secret{sig.Secret, sig.PreviousSecret} {
+		if secret == "" {
+			continue
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp))
+		mac.Write([]byte("."))
+		mac.Write(body)
+		signatures = append(signatures, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	return strings.Join(signatures, ",")
+}
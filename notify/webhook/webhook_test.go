@@ -14,15 +14,28 @@
 package webhook
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kit/log"
 	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/notify/test"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -64,3 +77,175 @@ func TestWebhookTruncateAlerts(t *testing.T) {
 	require.Len(t, truncatedAlerts, 10)
 	require.EqualValues(t, numTruncated, 0)
 }
+
+func TestWebhookSignatureAndHeaders(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	cfg := &config.WebhookConfig{
+		URL:        &config.URL{URL: u},
+		HTTPConfig: &commoncfg.HTTPClientConfig{},
+		Headers: map[string]string{
+			"X-Team":       "{{ .CommonLabels.team }}",
+			"Content-Type": "text/plain",
+		},
+		Body: "{{ .Status }}: {{ .CommonLabels.alertname }}",
+		Signature: &config.WebhookSignatureConfig{
+			Secret:         "new",
+			PreviousSecret: "old",
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "HighLatency", "team": "sre"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "firing: HighLatency", string(body))
+	require.Equal(t, "sre", header.Get("X-Team"))
+	require.Equal(t, "text/plain", header.Get("Content-Type"))
+
+	// The receiver verifies the signature with either secret.
+	ts := header.Get("X-Alertmanager-Timestamp")
+	sent, err := strconv.ParseInt(ts, 10, 64)
+	require.NoError(t, err)
+	require.InDelta(t, time.Now().Unix(), sent, 5)
+
+	signatures := strings.Split(header.Get("X-Alertmanager-Signature"), ",")
+	require.Len(t, signatures, 2)
+	for i, secret := range []string{"new", "old"} {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "." + string(body)))
+		require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signatures[i])
+	}
+}
+
+func TestWebhookTemplatingError(t *testing.T) {
+	u, _ := url.Parse("http://example.com")
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.URL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+			Body:       "{{ .CommonLabels.alertname }",
+		},
+		test.CreateTmpl(t),
+		log.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := notifier.Notify(ctx, &types.Alert{})
+	require.False(t, retry)
+	require.ErrorContains(t, err, "templating error")
+}
+
+func TestWebhookCloudEvents(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	ctx := notify.WithGroupKey(context.Background(), "{}:{alertname=\"HighLatency\"}")
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "instance": "a"},
+				StartsAt: time.Now(),
+				EndsAt:   time.Now().Add(time.Hour),
+			},
+		},
+		{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "HighLatency", "instance": "b"},
+				StartsAt: time.Now().Add(-time.Hour),
+				EndsAt:   time.Now().Add(-time.Minute),
+			},
+		},
+	}
+	notifierFor := func(mode string) *Notifier {
+		cfg := &config.WebhookConfig{
+			URL:         &config.URL{URL: u},
+			HTTPConfig:  &commoncfg.HTTPClientConfig{},
+			CloudEvents: &config.WebhookCloudEventsConfig{Mode: mode, Source: "/alertmanager"},
+		}
+		require.NoError(t, cfg.Validate())
+		notifier, err := New(cfg, test.CreateTmpl(t), log.NewNopLogger())
+		require.NoError(t, err)
+		return notifier
+	}
+
+	t.Run("structured", func(t *testing.T) {
+		_, err := notifierFor("").Notify(ctx, alerts...)
+		require.NoError(t, err)
+
+		require.Equal(t, "application/cloudevents+json", header.Get("Content-Type"))
+		var ev Event
+		require.NoError(t, json.Unmarshal(body, &ev))
+		require.Equal(t, "1.0", ev.SpecVersion)
+		require.NotEmpty(t, ev.ID)
+		require.Equal(t, "/alertmanager", ev.Source)
+		require.Equal(t, "io.prometheus.alertmanager.alertgroup.firing", ev.Type)
+		require.Equal(t, "{}:{alertname=\"HighLatency\"}", ev.Subject)
+		require.Equal(t, "application/json", ev.DataContentType)
+		require.Len(t, ev.Data.Alerts, 2)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		_, err := notifierFor(config.CloudEventsBinary).Notify(ctx, alerts...)
+		require.NoError(t, err)
+
+		require.Equal(t, "application/json", header.Get("Content-Type"))
+		require.Equal(t, "1.0", header.Get("ce-specversion"))
+		require.NotEmpty(t, header.Get("ce-id"))
+		require.Equal(t, "/alertmanager", header.Get("ce-source"))
+		require.Equal(t, "io.prometheus.alertmanager.alertgroup.firing", header.Get("ce-type"))
+		require.Equal(t, "{}:{alertname=\"HighLatency\"}", header.Get("ce-subject"))
+		_, err = time.Parse(time.RFC3339, header.Get("ce-time"))
+		require.NoError(t, err)
+
+		var msg Message
+		require.NoError(t, json.Unmarshal(body, &msg))
+		require.Equal(t, "4", msg.Version)
+		require.Len(t, msg.Alerts, 2)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		_, err := notifierFor(config.CloudEventsBatch).Notify(ctx, alerts...)
+		require.NoError(t, err)
+
+		require.Equal(t, "application/cloudevents-batch+json", header.Get("Content-Type"))
+		var events []Event
+		require.NoError(t, json.Unmarshal(body, &events))
+		require.Len(t, events, 2)
+		require.Equal(t, "io.prometheus.alertmanager.alert.firing", events[0].Type)
+		require.Equal(t, "io.prometheus.alertmanager.alert.resolved", events[1].Type)
+		require.NotEqual(t, events[0].ID, events[1].ID)
+		for i, ev := range events {
+			require.Len(t, ev.Data.Alerts, 1)
+			require.Equal(t, ev.Data.Alerts[0].Status, ev.Data.Status)
+			require.Equal(t, alerts[i].Labels["instance"], model.LabelValue(ev.Data.Alerts[0].Labels["instance"]))
+		}
+	})
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package outbox implements a durable store of notifications the notification
+// pipeline gave up on. They are retried in the background and become dead
+// letters once they run out of attempts.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/snapshot"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// ErrNotFound is returned if an entry doesn't exist.
+var ErrNotFound = errors.New("outbox entry not found")
+
+// Status is the status of an outbox entry.
+type Status string
+
+const (
+	// StatusPending entries are waiting for a background retry.
+	StatusPending Status = "pending"
+	// StatusDead entries ran out of attempts.
+	StatusDead Status = "dead"
+)
+
+// Entry is a notification the notification pipeline gave up on.
+type Entry struct {
+	ID          string         `json:"id"`
+	Receiver    string         `json:"receiver"`
+	Integration string         `json:"integration"`
+	Index       int            `json:"index"`
+	GroupKey    string         `json:"groupKey"`
+	GroupLabels model.LabelSet `json:"groupLabels"`
+	Alerts      []*types.Alert `json:"alerts"`
+
+	Status Status `json:"status"`
+	// Error is the last error returned when notifying.
+	Error string `json:"error"`
+	// Attempts is the number of background attempts.
+	Attempts    int       `json:"attempts"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	NextAttempt time.Time `json:"nextAttempt"`
+}
+
+// NotifyFunc sends the notification of an entry.
+type NotifyFunc func(ctx context.Context, e *Entry) error
+
+// Options configures an outbox.
+type Options struct {
+	// SnapshotFile is the file the entries are periodically persisted to. If
+	// empty, the entries are only kept in memory.
+	SnapshotFile string
+	// MaxAttempts is the number of background attempts before an entry
+	// becomes a dead letter. If 0, failed notifications directly become dead
+	// letters.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first background attempt. It
+	// doubles after each attempt, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout is the timeout of a background attempt.
+	Timeout time.Duration
+	// Retention is how long dead letters are kept.
+	Retention time.Duration
+
+	Logger  log.Logger
+	Metrics prometheus.Registerer
+}
+
+func (o *Options) validate() error {
+	if o.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts must not be negative")
+	}
+	if o.InitialBackoff <= 0 || o.MaxBackoff < o.InitialBackoff {
+		return fmt.Errorf("invalid backoff %s to %s", o.InitialBackoff, o.MaxBackoff)
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if o.Retention <= 0 {
+		return fmt.Errorf("retention must be positive")
+	}
+	return nil
+}
+
+type metrics struct {
+	entries        *prometheus.GaugeVec
+	attemptsTotal  prometheus.Counter
+	deliveredTotal prometheus.Counter
+	deadTotal      prometheus.Counter
+}
+
+func newMetrics(r prometheus.Registerer) *metrics {
+	m := &metrics{
+		entries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "alertmanager_outbox_entries",
+			Help: "Number of notifications in the outbox by status.",
+		}, []string{"status"}),
+		attemptsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_outbox_attempts_total",
+			Help: "Number of background attempts to deliver notifications from the outbox.",
+		}),
+		deliveredTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_outbox_delivered_total",
+			Help: "Number of notifications delivered from the outbox.",
+		}),
+		deadTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_outbox_dead_letters_total",
+			Help: "Number of notifications that became dead letters.",
+		}),
+	}
+	for _, s := range []Status{StatusPending, StatusDead} {
+		m.entries.WithLabelValues(string(s))
+	}
+	if r != nil {
+		r.MustRegister(m.entries, m.attemptsTotal, m.deliveredTotal, m.deadTotal)
+	}
+	return m
+}
+
+// Outbox stores the notifications the notification pipeline gave up on.
+type Outbox struct {
+	opts    Options
+	logger  log.Logger
+	metrics *metrics
+	now     func() time.Time
+	// wakec is notified when entries are due immediately.
+	wakec chan struct{}
+
+	mtx     sync.Mutex
+	entries map[string]*Entry
+	// dirty is set when the entries changed since the last snapshot.
+	dirty bool
+}
+
+// New returns a new outbox, loading the entries from the snapshot file if
+// it exists.
+func New(o Options) (*Outbox, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if o.Logger == nil {
+		o.Logger = log.NewNopLogger()
+	}
+	ob := &Outbox{
+		opts:    o,
+		logger:  o.Logger,
+		metrics: newMetrics(o.Metrics),
+		now:     time.Now,
+		wakec:   make(chan struct{}, 1),
+		entries: map[string]*Entry{},
+	}
+	if o.SnapshotFile == "" {
+		return ob, nil
+	}
+
+	b, err := os.ReadFile(o.SnapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ob, nil
+		}
+		return nil, err
+	}
+	var entries []*Entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, errors.Wrap(err, "failed to load outbox snapshot")
+	}
+	for _, e := range entries {
+		ob.entries[e.ID] = e
+	}
+	ob.updateMetrics()
+	return ob, nil
+}
+
+// Add records a notification the pipeline gave up on with err. It is
+// retried in the background, unless retry is false in which case it directly
+// becomes a dead letter.
+func (o *Outbox) Add(e *Entry, err error, retry bool) error {
+	id, uerr := uuid.NewV4()
+	if uerr != nil {
+		return uerr
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	now := o.now()
+	e.ID = id.String()
+	e.CreatedAt = now
+	o.release(e, err.Error(), retry, now)
+	o.entries[e.ID] = e
+	o.changed()
+	return nil
+}
+
+func (o *Outbox) release(e *Entry, errMsg string, retry bool, now time.Time) {
+	e.Error = errMsg
+	e.UpdatedAt = now
+	if !retry || o.opts.MaxAttempts == 0 {
+		e.Status = StatusDead
+		o.metrics.deadTotal.Inc()
+		return
+	}
+	e.Status = StatusPending
+	e.NextAttempt = now.Add(o.opts.InitialBackoff)
+}
+
+// List returns the entries with the given statuses, all entries if none is
+// given, from the oldest to the most recent.
+func (o *Outbox) List(statuses ...Status) []Entry {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	res := []Entry{}
+	for _, e := range o.entries {
+		if len(statuses) > 0 && !hasStatus(statuses, e.Status) {
+			continue
+		}
+		res = append(res, *e)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+	return res
+}
+
+func hasStatus(statuses []Status, s Status) bool {
+	for _, st := range statuses {
+		if st == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Replay schedules an immediate background attempt of the entry, resetting
+// its number of attempts.
+func (o *Outbox) Replay(id string) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	e, ok := o.entries[id]
+	if !ok {
+		return ErrNotFound
+	}
+	now := o.now()
+	e.Status = StatusPending
+	e.Attempts = 0
+	e.UpdatedAt = now
+	e.NextAttempt = now
+	o.changed()
+
+	select {
+	case o.wakec <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Discard removes the entry.
+func (o *Outbox) Discard(id string) error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if _, ok := o.entries[id]; !ok {
+		return ErrNotFound
+	}
+	delete(o.entries, id)
+	o.changed()
+	return nil
+}
+
+// Run retries the pending entries with notify every interval, removes the
+// dead letters past their retention and writes the snapshot if the entries
+// changed, until stopc is closed.
+func (o *Outbox) Run(interval time.Duration, stopc <-chan struct{}, notify NotifyFunc) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			o.snapshot()
+			return
+		case <-t.C:
+		case <-o.wakec:
+		}
+		o.retry(stopc, notify)
+		o.gc()
+		o.snapshot()
+	}
+}
+
+// retry attempts to deliver the pending entries that are due.
+func (o *Outbox) retry(stopc <-chan struct{}, notify NotifyFunc) {
+	now := o.now()
+	for _, e := range o.List(StatusPending) {
+		if e.NextAttempt.After(now) {
+			continue
+		}
+		select {
+		case <-stopc:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), o.opts.Timeout)
+		err := notify(ctx, &e)
+		cancel()
+		o.metrics.attemptsTotal.Inc()
+		o.attempted(e.ID, err)
+	}
+}
+
+// attempted records the outcome of a background attempt.
+func (o *Outbox) attempted(id string, err error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	e, ok := o.entries[id]
+	// The entry may have been discarded or replayed in the meantime.
+	if !ok || e.Status != StatusPending {
+		return
+	}
+	if err == nil {
+		o.metrics.deliveredTotal.Inc()
+		delete(o.entries, id)
+		o.changed()
+		return
+	}
+
+	now := o.now()
+	e.Attempts++
+	e.Error = err.Error()
+	e.UpdatedAt = now
+	if e.Attempts >= o.opts.MaxAttempts {
+		e.Status = StatusDead
+		o.metrics.deadTotal.Inc()
+		level.Warn(o.logger).Log("msg", "Notification became a dead letter", "id", id, "receiver", e.Receiver, "integration", fmt.Sprintf("%s[%d]", e.Integration, e.Index), "attempts", e.Attempts, "err", err)
+	} else {
+		backoff := o.opts.InitialBackoff << e.Attempts
+		if backoff > o.opts.MaxBackoff || backoff <= 0 {
+			backoff = o.opts.MaxBackoff
+		}
+		e.NextAttempt = now.Add(backoff)
+	}
+	o.changed()
+}
+
+// gc removes the dead letters past their retention.
+func (o *Outbox) gc() {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	now := o.now()
+	var removed bool
+	for id, e := range o.entries {
+		if e.Status == StatusDead && now.Sub(e.UpdatedAt) > o.opts.Retention {
+			delete(o.entries, id)
+			removed = true
+		}
+	}
+	if removed {
+		o.changed()
+	}
+}
+
+// changed marks the entries as changed since the last snapshot. It must be
+// called with the lock held.
+func (o *Outbox) changed() {
+	o.updateMetrics()
+	o.dirty = true
+}
+
+// snapshot writes the entries to the snapshot file if they changed since the
+// last snapshot.
+func (o *Outbox) snapshot() {
+	if o.opts.SnapshotFile == "" {
+		return
+	}
+
+	o.mtx.Lock()
+	if !o.dirty {
+		o.mtx.Unlock()
+		return
+	}
+	entries := make([]Entry, 0, len(o.entries))
+	for _, e := range o.entries {
+		entries = append(entries, *e)
+	}
+	o.dirty = false
+	o.mtx.Unlock()
+
+	if err := snapshot.WriteJSON(o.opts.SnapshotFile, entries); err != nil {
+		level.Error(o.logger).Log("msg", "Failed to persist the outbox", "err", err)
+		o.mtx.Lock()
+		o.dirty = true
+		o.mtx.Unlock()
+	}
+}
+
+func (o *Outbox) updateMetrics() {
+	counts := map[Status]int{}
+	for _, e := range o.entries {
+		counts[e.Status]++
+	}
+	for _, s := range []Status{StatusPending, StatusDead} {
+		o.metrics.entries.WithLabelValues(string(s)).Set(float64(counts[s]))
+	}
+}
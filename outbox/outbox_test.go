@@ -0,0 +1,167 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestOutbox(t *testing.T, snapshot string) *Outbox {
+	ob, err := New(Options{
+		SnapshotFile:   snapshot,
+		MaxAttempts:    2,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		Timeout:        time.Second,
+		Retention:      24 * time.Hour,
+		Metrics:        prometheus.NewRegistry(),
+	})
+	require.NoError(t, err)
+	return ob
+}
+
+func newTestEntry() *Entry {
+	return &Entry{
+		Receiver:    "team-X",
+		Integration: "webhook",
+		GroupKey:    "{}:{alertname=\"HighLatency\"}",
+		GroupLabels: model.LabelSet{"alertname": "HighLatency"},
+		Alerts: []*types.Alert{
+			{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency"}}},
+		},
+	}
+}
+
+func TestOutboxAdd(t *testing.T) {
+	ob := newTestOutbox(t, "")
+
+	// Notifications given up on are retried in the background.
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("unavailable"), true))
+	entries := ob.List(StatusPending)
+	require.Len(t, entries, 1)
+	require.Equal(t, "unavailable", entries[0].Error)
+	require.Equal(t, entries[0].UpdatedAt.Add(time.Minute), entries[0].NextAttempt)
+
+	// Unrecoverable failures directly become dead letters.
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("bad request"), false))
+	require.Len(t, ob.List(StatusDead), 1)
+	require.Len(t, ob.List(), 2)
+}
+
+func TestOutboxRetry(t *testing.T) {
+	var (
+		ob       = newTestOutbox(t, "")
+		now      = time.Now()
+		attempts int
+		notifyFn = func(ctx context.Context, e *Entry) error {
+			attempts++
+			require.Equal(t, "team-X", e.Receiver)
+			return errors.New("unavailable")
+		}
+	)
+	ob.now = func() time.Time { return now }
+	stopc := make(chan struct{})
+
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("unavailable"), true))
+	id := ob.List()[0].ID
+
+	// The entry isn't due yet.
+	ob.retry(stopc, notifyFn)
+	require.Equal(t, 0, attempts)
+
+	now = now.Add(time.Minute)
+	ob.retry(stopc, notifyFn)
+	require.Equal(t, 1, attempts)
+	e := ob.List()[0]
+	require.Equal(t, StatusPending, e.Status)
+	require.Equal(t, 1, e.Attempts)
+	require.Equal(t, now.Add(2*time.Minute), e.NextAttempt)
+
+	// The entry becomes a dead letter after the maximum number of attempts.
+	now = now.Add(2 * time.Minute)
+	ob.retry(stopc, notifyFn)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, StatusDead, ob.List()[0].Status)
+	ob.retry(stopc, notifyFn)
+	require.Equal(t, 2, attempts)
+
+	// Replayed entries are delivered right away.
+	require.NoError(t, ob.Replay(id))
+	e = ob.List()[0]
+	require.Equal(t, StatusPending, e.Status)
+	require.Equal(t, 0, e.Attempts)
+	ob.retry(stopc, func(ctx context.Context, e *Entry) error { return nil })
+	require.Empty(t, ob.List())
+
+	require.Equal(t, ErrNotFound, ob.Replay(id))
+	require.Equal(t, ErrNotFound, ob.Discard(id))
+}
+
+func TestOutboxDiscardAndGC(t *testing.T) {
+	ob := newTestOutbox(t, "")
+	now := time.Now()
+	ob.now = func() time.Time { return now }
+
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("bad request"), false))
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("bad request"), false))
+
+	require.NoError(t, ob.Discard(ob.List()[0].ID))
+	require.Len(t, ob.List(), 1)
+
+	// Dead letters are removed after the retention.
+	now = now.Add(23 * time.Hour)
+	ob.gc()
+	require.Len(t, ob.List(), 1)
+	now = now.Add(2 * time.Hour)
+	ob.gc()
+	require.Empty(t, ob.List())
+}
+
+func TestOutboxSnapshot(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "outbox")
+
+	ob := newTestOutbox(t, snapshot)
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("unavailable"), true))
+	require.NoError(t, ob.Add(newTestEntry(), errors.New("bad request"), false))
+
+	// The snapshot is written periodically and when the outbox stops.
+	stopc := make(chan struct{})
+	close(stopc)
+	ob.Run(time.Hour, stopc, nil)
+	require.False(t, ob.dirty)
+
+	entries := newTestOutbox(t, snapshot).List()
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		switch e.Status {
+		case StatusPending:
+			require.Equal(t, "unavailable", e.Error)
+		case StatusDead:
+			require.Equal(t, "bad request", e.Error)
+		}
+		require.Equal(t, model.LabelSet{"alertname": "HighLatency"}, e.GroupLabels)
+		require.Equal(t, model.LabelValue("HighLatency"), e.Alerts[0].Labels["alertname"])
+	}
+	require.NotEqual(t, entries[0].Status, entries[1].Status)
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package relabel rewrites label sets with Prometheus-style relabeling rules.
+package relabel
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+// Action is the action of a relabeling rule.
+type Action string
+
+const (
+	// Replace sets the target label to the replacement, expanded with the
+	// groups of the regex matched against the source labels.
+	Replace Action = "replace"
+	// Keep drops the label sets whose source labels don't match the regex.
+	Keep Action = "keep"
+	// Drop drops the label sets whose source labels match the regex.
+	Drop Action = "drop"
+	// LabelMap copies the labels whose name matches the regex to the label
+	// named after the replacement, expanded with the groups of the regex.
+	LabelMap Action = "labelmap"
+	// LabelDrop removes the labels whose name matches the regex.
+	LabelDrop Action = "labeldrop"
+	// LabelKeep removes the labels whose name doesn't match the regex.
+	LabelKeep Action = "labelkeep"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Action.
+func (a *Action) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return a.set(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Action.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return a.set(s)
+}
+
+func (a *Action) set(s string) error {
+	switch act := Action(strings.ToLower(s)); act {
+	case Replace, Keep, Drop, LabelMap, LabelDrop, LabelKeep:
+		*a = act
+		return nil
+	}
+	return fmt.Errorf("unknown relabel action %q", s)
+}
+
+// Regexp is an anchored regular expression.
+type Regexp struct {
+	*regexp.Regexp
+	original string
+}
+
+// NewRegexp returns the anchored regular expression of s.
+func NewRegexp(s string) (Regexp, error) {
+	re, err := regexp.Compile("^(?:" + s + ")$")
+	return Regexp{Regexp: re, original: s}, err
+}
+
+// MustNewRegexp works like NewRegexp, but panics if the regular expression
+// doesn't compile.
+func MustNewRegexp(s string) Regexp {
+	re, err := NewRegexp(s)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Regexp.
+func (re *Regexp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	r, err := NewRegexp(s)
+	if err != nil {
+		return err
+	}
+	*re = r
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface for Regexp.
+func (re Regexp) MarshalYAML() (interface{}, error) {
+	return re.original, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Regexp.
+func (re *Regexp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	r, err := NewRegexp(s)
+	if err != nil {
+		return err
+	}
+	*re = r
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Regexp.
+func (re Regexp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(re.original)
+}
+
+// DefaultConfig defines the default values of a relabeling rule.
+var DefaultConfig = Config{
+	Separator:   ";",
+	Regex:       MustNewRegexp("(.*)"),
+	Replacement: "$1",
+	Action:      Replace,
+}
+
+// templateLabel matches the label names containing references to the groups
+// of a regex, such as ${1} or $name.
+var templateLabel = regexp.MustCompile(`^(?:(?:[a-zA-Z_]|\$(?:\{\w+\}|\w+))+\w*)+$`)
+
+// Config is a relabeling rule.
+type Config struct {
+	// SourceLabels are the labels whose values, joined by the separator, are
+	// matched against the regex.
+	SourceLabels model.LabelNames `yaml:"source_labels,flow,omitempty" json:"source_labels,omitempty"`
+	Separator    string           `yaml:"separator,omitempty" json:"separator,omitempty"`
+	Regex        Regexp           `yaml:"regex,omitempty" json:"regex,omitempty"`
+	TargetLabel  string           `yaml:"target_label,omitempty" json:"target_label,omitempty"`
+	Replacement  string           `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+	Action       Action           `yaml:"action,omitempty" json:"action,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for Config.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultConfig
+	type plain Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Config.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	*c = DefaultConfig
+	type plain Config
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// Validate checks the relabeling rule.
+func (c *Config) Validate() error {
+	if c.Regex.Regexp == nil {
+		return fmt.Errorf("missing regex in relabel config")
+	}
+	switch c.Action {
+	case Replace:
+		if c.TargetLabel == "" {
+			return fmt.Errorf("relabel action %q requires target_label", c.Action)
+		}
+		if !model.LabelName(c.TargetLabel).IsValid() && !templateLabel.MatchString(c.TargetLabel) {
+			return fmt.Errorf("%q is an invalid target_label for relabel action %q", c.TargetLabel, c.Action)
+		}
+	case Keep, Drop:
+		if len(c.SourceLabels) == 0 {
+			return fmt.Errorf("relabel action %q requires source_labels", c.Action)
+		}
+	case LabelMap:
+		if !model.LabelName(c.Replacement).IsValid() && !templateLabel.MatchString(c.Replacement) {
+			return fmt.Errorf("%q is an invalid replacement for relabel action %q", c.Replacement, c.Action)
+		}
+		fallthrough
+	case LabelDrop, LabelKeep:
+		if len(c.SourceLabels) > 0 || c.TargetLabel != "" {
+			return fmt.Errorf("source_labels and target_label can't be set for relabel action %q", c.Action)
+		}
+	default:
+		return fmt.Errorf("unknown relabel action %q", c.Action)
+	}
+	return nil
+}
+
+// Process applies the relabeling rules in order to a copy of the label set.
+// It returns false if the label set is dropped.
+func Process(ls model.LabelSet, cfgs ...*Config) (model.LabelSet, bool) {
+	res := ls.Clone()
+	for _, c := range cfgs {
+		if !relabel(res, c) {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+func relabel(ls model.LabelSet, c *Config) bool {
+	values := make([]string, 0, len(c.SourceLabels))
+	for _, name := range c.SourceLabels {
+		values = append(values, string(ls[name]))
+	}
+	val := strings.Join(values, c.Separator)
+
+	switch c.Action {
+	case Keep:
+		return c.Regex.MatchString(val)
+	case Drop:
+		return !c.Regex.MatchString(val)
+	case Replace:
+		indexes := c.Regex.FindStringSubmatchIndex(val)
+		if indexes == nil {
+			break
+		}
+		target := model.LabelName(c.Regex.ExpandString(nil, c.TargetLabel, val, indexes))
+		if !target.IsValid() {
+			break
+		}
+		res := c.Regex.ExpandString(nil, c.Replacement, val, indexes)
+		if len(res) == 0 {
+			delete(ls, target)
+			break
+		}
+		ls[target] = model.LabelValue(res)
+	case LabelMap:
+		for name, value := range ls.Clone() {
+			if c.Regex.MatchString(string(name)) {
+				target := model.LabelName(c.Regex.ReplaceAllString(string(name), c.Replacement))
+				if target.IsValid() {
+					ls[target] = value
+				}
+			}
+		}
+	case LabelDrop, LabelKeep:
+		for name := range ls {
+			if c.Regex.MatchString(string(name)) == (c.Action == LabelDrop) {
+				delete(ls, name)
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relabel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "replace",
+			in: `
+source_labels: [sev]
+target_label: severity`,
+		},
+		{
+			name: "template target label",
+			in: `
+source_labels: [kind, name]
+regex: (.+);(.+)
+target_label: ${1}_name
+replacement: $2`,
+		},
+		{
+			name: "keep",
+			in: `
+source_labels: [env]
+regex: prod|production
+action: KEEP`,
+		},
+		{
+			name: "labelmap",
+			in: `
+regex: tag_(.+)
+action: labelmap`,
+		},
+		{
+			name: "unknown action",
+			in:   `action: rename`,
+			err:  `unknown relabel action "rename"`,
+		},
+		{
+			name: "invalid regex",
+			in: `
+source_labels: [env]
+regex: (
+action: drop`,
+			err: "error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			name: "replace without target label",
+			in:   `source_labels: [sev]`,
+			err:  `relabel action "replace" requires target_label`,
+		},
+		{
+			name: "invalid target label",
+			in: `
+source_labels: [sev]
+target_label: 1severity`,
+			err: `"1severity" is an invalid target_label for relabel action "replace"`,
+		},
+		{
+			name: "drop without source labels",
+			in:   `action: drop`,
+			err:  `relabel action "drop" requires source_labels`,
+		},
+		{
+			name: "labeldrop with target label",
+			in: `
+regex: tmp_.*
+target_label: foo
+action: labeldrop`,
+			err: `source_labels and target_label can't be set for relabel action "labeldrop"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Config
+			err := yaml.UnmarshalStrict([]byte(tc.in), &c)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	// The defaults apply to the JSON configuration too.
+	var c Config
+	require.NoError(t, json.Unmarshal([]byte(`{"source_labels":["sev"],"target_label":"severity"}`), &c))
+	require.Equal(t, Replace, c.Action)
+	require.Equal(t, "$1", c.Replacement)
+	require.Equal(t, ";", c.Separator)
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"source_labels":["sev"],"separator":";","regex":"(.*)","target_label":"severity","replacement":"$1","action":"replace"}`, string(b))
+}
+
+func mustConfig(t *testing.T, in string) *Config {
+	var c Config
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &c))
+	return &c
+}
+
+func TestProcess(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   model.LabelSet
+		cfgs []string
+		out  model.LabelSet
+		drop bool
+	}{
+		{
+			name: "replace",
+			in:   model.LabelSet{"sev": "crit"},
+			cfgs: []string{`
+source_labels: [sev]
+target_label: severity`},
+			out: model.LabelSet{"sev": "crit", "severity": "crit"},
+		},
+		{
+			name: "replace value",
+			in:   model.LabelSet{"env": "prod"},
+			cfgs: []string{`
+source_labels: [env]
+regex: prod
+target_label: env
+replacement: production`},
+			out: model.LabelSet{"env": "production"},
+		},
+		{
+			name: "replace without match",
+			in:   model.LabelSet{"env": "staging"},
+			cfgs: []string{`
+source_labels: [env]
+regex: prod
+target_label: env
+replacement: production`},
+			out: model.LabelSet{"env": "staging"},
+		},
+		{
+			name: "replace with empty value",
+			in:   model.LabelSet{"env": "prod", "tmp": "x"},
+			cfgs: []string{`
+source_labels: [missing]
+target_label: tmp
+replacement: ""`},
+			out: model.LabelSet{"env": "prod"},
+		},
+		{
+			name: "replace with template target",
+			in:   model.LabelSet{"kind": "pod", "name": "web-1"},
+			cfgs: []string{`
+source_labels: [kind, name]
+regex: (.+);(.+)
+target_label: ${1}_name
+replacement: $2`},
+			out: model.LabelSet{"kind": "pod", "name": "web-1", "pod_name": "web-1"},
+		},
+		{
+			name: "keep",
+			in:   model.LabelSet{"env": "staging"},
+			cfgs: []string{`
+source_labels: [env]
+regex: prod|production
+action: keep`},
+			drop: true,
+		},
+		{
+			name: "drop",
+			in:   model.LabelSet{"env": "test"},
+			cfgs: []string{`
+source_labels: [env]
+regex: test
+action: drop`},
+			drop: true,
+		},
+		{
+			name: "labelmap",
+			in:   model.LabelSet{"tag_team": "payments", "alertname": "Foo"},
+			cfgs: []string{`
+regex: tag_(.+)
+action: labelmap`},
+			out: model.LabelSet{"tag_team": "payments", "team": "payments", "alertname": "Foo"},
+		},
+		{
+			name: "labeldrop",
+			in:   model.LabelSet{"tmp_id": "1", "alertname": "Foo"},
+			cfgs: []string{`
+regex: tmp_.*
+action: labeldrop`},
+			out: model.LabelSet{"alertname": "Foo"},
+		},
+		{
+			name: "labelkeep",
+			in:   model.LabelSet{"tmp_id": "1", "alertname": "Foo"},
+			cfgs: []string{`
+regex: alertname
+action: labelkeep`},
+			out: model.LabelSet{"alertname": "Foo"},
+		},
+		{
+			name: "in order",
+			in:   model.LabelSet{"sev": "crit"},
+			cfgs: []string{`
+source_labels: [sev]
+target_label: severity`, `
+regex: sev
+action: labeldrop`, `
+source_labels: [severity]
+regex: crit
+target_label: severity
+replacement: critical`},
+			out: model.LabelSet{"severity": "critical"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfgs := make([]*Config, 0, len(tc.cfgs))
+			for _, in := range tc.cfgs {
+				cfgs = append(cfgs, mustConfig(t, in))
+			}
+			orig := tc.in.Clone()
+			out, keep := Process(tc.in, cfgs...)
+			require.Equal(t, !tc.drop, keep)
+			require.Equal(t, tc.out, out)
+			// The input is never modified.
+			require.Equal(t, orig, tc.in)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot persists the state of the components to snapshot files.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Write atomically replaces the file with the data written by write. The data
+// is synced to disk before the file is replaced.
+func Write(filename string, write func(io.Writer) error) error {
+	tmp := fmt.Sprintf("%s.%x", filename, uint64(rand.Int63()))
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// WriteJSON atomically replaces the file with the JSON encoding of v.
+func WriteJSON(filename string, v interface{}) error {
+	return Write(filename, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(v)
+	})
+}
+
+// Maintain runs f every interval, and once more when stopc is closed.
+func Maintain(interval time.Duration, stopc <-chan struct{}, f func()) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			f()
+			return
+		case <-t.C:
+			f()
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "state")
+
+	require.NoError(t, WriteJSON(filename, map[string]int{"a": 1}))
+	b, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "{\"a\":1}\n", string(b))
+
+	// A failed write leaves the previous snapshot in place.
+	err = Write(filename, func(w io.Writer) error {
+		w.Write([]byte("partial"))
+		return errors.New("fail")
+	})
+	require.EqualError(t, err, "fail")
+	b, err = os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "{\"a\":1}\n", string(b))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestMaintain(t *testing.T) {
+	var runs int
+	stopc := make(chan struct{})
+	close(stopc)
+
+	// The function runs once more when stopped.
+	Maintain(time.Hour, stopc, func() { runs++ })
+	require.Equal(t, 1, runs)
+}
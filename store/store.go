@@ -128,6 +128,14 @@ func (a *Alerts) List() []*types.Alert {
 	return alerts
 }
 
+// Len returns the number of alerts in the store.
+func (a *Alerts) Len() int {
+	a.Lock()
+	defer a.Unlock()
+
+	return len(a.c)
+}
+
 // Empty returns true if the store is empty.
 func (a *Alerts) Empty() bool {
 	a.Lock()
@@ -244,6 +244,21 @@ type Data struct {
 	CommonAnnotations KV `json:"commonAnnotations"`
 
 	ExternalURL string `json:"externalURL"`
+
+	// Digest is set for the notifications of digest receivers.
+	Digest *Digest `json:"digest,omitempty"`
+}
+
+// Digest holds the summary of the alerts of a digest receiver over a window.
+type Digest struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Fired are the alerts that started firing during the window.
+	Fired Alerts `json:"fired"`
+	// Resolved are the alerts that were resolved during the window.
+	Resolved Alerts `json:"resolved"`
+	// Open are the alerts still firing at the end of the window.
+	Open Alerts `json:"open"`
 }
 
 // Alert holds one alert for notification templates.
@@ -255,6 +270,11 @@ type Alert struct {
 	EndsAt       time.Time `json:"endsAt"`
 	GeneratorURL string    `json:"generatorURL"`
 	Fingerprint  string    `json:"fingerprint"`
+	// Acknowledgement is set if someone acknowledged the alert.
+	Acknowledgement *types.Acknowledgement `json:"acknowledgement,omitempty"`
+	// Flapping is set if the alert changes state too often. Its
+	// notifications are suppressed until it stops flapping.
+	Flapping bool `json:"flapping,omitempty"`
 }
 
 // Alerts is a list of Alert objects.
@@ -282,18 +302,9 @@ func (as Alerts) Resolved() []Alert {
 	return res
 }
 
-// Data assembles data for template expansion.
-func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*types.Alert) *Data {
-	data := &Data{
-		Receiver:          regexp.QuoteMeta(recv),
-		Status:            string(types.Alerts(alerts...).Status()),
-		Alerts:            make(Alerts, 0, len(alerts)),
-		GroupLabels:       KV{},
-		CommonLabels:      KV{},
-		CommonAnnotations: KV{},
-		ExternalURL:       t.ExternalURL.String(),
-	}
-
+// templateAlerts converts the alerts for template expansion.
+func templateAlerts(alerts []*types.Alert) Alerts {
+	res := make(Alerts, 0, len(alerts))
 	// The call to types.Alert is necessary to correctly resolve the internal
 	// representation to the user representation.
 	for _, a := range types.Alerts(alerts...) {
@@ -312,8 +323,36 @@ func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*type
 		for k, v := range a.Annotations {
 			alert.Annotations[string(k)] = string(v)
 		}
-		data.Alerts = append(data.Alerts, alert)
+		res = append(res, alert)
 	}
+	return res
+}
+
+// NewDigest assembles the summary of the alerts of a digest receiver for
+// template expansion.
+func NewDigest(start, end time.Time, fired, resolved, open []*types.Alert) *Digest {
+	return &Digest{
+		Start:    start,
+		End:      end,
+		Fired:    templateAlerts(fired),
+		Resolved: templateAlerts(resolved),
+		Open:     templateAlerts(open),
+	}
+}
+
+// Data assembles data for template expansion.
+func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*types.Alert) *Data {
+	data := &Data{
+		Receiver:          regexp.QuoteMeta(recv),
+		Status:            string(types.Alerts(alerts...).Status()),
+		Alerts:            make(Alerts, 0, len(alerts)),
+		GroupLabels:       KV{},
+		CommonLabels:      KV{},
+		CommonAnnotations: KV{},
+		ExternalURL:       t.ExternalURL.String(),
+	}
+
+	data.Alerts = append(data.Alerts, templateAlerts(alerts)...)
 
 	for k, v := range groupLabels {
 		data.GroupLabels[string(k)] = string(v)
@@ -370,6 +370,35 @@ func TestTemplateExpansion(t *testing.T) {
 			},
 			exp: "[key2 key4]",
 		},
+		{
+			title: "Digest subject",
+			in:    `{{ template "__subject" . }}`,
+			data: Data{
+				Digest: &Digest{
+					Fired: Alerts{{Status: "firing"}, {Status: "resolved"}},
+					Open:  Alerts{{Status: "firing"}},
+				},
+			},
+			exp: "[DIGEST] 2 fired, 0 resolved, 1 open",
+		},
+		{
+			title: "Digest text",
+			in:    `{{ template "digest.default.text" . }}`,
+			data: Data{
+				Digest: &Digest{
+					Start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+					End:   time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC),
+					Resolved: Alerts{
+						{
+							Labels:      KV{"alertname": "DiskFull"},
+							Annotations: KV{"summary": "disk is full"},
+							StartsAt:    time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+			exp: "Alerts from 2026-01-01 09:00 UTC to 2026-01-02 09:00 UTC\n\nResolved:\n - DiskFull: disk is full (since 2026-01-01 10:00 UTC)\n",
+		},
 	} {
 		tc := tc
 		t.Run(tc.title, func(t *testing.T) {
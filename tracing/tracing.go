@@ -0,0 +1,321 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports the traces of Alertmanager with OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const serviceName = "alertmanager"
+
+// Manager installs the tracer provider of the configuration.
+type Manager struct {
+	logger log.Logger
+
+	mtx          sync.Mutex
+	config       *config.TracingConfig
+	shutdownFunc func() error
+}
+
+// NewManager returns a new Manager. The traces aren't exported until a
+// configuration enabling them is applied.
+func NewManager(logger log.Logger) *Manager {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		level.Error(logger).Log("msg", "OpenTelemetry handler returned an error", "err", err)
+	}))
+	return &Manager{logger: logger}
+}
+
+// ApplyConfig replaces the tracer provider if the tracing configuration
+// changed. On error, the previous provider and configuration are kept.
+func (m *Manager) ApplyConfig(cfg *config.Config) error {
+	u, err := m.Prepare(cfg)
+	if err != nil {
+		return err
+	}
+	u.Commit()
+	return nil
+}
+
+// Update is a tracer provider built for a configuration and not installed
+// yet.
+type Update struct {
+	m            *Manager
+	config       *config.TracingConfig
+	tp           trace.TracerProvider
+	shutdownFunc func() error
+	unchanged    bool
+}
+
+// Prepare builds the tracer provider of the configuration without
+// installing it.
+func (m *Manager) Prepare(cfg *config.Config) (*Update, error) {
+	m.mtx.Lock()
+	unchanged := reflect.DeepEqual(m.config, cfg.Tracing)
+	m.mtx.Unlock()
+
+	u := &Update{m: m, config: cfg.Tracing, tp: trace.NewNoopTracerProvider(), unchanged: unchanged}
+	if unchanged || cfg.Tracing == nil {
+		return u, nil
+	}
+	var err error
+	if u.tp, u.shutdownFunc, err = newTracerProvider(cfg.Tracing); err != nil {
+		return nil, errors.Wrap(err, "failed to install a new tracer provider")
+	}
+	return u, nil
+}
+
+// Commit installs the tracer provider. The previous provider is shut down
+// once the new one is installed, so that no span is lost in between.
+func (u *Update) Commit() {
+	m := u.m
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if u.unchanged {
+		return
+	}
+	otel.SetTracerProvider(u.tp)
+	if err := m.shutdown(); err != nil {
+		level.Warn(m.logger).Log("msg", "Failed to shut down the previous tracer provider", "err", err)
+	}
+	m.config, m.shutdownFunc = u.config, u.shutdownFunc
+	u.shutdownFunc = nil
+	if u.config == nil {
+		level.Info(m.logger).Log("msg", "Tracing provider uninstalled")
+		return
+	}
+	level.Info(m.logger).Log("msg", "Tracing provider installed", "endpoint", u.config.Endpoint)
+}
+
+// Discard shuts down the tracer provider if it wasn't installed.
+func (u *Update) Discard() {
+	if u.shutdownFunc == nil {
+		return
+	}
+	if err := u.shutdownFunc(); err != nil {
+		level.Warn(u.m.logger).Log("msg", "Failed to shut down the discarded tracer provider", "err", err)
+	}
+	u.shutdownFunc = nil
+}
+
+// Stop flushes the pending spans and shuts down the tracer provider.
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if err := m.shutdown(); err != nil {
+		level.Error(m.logger).Log("msg", "Failed to shut down the tracer provider", "err", err)
+	}
+}
+
+// shutdown shuts down the current tracer provider. The lock must be held.
+func (m *Manager) shutdown() error {
+	if m.shutdownFunc == nil {
+		return nil
+	}
+	err := m.shutdownFunc()
+	m.shutdownFunc = nil
+	return err
+}
+
+// newTracerProvider returns a tracer provider exporting the spans to the
+// configured endpoint, along with a function shutting it down.
+func newTracerProvider(c *config.TracingConfig) (trace.TracerProvider, func() error, error) {
+	client, err := newClient(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	exp, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := resource.New(
+		context.Background(),
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version.Version),
+		),
+		resource.WithProcessRuntimeDescription(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exp),
+		tracesdk.WithSampler(tracesdk.ParentBased(
+			tracesdk.TraceIDRatioBased(c.SamplingFraction),
+		)),
+		tracesdk.WithResource(res),
+	)
+	return tp, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return tp.Shutdown(ctx)
+	}, nil
+}
+
+// newClient returns the OTLP client of the configured protocol.
+func newClient(c *config.TracingConfig) (otlptrace.Client, error) {
+	var tlsConfig *commoncfg.TLSConfig
+	if !c.Insecure {
+		tlsConfig = c.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &commoncfg.TLSConfig{}
+		}
+	}
+
+	switch c.ClientType {
+	case config.OTLPClientGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(c.Endpoint),
+			otlptracegrpc.WithTimeout(time.Duration(c.Timeout)),
+			otlptracegrpc.WithHeaders(c.Headers),
+		}
+		if c.Compression != "" {
+			opts = append(opts, otlptracegrpc.WithCompressor(c.Compression))
+		}
+		if tlsConfig == nil {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			tc, err := commoncfg.NewTLSConfig(tlsConfig)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tc)))
+		}
+		return otlptracegrpc.NewClient(opts...), nil
+
+	case config.OTLPClientHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(c.Endpoint),
+			otlptracehttp.WithTimeout(time.Duration(c.Timeout)),
+			otlptracehttp.WithHeaders(c.Headers),
+		}
+		if c.Compression == "gzip" {
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if tlsConfig == nil {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			tc, err := commoncfg.NewTLSConfig(tlsConfig)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tc))
+		}
+		return otlptracehttp.NewClient(opts...), nil
+	}
+	return nil, errors.Errorf("unknown client type %q", c.ClientType)
+}
+
+// Transport returns an http.RoundTripper tracing the requests of the named
+// client. Each request gets a client span and carries its trace context.
+func Transport(rt http.RoundTripper, name string) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{rt: rt, name: name}
+}
+
+type transport struct {
+	rt   http.RoundTripper
+	name string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The URL isn't recorded as it may hold secrets, such as the token of
+	// a webhook.
+	ctx, span := otel.Tracer("github.com/prometheus/alertmanager/tracing").Start(
+		req.Context(),
+		"HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("integration", t.name),
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.NetPeerNameKey.String(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// StartServerSpan starts a server span of the request, continuing the trace
+// propagated by the client if any.
+func StartServerSpan(r *http.Request, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return otel.Tracer("github.com/prometheus/alertmanager/api").Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// RecordError records the error on the span, if any, and marks the span as
+// failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+func TestTransport(t *testing.T) {
+	NewManager(log.NewNopLogger())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Transport(nil, "webhook")}
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/secret-token", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, "HTTP POST", span.Name())
+	require.Equal(t, trace.SpanKindClient, span.SpanKind())
+	require.Equal(t, codes.Error, span.Status().Code)
+	require.Contains(t, span.Attributes(), attribute.String("integration", "webhook"))
+	require.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusServiceUnavailable))
+	for _, attr := range span.Attributes() {
+		require.NotContains(t, attr.Value.Emit(), "secret-token")
+	}
+
+	// The trace context is propagated to the server.
+	require.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	// The request of the caller isn't modified.
+	require.Empty(t, req.Header.Get("traceparent"))
+}
+
+func TestManagerApplyConfig(t *testing.T) {
+	m := NewManager(log.NewNopLogger())
+	t.Cleanup(m.Stop)
+
+	for _, clientType := range []config.OTLPClientType{config.OTLPClientGRPC, config.OTLPClientHTTP} {
+		cfg := &config.Config{Tracing: &config.TracingConfig{
+			ClientType:       clientType,
+			Endpoint:         "localhost:4317",
+			SamplingFraction: 1,
+			Insecure:         true,
+			Timeout:          model.Duration(time.Second),
+		}}
+		require.NoError(t, m.ApplyConfig(cfg))
+		require.NotNil(t, m.shutdownFunc)
+		_, ok := otel.GetTracerProvider().(*tracesdk.TracerProvider)
+		require.True(t, ok)
+
+		// Applying the same configuration keeps the provider.
+		tp := otel.GetTracerProvider()
+		require.NoError(t, m.ApplyConfig(cfg))
+		require.Equal(t, tp, otel.GetTracerProvider())
+	}
+
+	// A failed update keeps the previous provider and configuration.
+	tp := otel.GetTracerProvider()
+	prev := m.config
+	bad := &config.Config{Tracing: &config.TracingConfig{
+		ClientType: config.OTLPClientHTTP,
+		Endpoint:   "localhost:4318",
+		TLSConfig:  &commoncfg.TLSConfig{CAFile: "testdata/missing.pem"},
+		Timeout:    model.Duration(time.Second),
+	}}
+	require.Error(t, m.ApplyConfig(bad))
+	require.Equal(t, tp, otel.GetTracerProvider())
+	require.Equal(t, prev, m.config)
+	require.Error(t, m.ApplyConfig(bad))
+
+	require.NoError(t, m.ApplyConfig(&config.Config{}))
+	require.Nil(t, m.shutdownFunc)
+	_, ok := otel.GetTracerProvider().(*tracesdk.TracerProvider)
+	require.False(t, ok)
+}
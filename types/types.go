@@ -43,12 +43,28 @@ type AlertStatus struct {
 	State       AlertState `json:"state"`
 	SilencedBy  []string   `json:"silencedBy"`
 	InhibitedBy []string   `json:"inhibitedBy"`
+	// Acknowledgement is set if someone acknowledged the alert. Unlike
+	// silenced or inhibited alerts, acknowledged alerts remain active: only
+	// their repeat notifications are suppressed.
+	Acknowledgement *Acknowledgement `json:"acknowledgement,omitempty"`
+	// Flapping is set while the alert changes state too often. The
+	// notifications of flapping alerts are suppressed.
+	Flapping bool `json:"flapping,omitempty"`
 
 	// For internal tracking, not exposed in the API.
 	pendingSilences []string
 	silencesVersion int
 }
 
+// Acknowledgement records that someone is working on an alert.
+type Acknowledgement struct {
+	ID        string    `json:"id"`
+	By        string    `json:"by"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 // Marker helps to mark alerts as silenced and/or inhibited.
 // All methods are goroutine-safe.
 type Marker interface {
@@ -68,6 +84,13 @@ type Marker interface {
 	// AlertStateActive. Otherwise, it sets the provided alert to
 	// AlertStateSuppressed.
 	SetInhibited(alert model.Fingerprint, alertIDs ...string)
+	// SetAcknowledged replaces the acknowledgement of the alert. A nil
+	// acknowledgement clears it. It doesn't change the AlertState of the
+	// alert.
+	SetAcknowledged(alert model.Fingerprint, ack *Acknowledgement)
+	// SetFlapping sets whether the alert is flapping. It doesn't change the
+	// AlertState of the alert.
+	SetFlapping(alert model.Fingerprint, flapping bool)
 
 	// Count alerts of the given state(s). With no state provided, count all
 	// alerts.
@@ -87,6 +110,8 @@ type Marker interface {
 	Active(model.Fingerprint) bool
 	Silenced(model.Fingerprint) (activeIDs []string, pendingIDs []string, version int, silenced bool)
 	Inhibited(model.Fingerprint) ([]string, bool)
+	Acknowledged(model.Fingerprint) (*Acknowledgement, bool)
+	Flapping(model.Fingerprint) bool
 }
 
 // NewMarker returns an instance of a Marker implementation.
@@ -122,9 +147,48 @@ func (m *memMarker) registerMetrics(r prometheus.Registerer) {
 
 	alertsActive := newAlertMetricByState(AlertStateActive)
 	alertsSuppressed := newAlertMetricByState(AlertStateSuppressed)
+	alertsAcknowledged := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "alertmanager_alerts_acknowledged",
+			Help: "How many alerts are acknowledged.",
+		},
+		func() float64 {
+			m.mtx.RLock()
+			defer m.mtx.RUnlock()
+
+			var count int
+			for _, status := range m.m {
+				if status.Acknowledgement != nil {
+					count++
+				}
+			}
+			return float64(count)
+		},
+	)
+
+	alertsFlapping := prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "alertmanager_alerts_flapping",
+			Help: "How many alerts are flapping.",
+		},
+		func() float64 {
+			m.mtx.RLock()
+			defer m.mtx.RUnlock()
+
+			var count int
+			for _, status := range m.m {
+				if status.Flapping {
+					count++
+				}
+			}
+			return float64(count)
+		},
+	)
 
 	r.MustRegister(alertsActive)
 	r.MustRegister(alertsSuppressed)
+	r.MustRegister(alertsAcknowledged)
+	r.MustRegister(alertsFlapping)
 }
 
 // Count implements Marker.
@@ -195,6 +259,42 @@ func (m *memMarker) SetInhibited(alert model.Fingerprint, ids ...string) {
 	s.State = AlertStateSuppressed
 }
 
+// SetAcknowledged implements Marker.
+func (m *memMarker) SetAcknowledged(alert model.Fingerprint, ack *Acknowledgement) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, found := m.m[alert]
+	if !found {
+		if ack == nil {
+			return
+		}
+		// The state of the alert is only known once it went through the
+		// silences and the inhibitions.
+		s = &AlertStatus{State: AlertStateUnprocessed}
+		m.m[alert] = s
+	}
+	s.Acknowledgement = ack
+}
+
+// SetFlapping implements Marker.
+func (m *memMarker) SetFlapping(alert model.Fingerprint, flapping bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	s, found := m.m[alert]
+	if !found {
+		if !flapping {
+			return
+		}
+		// The state of the alert is only known once it went through the
+		// silences and the inhibitions.
+		s = &AlertStatus{State: AlertStateUnprocessed}
+		m.m[alert] = s
+	}
+	s.Flapping = flapping
+}
+
 // Status implements Marker.
 func (m *memMarker) Status(alert model.Fingerprint) AlertStatus {
 	m.mtx.RLock()
@@ -235,6 +335,17 @@ func (m *memMarker) Inhibited(alert model.Fingerprint) ([]string, bool) {
 		s.State == AlertStateSuppressed && len(s.InhibitedBy) > 0
 }
 
+// Acknowledged implements Marker.
+func (m *memMarker) Acknowledged(alert model.Fingerprint) (*Acknowledgement, bool) {
+	s := m.Status(alert)
+	return s.Acknowledgement, s.Acknowledgement != nil
+}
+
+// Flapping implements Marker.
+func (m *memMarker) Flapping(alert model.Fingerprint) bool {
+	return m.Status(alert).Flapping
+}
+
 // Silenced returns whether the alert for the given Fingerprint is in the
 // Silenced state, any associated silence IDs, and the silences state version
 // the result is based on.
@@ -380,6 +491,12 @@ type Muter interface {
 	Mutes(model.LabelSet) bool
 }
 
+// An Acknowledger determines whether an alert is acknowledged in an
+// aggregation group.
+type Acknowledger interface {
+	Acknowledged(groupKey string, fp model.Fingerprint) (*Acknowledgement, bool)
+}
+
 // A MuteFunc is a function that implements the Muter interface.
 type MuteFunc func(model.LabelSet) bool
 